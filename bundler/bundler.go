@@ -0,0 +1,385 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+// Package bundler combines a specification that has been exploded across many files (or remote locations)
+// into a single, self-contained document.
+//
+// Every component that is referenced from another file is copied into the components of the root document
+// (or the definitions, parameters and responses of a Swagger document), and the references are rewritten to
+// point to the local copy. References to path items, and anything else that has no home in components, are
+// inlined instead.
+//
+// Split does the reverse, and breaks a document up into a file for each path item, and each component schema,
+// parameter and response.
+package bundler
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// Bundle creates a single, self-contained specification from a document that references other files or remote
+// locations. The document must have been created with a configuration that allows those references to be
+// looked up (see datamodel.DocumentConfiguration).
+//
+// The original document is not changed, the bundled specification is returned as a new *yaml.Node. Any
+// reference that cannot be resolved is left as it is, and returned as an error. If the document itself cannot be
+// built (for example, because a referenced file does not exist), then the errors from building it are returned.
+func Bundle(document libopenapi.Document) (*yaml.Node, []error) {
+	if document == nil || document.GetSpecInfo() == nil || document.GetSpecInfo().RootNode == nil {
+		return nil, []error{errors.New("unable to bundle, no document was supplied")}
+	}
+	info := document.GetSpecInfo()
+
+	var idx *index.SpecIndex
+	var sections map[string]string
+	switch info.SpecFormat {
+	case datamodel.OAS2:
+		model, errs := document.BuildV2Model()
+		if model == nil {
+			return nil, errs
+		}
+		idx = model.Model.GoLow().Index
+		sections = swaggerSections
+	case datamodel.OAS3, datamodel.OAS31:
+		model, errs := document.BuildV3Model()
+		if model == nil {
+			return nil, errs
+		}
+		idx = model.Model.Index
+		sections = openAPISections
+	default:
+		return nil, []error{fmt.Errorf("unable to bundle, unknown specification format '%s'", info.SpecFormat)}
+	}
+
+	root := utils.CopyNode(info.RootNode)
+	if root.Kind != yaml.DocumentNode || len(root.Content) == 0 || !utils.IsNodeMap(root.Content[0]) {
+		return nil, []error{errors.New("unable to bundle, the root of the document is not an object")}
+	}
+	b := &bundler{
+		root:     root.Content[0],
+		index:    idx,
+		sections: sections,
+		nodes:    make(map[string]*yaml.Node),
+		names:    make(map[string]map[string]bool),
+		bundled:  make(map[string]string),
+		inlining: make(map[string]bool),
+		docs:     map[*index.SpecIndex]string{idx: ""},
+	}
+	b.walk(b.root, idx, nil)
+	return root, b.errors
+}
+
+// BundleToYAML bundles a document using Bundle, and renders the result as YAML.
+func BundleToYAML(document libopenapi.Document) ([]byte, []error) {
+	root, errs := Bundle(document)
+	if root == nil {
+		return nil, errs
+	}
+	out, err := yaml.Marshal(root)
+	if err != nil {
+		return nil, append(errs, err)
+	}
+	return out, errs
+}
+
+// BundleToJSON bundles a document using Bundle, and renders the result as JSON.
+func BundleToJSON(document libopenapi.Document) ([]byte, []error) {
+	out, errs := BundleToYAML(document)
+	if out == nil {
+		return nil, errs
+	}
+	j, err := utils.ConvertYAMLtoJSON(out)
+	if err != nil {
+		return nil, append(errs, err)
+	}
+	return j, errs
+}
+
+// openAPISections maps each type of component to its location, in an OpenAPI 3 document.
+var openAPISections = map[string]string{
+	"schemas":         "components/schemas",
+	"responses":       "components/responses",
+	"parameters":      "components/parameters",
+	"examples":        "components/examples",
+	"requestBodies":   "components/requestBodies",
+	"headers":         "components/headers",
+	"securitySchemes": "components/securitySchemes",
+	"links":           "components/links",
+	"callbacks":       "components/callbacks",
+}
+
+// swaggerSections maps each type of component to its location, in a Swagger document.
+var swaggerSections = map[string]string{
+	"schemas":    "definitions",
+	"responses":  "responses",
+	"parameters": "parameters",
+}
+
+// schemaKeywords are the keys that contain a schema, any reference inside them is a reference to a schema.
+var schemaKeywords = map[string]bool{
+	"schema": true, "schemas": true, "definitions": true, "items": true, "properties": true,
+	"additionalProperties": true, "allOf": true, "anyOf": true, "oneOf": true, "not": true, "prefixItems": true,
+	"$defs": true, "patternProperties": true, "dependentSchemas": true, "contains": true, "if": true,
+	"then": true, "else": true, "propertyNames": true, "unevaluatedItems": true,
+	"unevaluatedProperties": true, "contentSchema": true,
+}
+
+// namedKeywords are the keys that contain a map of names (or a list), the keys inside them are names chosen
+// by the author of the specification, and not keywords.
+var namedKeywords = map[string]bool{
+	"paths": true, "webhooks": true, "properties": true, "patternProperties": true, "$defs": true,
+	"dependentSchemas": true, "definitions": true, "schemas": true, "responses": true, "parameters": true,
+	"examples": true, "requestBodies": true, "headers": true, "securitySchemes": true, "links": true,
+	"callbacks": true, "content": true, "encoding": true, "variables": true, "mapping": true,
+	"allOf": true, "anyOf": true, "oneOf": true, "prefixItems": true, "tags": true, "servers": true,
+	"security": true,
+}
+
+// componentNames are used to name components, anything else is replaced with an underscore.
+var componentNames = regexp.MustCompile(`[^a-zA-Z0-9._-]`)
+
+// bundler copies externally referenced components into the root of a specification.
+type bundler struct {
+	root     *yaml.Node
+	index    *index.SpecIndex
+	sections map[string]string
+
+	// nodes are the mapping nodes for each section (like 'components/schemas'), created when needed.
+	nodes map[string]*yaml.Node
+
+	// names are the component names used in each section.
+	names map[string]map[string]bool
+
+	// bundled maps the location of every component that has been copied, to its new local reference.
+	bundled map[string]string
+
+	// inlining contains the locations that are being inlined, to stop circular references inlining forever.
+	inlining map[string]bool
+
+	// docs are the locations of the documents each index was built from, the root document is empty.
+	docs map[*index.SpecIndex]string
+
+	errors []error
+}
+
+// walk looks for references in a node, path is the location of the node in the bundled specification.
+// References are resolved using idx, which is the index of the document the node was copied from.
+func (b *bundler) walk(node *yaml.Node, idx *index.SpecIndex, path []string) {
+	switch node.Kind {
+	case yaml.SequenceNode:
+		for i, n := range node.Content {
+			b.walk(n, idx, appendPath(path, strconv.Itoa(i)))
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			k, v := node.Content[i], node.Content[i+1]
+			if k.Value == "$ref" && v.Kind == yaml.ScalarNode {
+				if b.reference(node, v, idx, path) {
+					return
+				}
+				continue
+			}
+			if k.Value == "example" || strings.HasPrefix(k.Value, "x-") {
+				continue
+			}
+			b.walk(v, idx, appendPath(path, k.Value))
+		}
+	}
+}
+
+// reference bundles the target of a reference, and rewrites the reference to point to it. If the target is
+// inlined instead, then node is replaced, and true is returned.
+func (b *bundler) reference(node, ref *yaml.Node, idx *index.SpecIndex, path []string) bool {
+	if idx == b.index && strings.HasPrefix(ref.Value, "#") {
+		return false // local to the root document, nothing to do.
+	}
+	target, owner, key := b.resolve(ref, idx)
+	if target == nil {
+		return false
+	}
+
+	section := b.section(path)
+	if section == "" {
+		if b.inlining[key] {
+			b.errors = append(b.errors, fmt.Errorf("unable to inline circular reference '%s' (line %d, column %d)",
+				ref.Value, ref.Line, ref.Column))
+			return false
+		}
+		b.inlining[key] = true
+		*node = *utils.CopyNode(target)
+		b.walk(node, owner, path)
+		delete(b.inlining, key)
+		return true
+	}
+
+	if local, ok := b.bundled[key]; ok {
+		ref.Value = local
+		return false
+	}
+	location := b.sections[section]
+	name := b.name(location, ref.Value)
+	local := fmt.Sprintf("#/%s/%s", location, name)
+	b.bundled[key] = local
+	ref.Value = local
+
+	component := utils.CopyNode(target)
+	sectionNode := b.sectionNode(location)
+	sectionNode.Content = append(sectionNode.Content, utils.CreateStringNode(name), component)
+	b.walk(component, owner, append(strings.Split(location, "/"), name))
+	return false
+}
+
+// resolve finds the target of a reference, the index of the document that contains it, and its location
+// (the location of the document and the fragment), which is the same no matter where it is referenced from.
+func (b *bundler) resolve(ref *yaml.Node, idx *index.SpecIndex) (*yaml.Node, *index.SpecIndex, string) {
+	found := idx.FindComponent(ref.Value, ref)
+	if found == nil || found.Node == nil {
+		b.errors = append(b.errors, fmt.Errorf("unable to resolve reference '%s' (line %d, column %d)",
+			ref.Value, ref.Line, ref.Column))
+		return nil, nil, ""
+	}
+	uri, fragment, _ := strings.Cut(ref.Value, "#")
+	owner, doc := idx, b.docs[idx]
+	if uri != "" {
+		owner = idx.GetAllExternalIndexes()[uri]
+		if owner == nil {
+			owner = idx.SearchAncestryForSeenURI(uri)
+		}
+		if owner == nil {
+			b.errors = append(b.errors, fmt.Errorf("unable to find the index for reference '%s' (line %d, "+
+				"column %d)", ref.Value, ref.Line, ref.Column))
+			return nil, nil, ""
+		}
+		doc = documentLocation(doc, uri)
+		if _, ok := b.docs[owner]; !ok {
+			b.docs[owner] = doc
+		}
+	}
+	target := found.Node
+	if target.Kind == yaml.DocumentNode && len(target.Content) > 0 {
+		target = target.Content[0]
+	}
+	return target, owner, doc + "#" + fragment
+}
+
+// documentLocation resolves the location of a referenced document, relative to the document that
+// references it (an empty location is the root document).
+func documentLocation(from, uri string) string {
+	if u, err := url.Parse(uri); err == nil && u.IsAbs() {
+		return uri
+	}
+	if f, err := url.Parse(from); err == nil && f.IsAbs() {
+		if u, err := url.Parse(uri); err == nil {
+			return f.ResolveReference(u).String()
+		}
+	}
+	return path.Join(path.Dir(from), uri)
+}
+
+// section works out which type of component a reference at a location points to. An empty string is
+// returned if the component does not have a section (like a path item), and should be inlined.
+func (b *bundler) section(path []string) string {
+	var section string
+	for i, p := range path {
+		if i > 0 && namedKeywords[path[i-1]] {
+			continue // a name, not a keyword.
+		}
+		if schemaKeywords[p] {
+			section = "schemas"
+			break
+		}
+	}
+	n := len(path)
+	switch {
+	case section != "":
+	case n > 0 && path[n-1] == "requestBody":
+		section = "requestBodies"
+	case n > 1:
+		switch path[n-2] {
+		case "parameters", "responses", "requestBodies", "headers", "examples", "links", "callbacks",
+			"securitySchemes":
+			section = path[n-2]
+		}
+	}
+	if _, ok := b.sections[section]; !ok {
+		return ""
+	}
+	return section
+}
+
+// name creates a unique name for a component, from the last segment of its reference (or the name of the
+// file, if the whole file is referenced).
+func (b *bundler) name(location, ref string) string {
+	uri, fragment, _ := strings.Cut(ref, "#")
+	var name string
+	if segments := strings.Split(fragment, "/"); fragment != "" {
+		name = utils.UnescapePointerSegment(segments[len(segments)-1])
+	}
+	if name == "" {
+		uri, _, _ = strings.Cut(uri, "?")
+		base := path.Base(uri)
+		name = strings.TrimSuffix(base, path.Ext(base))
+	}
+	name = componentNames.ReplaceAllString(name, "_")
+	if name == "" || name == "." {
+		name = "component"
+	}
+
+	b.sectionNode(location) // make sure the names already used in the section are known.
+	taken := b.names[location]
+	unique := name
+	for i := 2; taken[unique]; i++ {
+		unique = fmt.Sprintf("%s_%d", name, i)
+	}
+	taken[unique] = true
+	return unique
+}
+
+// sectionNode returns the mapping node for a section of the root document, creating it if needed.
+func (b *bundler) sectionNode(location string) *yaml.Node {
+	if n := b.nodes[location]; n != nil {
+		return n
+	}
+	n := b.root
+	for _, key := range strings.Split(location, "/") {
+		n = mapValue(n, key)
+	}
+	b.nodes[location] = n
+	taken := make(map[string]bool)
+	for i := 0; i < len(n.Content); i += 2 {
+		taken[n.Content[i].Value] = true
+	}
+	b.names[location] = taken
+	return n
+}
+
+// mapValue returns the value of a key in a mapping node, a new mapping node is added if the key is missing.
+func mapValue(m *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	v := utils.CreateEmptyMapNode()
+	m.Content = append(m.Content, utils.CreateStringNode(key), v)
+	return v
+}
+
+// appendPath returns a new path with a segment added, the original path is not changed.
+func appendPath(path []string, segment string) []string {
+	p := make([]string, len(path), len(path)+1)
+	copy(p, path)
+	return append(p, segment)
+}
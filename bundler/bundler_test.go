@@ -0,0 +1,169 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package bundler
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+var explodedSpec = fstest.MapFS{
+	"openapi.yaml": {Data: []byte(`openapi: 3.0.3
+info:
+  title: pets
+  version: 1.0.0
+paths:
+  /pets:
+    $ref: 'paths.yaml#/pets'
+  /owners:
+    get:
+      parameters:
+        - $ref: 'parameters.yaml#/limit'
+      responses:
+        "200":
+          description: owners
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: 'models/owner.yaml#/Owner'
+components:
+  schemas:
+    Owner:
+      type: string`)},
+	"paths.yaml": {Data: []byte(`pets:
+  get:
+    parameters:
+      - $ref: 'parameters.yaml#/limit'
+    responses:
+      "200":
+        $ref: 'responses.yaml#/PetList'`)},
+	"parameters.yaml": {Data: []byte(`limit:
+  name: limit
+  in: query
+  schema:
+    type: integer`)},
+	"responses.yaml": {Data: []byte(`PetList:
+  description: pets
+  content:
+    application/json:
+      schema:
+        type: array
+        items:
+          $ref: 'models/pet.yaml'`)},
+	"models/pet.yaml": {Data: []byte(`type: object
+properties:
+  owner:
+    $ref: 'owner.yaml#/Owner'
+  friend:
+    $ref: 'pet.yaml'`)},
+	"models/owner.yaml": {Data: []byte(`Owner:
+  type: object
+  properties:
+    name:
+      $ref: '#/Name'
+Name:
+  type: string`)},
+}
+
+func bundleDocument(t *testing.T, fs fstest.MapFS) libopenapi.Document {
+	doc, err := libopenapi.NewDocumentWithConfiguration(fs["openapi.yaml"].Data, &datamodel.DocumentConfiguration{
+		AllowFileReferences: true,
+		FileSystem:          fs,
+	})
+	assert.NoError(t, err)
+	return doc
+}
+
+func TestBundle(t *testing.T) {
+	root, errs := Bundle(bundleDocument(t, explodedSpec))
+	assert.Empty(t, errs)
+
+	out, _ := yaml.Marshal(root)
+	var spec map[string]any
+	assert.NoError(t, yaml.Unmarshal(out, &spec))
+
+	paths := spec["paths"].(map[string]any)
+	pets := paths["/pets"].(map[string]any)["get"].(map[string]any)
+	assert.Equal(t, "#/components/parameters/limit", pets["parameters"].([]any)[0].(map[string]any)["$ref"])
+	assert.Equal(t, "#/components/responses/PetList",
+		pets["responses"].(map[string]any)["200"].(map[string]any)["$ref"])
+	owners := paths["/owners"].(map[string]any)["get"].(map[string]any)
+	assert.Equal(t, "#/components/parameters/limit", owners["parameters"].([]any)[0].(map[string]any)["$ref"])
+
+	components := spec["components"].(map[string]any)
+	schemas := components["schemas"].(map[string]any)
+	assert.Len(t, schemas, 4)
+	assert.Equal(t, "string", schemas["Owner"].(map[string]any)["type"])
+	assert.Equal(t, "#/components/schemas/Owner_2", schemas["pet"].(map[string]any)["properties"].(map[string]any)["owner"].(map[string]any)["$ref"])
+	assert.Equal(t, "#/components/schemas/pet", schemas["pet"].(map[string]any)["properties"].(map[string]any)["friend"].(map[string]any)["$ref"])
+	assert.Equal(t, "#/components/schemas/Name", schemas["Owner_2"].(map[string]any)["properties"].(map[string]any)["name"].(map[string]any)["$ref"])
+	assert.Equal(t, "string", schemas["Name"].(map[string]any)["type"])
+
+	assert.Len(t, components["parameters"], 1)
+	assert.Len(t, components["responses"], 1)
+	assert.Equal(t, "#/components/schemas/pet", components["responses"].(map[string]any)["PetList"].(map[string]any)["content"].(map[string]any)["application/json"].(map[string]any)["schema"].(map[string]any)["items"].(map[string]any)["$ref"])
+
+	// the bundled document is self-contained, and can be loaded without any file references.
+	bundled, err := libopenapi.NewDocument(out)
+	assert.NoError(t, err)
+	model, errs := bundled.BuildV3Model()
+	assert.Empty(t, errs)
+	assert.Equal(t, 4, model.Model.Components.Schemas.Len())
+}
+
+func TestBundle_Missing(t *testing.T) {
+	fs := fstest.MapFS{"openapi.yaml": {Data: []byte(`openapi: 3.0.3
+info:
+  title: pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          $ref: 'missing.yaml#/PetList'`)}}
+
+	root, errs := Bundle(bundleDocument(t, fs))
+	assert.Nil(t, root)
+	assert.NotEmpty(t, errs)
+}
+
+func TestBundle_Swagger(t *testing.T) {
+	fs := fstest.MapFS{
+		"openapi.yaml": {Data: []byte(`swagger: "2.0"
+info:
+  title: pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: pets
+          schema:
+            $ref: 'definitions.yaml#/Pet'`)},
+		"definitions.yaml": {Data: []byte(`Pet:
+  type: object`)},
+	}
+	out, errs := BundleToYAML(bundleDocument(t, fs))
+	assert.Empty(t, errs)
+	assert.Contains(t, string(out), "$ref: '#/definitions/Pet'")
+	assert.Contains(t, string(out), "definitions:\n    Pet:\n        type: object")
+}
+
+func TestBundleToJSON(t *testing.T) {
+	out, errs := BundleToJSON(bundleDocument(t, explodedSpec))
+	assert.Empty(t, errs)
+	assert.Contains(t, string(out), `"$ref":"#/components/responses/PetList"`)
+
+	_, errs = BundleToJSON(nil)
+	assert.Len(t, errs, 1)
+}
@@ -0,0 +1,196 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package bundler
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// RootFileName is the name of the root document created by Split.
+const RootFileName = "openapi.yaml"
+
+// splitSections are the sections of components that are split into their own files.
+var splitSections = []string{"schemas", "parameters", "responses"}
+
+// Split breaks an OpenAPI 3 document up into many files, which is the reverse of Bundle. Each path item is
+// moved into 'paths/', and each component schema, parameter and response is moved into
+// 'components/schemas/', 'components/parameters/' and 'components/responses/'. Everything else stays in the
+// root document (RootFileName).
+//
+// The files are wired together with relative references, so the root document can be loaded back (with
+// AllowFileReferences enabled) into an equivalent model. The files are returned as YAML, keyed by their path
+// relative to the root document.
+func Split(doc *v3high.Document) (map[string][]byte, error) {
+	if doc == nil {
+		return nil, errors.New("unable to split, no document was supplied")
+	}
+	rendered, err := doc.MarshalYAML()
+	if err != nil {
+		return nil, err
+	}
+	root, ok := rendered.(*yaml.Node)
+	if !ok || !utils.IsNodeMap(root) {
+		return nil, errors.New("unable to split, the document did not render as an object")
+	}
+
+	s := &splitter{
+		files:    map[string]*yaml.Node{RootFileName: root},
+		pointers: make(map[string]string),
+		names:    make(map[string]bool),
+	}
+	if paths := findValue(root, "paths"); paths != nil {
+		for i := 0; i+1 < len(paths.Content); i += 2 {
+			p := paths.Content[i].Value
+			if strings.HasPrefix(p, "x-") {
+				continue
+			}
+			s.move(paths, i+1, "#/paths/"+utils.EscapePointerSegment(p), "paths", p)
+		}
+	}
+	if components := findValue(root, "components"); components != nil {
+		for _, section := range splitSections {
+			n := findValue(components, section)
+			if n == nil {
+				continue
+			}
+			for i := 0; i+1 < len(n.Content); i += 2 {
+				name := n.Content[i].Value
+				s.move(n, i+1, fmt.Sprintf("#/components/%s/%s", section, utils.EscapePointerSegment(name)),
+					"components/"+section, name)
+			}
+		}
+	}
+
+	files := make(map[string][]byte, len(s.files))
+	for name, node := range s.files {
+		s.rewrite(node, name)
+		out, err := yaml.Marshal(node)
+		if err != nil {
+			return nil, err
+		}
+		files[name] = out
+	}
+	return files, nil
+}
+
+// SplitToDirectory splits a document using Split, and writes the files into a directory (which is created
+// if it does not exist).
+func SplitToDirectory(doc *v3high.Document, dir string) error {
+	files, err := Split(doc)
+	if err != nil {
+		return err
+	}
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		file := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(file, files[name], 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// splitter moves parts of a rendered document into their own files.
+type splitter struct {
+	// files are the root of each file, keyed by the path of the file.
+	files map[string]*yaml.Node
+
+	// pointers maps the JSON pointer of everything that has been moved, to the file it was moved into.
+	pointers map[string]string
+
+	// names are the file names that have been used.
+	names map[string]bool
+}
+
+// move moves the value at index i of a mapping node into a new file, and replaces it with a reference.
+func (s *splitter) move(m *yaml.Node, i int, pointer, dir, name string) {
+	file := s.fileName(dir, name)
+	s.files[file] = m.Content[i]
+	s.pointers[pointer] = file
+	m.Content[i] = utils.CreateRefNode(file)
+}
+
+// fileName creates a unique file name in a directory.
+func (s *splitter) fileName(dir, name string) string {
+	name = strings.NewReplacer("{", "", "}", "").Replace(strings.Trim(name, "/"))
+	name = componentNames.ReplaceAllString(name, "_")
+	if name == "" {
+		name = "root"
+	}
+	file := path.Join(dir, name+".yaml")
+	for i := 2; s.names[file]; i++ {
+		file = path.Join(dir, fmt.Sprintf("%s_%d.yaml", name, i))
+	}
+	s.names[file] = true
+	return file
+}
+
+// rewrite changes every local reference in a file, so it points to the file its target was moved into.
+func (s *splitter) rewrite(node *yaml.Node, file string) {
+	switch node.Kind {
+	case yaml.SequenceNode:
+		for _, n := range node.Content {
+			s.rewrite(n, file)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			k, v := node.Content[i], node.Content[i+1]
+			if k.Value == "$ref" && v.Kind == yaml.ScalarNode && strings.HasPrefix(v.Value, "#") {
+				v.Value = s.reference(v.Value, file)
+				continue
+			}
+			s.rewrite(v, file)
+		}
+	}
+}
+
+// reference works out what a local reference from the original document becomes, inside a file.
+func (s *splitter) reference(ref, file string) string {
+	target, fragment := RootFileName, strings.TrimPrefix(ref, "#")
+	var longest string
+	for pointer, f := range s.pointers {
+		if (ref == pointer || strings.HasPrefix(ref, pointer+"/")) && len(pointer) > len(longest) {
+			longest, target, fragment = pointer, f, strings.TrimPrefix(ref, pointer)
+		}
+	}
+	if target == file && fragment != "" {
+		return "#" + fragment
+	}
+	rel, err := filepath.Rel(filepath.Dir(filepath.FromSlash(file)), filepath.FromSlash(target))
+	if err != nil {
+		return ref
+	}
+	rel = filepath.ToSlash(rel)
+	if fragment != "" {
+		return rel + "#" + fragment
+	}
+	return rel
+}
+
+// findValue returns the value of a key in a mapping node, or nil if the key is missing.
+func findValue(m *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package bundler
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi/datamodel"
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func loadBurgerShop(t *testing.T) *v3high.Document {
+	spec, err := os.ReadFile("../test_specs/burgershop.openapi.yaml")
+	assert.NoError(t, err)
+	doc, err := libopenapi.NewDocument(spec)
+	assert.NoError(t, err)
+	model, errs := doc.BuildV3Model()
+	assert.Empty(t, errs)
+	return &model.Model
+}
+
+func TestSplit(t *testing.T) {
+	original := loadBurgerShop(t)
+	files, err := Split(original)
+	assert.NoError(t, err)
+
+	assert.Contains(t, files, RootFileName)
+	assert.Contains(t, files, "paths/burgers.yaml")
+	assert.Contains(t, files, "paths/burgers_burgerId.yaml")
+	assert.Contains(t, files, "components/schemas/Burger.yaml")
+	assert.Contains(t, string(files[RootFileName]), "$ref: 'paths/burgers.yaml'")
+	assert.Contains(t, string(files["paths/burgers.yaml"]), "$ref: ../components/schemas/Burger.yaml")
+
+	fs := fstest.MapFS{}
+	for name, data := range files {
+		fs[name] = &fstest.MapFile{Data: data}
+	}
+	doc, err := libopenapi.NewDocumentWithConfiguration(files[RootFileName], &datamodel.DocumentConfiguration{
+		AllowFileReferences: true,
+		FileSystem:          fs,
+	})
+	assert.NoError(t, err)
+	model, errs := doc.BuildV3Model()
+	assert.Empty(t, errs)
+	loaded := &model.Model
+
+	assert.Equal(t, original.Paths.PathItems.Keys(), loaded.Paths.PathItems.Keys())
+	for _, p := range original.Paths.PathItems.Keys() {
+		o, l := original.Paths.PathItems.GetOrZero(p), loaded.Paths.PathItems.GetOrZero(p)
+		for method, op := range o.GetOperations() {
+			assert.Equal(t, op.OperationId, l.GetOperations()[method].OperationId)
+		}
+	}
+	assert.Equal(t, original.Components.Schemas.Keys(), loaded.Components.Schemas.Keys())
+	for _, name := range original.Components.Schemas.Keys() {
+		o := original.Components.Schemas.GetOrZero(name).Schema()
+		l := loaded.Components.Schemas.GetOrZero(name).Schema()
+		assert.Equal(t, o.Type, l.Type, name)
+		assert.Equal(t, o.Properties.Keys(), l.Properties.Keys(), name)
+	}
+	burger := loaded.Paths.PathItems.GetOrZero("/burgers").Post.RequestBody.Content.GetOrZero("application/json").Schema
+	assert.Equal(t, original.Components.Schemas.GetOrZero("Burger").Schema().Properties.Keys(),
+		burger.Schema().Properties.Keys())
+}
+
+func TestSplitToDirectory(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, SplitToDirectory(loadBurgerShop(t), dir))
+
+	spec, err := os.ReadFile(filepath.Join(dir, RootFileName))
+	assert.NoError(t, err)
+	doc, err := libopenapi.NewDocumentWithConfiguration(spec, &datamodel.DocumentConfiguration{
+		AllowFileReferences: true,
+		BasePath:            dir,
+	})
+	assert.NoError(t, err)
+	_, errs := doc.BuildV3Model()
+	assert.Empty(t, errs)
+
+	_, err = Split(nil)
+	assert.Error(t, err)
+}
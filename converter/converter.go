@@ -0,0 +1,166 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+// Package converter translates specifications between versions of OpenAPI.
+//
+// ConvertSwagger converts a Swagger / OpenAPI 2 document into an OpenAPI 3 document. Not everything in Swagger
+// has an equivalent in OpenAPI 3, anything that cannot be translated is dropped, and reported as a
+// *ConversionWarning that contains the line and column of the construct in the original specification.
+//
+// UpgradeDocument upgrades an OpenAPI 3.0 document model to OpenAPI 3.1 in place, and Upgrade renders and
+// reloads the upgraded document. Every change that is made is reported as a *Rewrite.
+// DowngradeDocument does the reverse, anything in an OpenAPI 3.1 document that cannot be expressed in
+// OpenAPI 3.0 is dropped and reported as a *ConversionWarning.
+package converter
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// ConversionWarning describes something in a specification that could not be converted, or was converted
+// in a way that changes its meaning. Line and Column point to the original specification, they are zero if
+// the position is not known (for example, when a model was built by hand).
+type ConversionWarning struct {
+	// Location is a JSON pointer to the object the warning is about, it is empty if the warning is not about
+	// a single object (warnings from ConvertSwagger do not have a location).
+	Location string
+
+	// Message describes what could not be converted, and what happened to it.
+	Message string
+
+	// Line is the line in the original specification.
+	Line int
+
+	// Column is the column in the original specification.
+	Column int
+}
+
+// String returns a readable representation of the warning, including the position if known.
+func (w *ConversionWarning) String() string {
+	message := w.Message
+	if w.Location != "" {
+		message = fmt.Sprintf("%s: %s", w.Location, w.Message)
+	}
+	if w.Line == 0 {
+		return message
+	}
+	return fmt.Sprintf("%s (line %d, column %d)", message, w.Line, w.Column)
+}
+
+// warnings collects ConversionWarning instances as a conversion runs.
+type warnings struct {
+	warnings []*ConversionWarning
+}
+
+// warn adds a warning, positioned at a node from the original specification (which can be nil).
+func (w *warnings) warn(node *yaml.Node, format string, args ...any) {
+	w.warnAt("", node, format, args...)
+}
+
+// warnAt adds a warning about the object at a location, positioned at a node from the original specification.
+func (w *warnings) warnAt(location string, node *yaml.Node, format string, args ...any) {
+	warning := &ConversionWarning{Location: location, Message: fmt.Sprintf(format, args...)}
+	if node != nil {
+		warning.Line, warning.Column = node.Line, node.Column
+	}
+	w.warnings = append(w.warnings, warning)
+}
+
+// addNode adds a key and value to a mapping node, nil values are skipped.
+func addNode(m *yaml.Node, key string, value *yaml.Node) {
+	if value == nil {
+		return
+	}
+	m.Content = append(m.Content, utils.CreateStringNode(key), value)
+}
+
+// addString adds a string to a mapping node, empty strings are skipped.
+func addString(m *yaml.Node, key, value string) {
+	if value != "" {
+		addNode(m, key, utils.CreateStringNode(value))
+	}
+}
+
+// addBool adds a boolean to a mapping node, if it is true.
+func addBool(m *yaml.Node, key string, value bool) {
+	if value {
+		addNode(m, key, utils.CreateBoolNode("true"))
+	}
+}
+
+// addValue encodes a value and adds it to a mapping node, nil values are skipped.
+func addValue(m *yaml.Node, key string, value any) {
+	addNode(m, key, encodeNode(value))
+}
+
+// encodeNode encodes any value (including high-level models that implement yaml.Marshaler) into a node.
+func encodeNode(value any) *yaml.Node {
+	if value == nil {
+		return nil
+	}
+	if v := reflect.ValueOf(value); v.Kind() == reflect.Pointer && v.IsNil() {
+		return nil
+	}
+	n := new(yaml.Node)
+	if err := n.Encode(value); err != nil {
+		return nil
+	}
+	return n
+}
+
+// stringsNode creates a sequence node from a slice of strings.
+func stringsNode(values []string) *yaml.Node {
+	seq := utils.CreateEmptySequenceNode()
+	for _, v := range values {
+		seq.Content = append(seq.Content, utils.CreateStringNode(v))
+	}
+	return seq
+}
+
+// addExtensions adds extensions to a mapping node, in the order they appear in the original specification.
+func addExtensions[L any](m *yaml.Node, extensions map[string]any, lowExtensions map[low.KeyReference[string]]L) {
+	for _, k := range orderedKeys(extensions, lowExtensions) {
+		n := encodeNode(extensions[k])
+		if n == nil {
+			n = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}
+		}
+		addNode(m, k, n)
+	}
+}
+
+// orderedKeys returns the keys of a high-level map in the order they appear in the original specification.
+// Keys that cannot be found in the low-level map (for example, because they were added by hand) are sorted
+// and returned last.
+func orderedKeys[H any, L any](m map[string]H, lowMap map[low.KeyReference[string]]L) []string {
+	positions := make(map[string][2]int, len(lowMap))
+	for k := range lowMap {
+		if k.KeyNode != nil {
+			positions[k.Value] = [2]int{k.KeyNode.Line, k.KeyNode.Column}
+		}
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		pi, iok := positions[keys[i]]
+		pj, jok := positions[keys[j]]
+		if iok != jok {
+			return iok
+		}
+		if iok && pi != pj {
+			if pi[0] != pj[0] {
+				return pi[0] < pj[0]
+			}
+			return pi[1] < pj[1]
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
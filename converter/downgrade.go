@@ -0,0 +1,342 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package converter
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+	lowbase "github.com/pb33f/libopenapi/datamodel/low/base"
+	"gopkg.in/yaml.v3"
+)
+
+// DowngradeVersion is the version of OpenAPI that 3.1 documents are downgraded to.
+const DowngradeVersion = "3.0.3"
+
+// DowngradeDocument downgrades an OpenAPI 3.1 document model to OpenAPI 3.0, in place.
+//
+//   - the openapi version becomes 3.0.3.
+//   - a 'null' type becomes 'nullable: true', and multiple types become an 'anyOf' of each type.
+//   - a {type: 'null'} schema in 'anyOf' or 'oneOf' becomes 'nullable: true', a single remaining schema (such
+//     as a reference) is moved into 'allOf'.
+//   - numeric exclusiveMinimum and exclusiveMaximum values are replaced with boolean ones.
+//   - the first of the 'examples' of a schema becomes its 'example', and 'const' becomes a single value 'enum'.
+//   - 'contentEncoding: base64' becomes 'format: byte', and 'contentMediaType' becomes 'format: binary'.
+//
+// Everything that cannot be expressed in OpenAPI 3.0 ('webhooks', 'jsonSchemaDialect', 'prefixItems',
+// 'if', 'then', 'else' and other JSON Schema keywords) is dropped, and reported as a *ConversionWarning. The
+// changes that were made are returned as a *Rewrite. The model is changed, but the low-level model and the index
+// are not, use RenderAndReload to build a new document from the downgraded model.
+func DowngradeDocument(doc *v3high.Document) ([]*Rewrite, []*ConversionWarning, error) {
+	if doc == nil {
+		return nil, nil, errors.New("unable to downgrade, no document was supplied")
+	}
+	if !strings.HasPrefix(doc.Version, "3.1") {
+		return nil, nil, fmt.Errorf("unable to downgrade, the document is version '%s', only 3.1 documents "+
+			"can be downgraded", doc.Version)
+	}
+	d := new(downgrader)
+	d.document(doc)
+
+	w := &schemaWalker{visit: d.schema}
+	w.document(doc)
+	return d.rewrites.rewrites, d.warnings.warnings, nil
+}
+
+// downgrader rewrites OpenAPI 3.1 documents into OpenAPI 3.0 documents.
+type downgrader struct {
+	rewrites
+	warnings
+}
+
+func (d *downgrader) document(doc *v3high.Document) {
+	var version, dialect *yaml.Node
+	l := doc.GoLow()
+	if l != nil {
+		version, dialect = l.Version.ValueNode, l.JsonSchemaDialect.KeyNode
+	}
+	d.rewrite("/openapi", version, "version '%s' has been downgraded to '%s'", doc.Version, DowngradeVersion)
+	doc.Version = DowngradeVersion
+
+	if doc.JsonSchemaDialect != "" {
+		d.warnAt("/jsonSchemaDialect", dialect, "'jsonSchemaDialect' cannot be expressed in OpenAPI 3.0, "+
+			"it has been dropped")
+		doc.JsonSchemaDialect = ""
+	}
+	if doc.Info != nil && doc.Info.Summary != "" {
+		var summary *yaml.Node
+		if doc.Info.GoLow() != nil {
+			summary = doc.Info.GoLow().Summary.KeyNode
+		}
+		d.warnAt("/info", summary, "'summary' cannot be expressed in OpenAPI 3.0, it has been dropped")
+		doc.Info.Summary = ""
+	}
+	if doc.Webhooks.Len() > 0 {
+		for _, name := range doc.Webhooks.Keys() {
+			var key *yaml.Node
+			if l != nil {
+				k, _ := lowEntry(l.Webhooks.Value, name)
+				key = k.KeyNode
+			}
+			d.warnAt(pointer("/webhooks", name), key, "webhook '%s' cannot be expressed in OpenAPI 3.0, "+
+				"it has been dropped", name)
+		}
+		doc.Webhooks = nil
+	}
+}
+
+func (d *downgrader) schema(location string, s *base.Schema) {
+	l := s.GoLow()
+	if l == nil {
+		l = new(lowbase.Schema)
+	}
+
+	d.nullBranch(location, l.AnyOf.KeyNode, "anyOf", &s.AnyOf, s)
+	d.nullBranch(location, l.OneOf.KeyNode, "oneOf", &s.OneOf, s)
+	d.types(location, l.Type.KeyNode, s)
+
+	s.Minimum, s.ExclusiveMinimum = d.exclusive(location, l.ExclusiveMinimum.KeyNode, "exclusiveMinimum",
+		"minimum", s.Minimum, s.ExclusiveMinimum, func(exclusive, limit float64) bool { return exclusive >= limit })
+	s.Maximum, s.ExclusiveMaximum = d.exclusive(location, l.ExclusiveMaximum.KeyNode, "exclusiveMaximum",
+		"maximum", s.Maximum, s.ExclusiveMaximum, func(exclusive, limit float64) bool { return exclusive <= limit })
+
+	if len(s.Examples) > 0 {
+		node := l.Examples.KeyNode
+		switch {
+		case s.Example != nil:
+			d.warnAt(location, node, "'examples' cannot be expressed in OpenAPI 3.0 alongside 'example', "+
+				"it has been dropped")
+		default:
+			s.Example = s.Examples[0]
+			d.rewrite(location, node, "'examples' has been replaced with 'example'")
+			if len(s.Examples) > 1 {
+				d.warnAt(location, node, "OpenAPI 3.0 schemas only have a single example, %d other examples "+
+					"have been dropped", len(s.Examples)-1)
+			}
+		}
+		s.Examples = nil
+	}
+
+	if s.Const != nil || l.Const.KeyNode != nil {
+		node := l.Const.KeyNode
+		if len(s.Enum) == 0 {
+			s.Enum = []any{s.Const}
+			d.rewrite(location, node, "'const' has been replaced with a single value 'enum'")
+		} else {
+			d.warnAt(location, node, "'const' cannot be expressed in OpenAPI 3.0 alongside 'enum', "+
+				"it has been dropped")
+		}
+		s.Const = nil
+	}
+
+	d.content(location, l, s)
+
+	if s.Items != nil && s.Items.IsB() {
+		if s.Items.B {
+			d.rewrite(location, l.Items.KeyNode, "'items: true' has been removed, it has no effect")
+		} else {
+			d.warnAt(location, l.Items.KeyNode, "'items: false' cannot be expressed in OpenAPI 3.0, "+
+				"it has been dropped")
+		}
+		s.Items = nil
+	}
+
+	keywords := []struct {
+		keyword string
+		node    *yaml.Node
+		set     bool
+		drop    func()
+	}{
+		{"$schema", l.SchemaTypeRef.KeyNode, s.SchemaTypeRef != "", func() { s.SchemaTypeRef = "" }},
+		{"$id", l.Id.KeyNode, s.Id != "", func() { s.Id = "" }},
+		{"$anchor", l.Anchor.KeyNode, s.Anchor != "", func() { s.Anchor = "" }},
+		{"$dynamicRef", l.DynamicRef.KeyNode, s.DynamicRef != "", func() { s.DynamicRef = "" }},
+		{"$dynamicAnchor", l.DynamicAnchor.KeyNode, s.DynamicAnchor != "", func() { s.DynamicAnchor = "" }},
+		{"$vocabulary", l.Vocabulary.KeyNode, s.Vocabulary.Len() > 0, func() { s.Vocabulary = nil }},
+		{"$comment", l.Comment.KeyNode, s.Comment != "", func() { s.Comment = "" }},
+		{"$defs", l.Defs.KeyNode, s.Defs.Len() > 0, func() { s.Defs = nil }},
+		{"prefixItems", l.PrefixItems.KeyNode, len(s.PrefixItems) > 0, func() { s.PrefixItems = nil }},
+		{"contains", l.Contains.KeyNode, s.Contains != nil, func() { s.Contains = nil }},
+		{"minContains", l.MinContains.KeyNode, s.MinContains != nil, func() { s.MinContains = nil }},
+		{"maxContains", l.MaxContains.KeyNode, s.MaxContains != nil, func() { s.MaxContains = nil }},
+		{"if", l.If.KeyNode, s.If != nil, func() { s.If = nil }},
+		{"then", l.Then.KeyNode, s.Then != nil, func() { s.Then = nil }},
+		{"else", l.Else.KeyNode, s.Else != nil, func() { s.Else = nil }},
+		{"dependentSchemas", l.DependentSchemas.KeyNode, s.DependentSchemas.Len() > 0,
+			func() { s.DependentSchemas = nil }},
+		{"dependentRequired", l.DependentRequired.KeyNode, s.DependentRequired.Len() > 0,
+			func() { s.DependentRequired = nil }},
+		{"patternProperties", l.PatternProperties.KeyNode, s.PatternProperties.Len() > 0,
+			func() { s.PatternProperties = nil }},
+		{"propertyNames", l.PropertyNames.KeyNode, s.PropertyNames != nil, func() { s.PropertyNames = nil }},
+		{"unevaluatedItems", l.UnevaluatedItems.KeyNode, s.UnevaluatedItems != nil,
+			func() { s.UnevaluatedItems = nil }},
+		{"unevaluatedProperties", l.UnevaluatedProperties.KeyNode, s.UnevaluatedProperties != nil,
+			func() { s.UnevaluatedProperties = nil }},
+		{"contentSchema", l.ContentSchema.KeyNode, s.ContentSchema != nil, func() { s.ContentSchema = nil }},
+	}
+	for _, k := range keywords {
+		if k.set {
+			d.warnAt(location, k.node, "'%s' cannot be expressed in OpenAPI 3.0, it has been dropped", k.keyword)
+			k.drop()
+		}
+	}
+}
+
+// types collapses a 3.1 type array into a single type, and 'nullable'. Multiple types (other than 'null')
+// become an 'anyOf' with a schema for each type, which is nested in 'allOf' when the schema already has an
+// 'anyOf'.
+func (d *downgrader) types(location string, node *yaml.Node, s *base.Schema) {
+	var types []string
+	var nullable bool
+	for _, t := range s.Type {
+		if t == "null" {
+			nullable = true
+		} else {
+			types = append(types, t)
+		}
+	}
+	switch {
+	case len(types) == 0:
+		if nullable {
+			d.warnAt(location, node, "type 'null' cannot be expressed in OpenAPI 3.0 without another type, "+
+				"it has been dropped")
+			s.Type = nil
+		}
+		return
+	case len(types) == 1:
+		s.Type = types
+	default:
+		var typed []*base.SchemaProxy
+		for _, t := range types {
+			alternative := &base.Schema{Type: []string{t}}
+			if nullable {
+				alternative.Nullable = &nullable
+			}
+			typed = append(typed, base.CreateSchemaProxy(alternative))
+		}
+		s.Type = nil
+		if len(s.AnyOf) > 0 {
+			// the existing 'anyOf' still has to match, so the types become a second 'anyOf' inside 'allOf'.
+			s.AllOf = append(s.AllOf, base.CreateSchemaProxy(&base.Schema{AnyOf: typed}))
+			d.rewrite(location, node, "types '%s' have been replaced with an 'anyOf' in 'allOf'",
+				strings.Join(types, "', '"))
+			return
+		}
+		s.AnyOf = typed
+		d.rewrite(location, node, "types '%s' have been replaced with 'anyOf'", strings.Join(types, "', '"))
+		return
+	}
+	if nullable {
+		s.Nullable = &nullable
+		d.rewrite(location, node, "type 'null' has been replaced with 'nullable: true'")
+	}
+}
+
+// nullBranch replaces a {type: 'null'} schema in an 'anyOf' or 'oneOf' with 'nullable: true', which is how a
+// 3.1 nullable reference is written in OpenAPI 3.0. When a single schema is left, it is moved into 'allOf', as
+// a reference cannot be nullable itself. Schemas that have a type of their own are left alone, as are an
+// 'anyOf' or 'oneOf' that only allows null, there is nothing to attach 'nullable' to.
+func (d *downgrader) nullBranch(location string, node *yaml.Node, keyword string, proxies *[]*base.SchemaProxy,
+	s *base.Schema) {
+	if len(s.Type) > 0 {
+		return
+	}
+	var kept []*base.SchemaProxy
+	for _, p := range *proxies {
+		if !isNullSchema(p) {
+			kept = append(kept, p)
+		}
+	}
+	if len(kept) == 0 || len(kept) == len(*proxies) {
+		return
+	}
+	nullable := true
+	s.Nullable = &nullable
+	if len(kept) == 1 {
+		s.AllOf = append([]*base.SchemaProxy{kept[0]}, s.AllOf...)
+		*proxies = nil
+		d.rewrite(location, node, "a 'null' type in '%s' has been replaced with 'nullable: true', and 'allOf'",
+			keyword)
+		return
+	}
+	*proxies = kept
+	d.rewrite(location, node, "a 'null' type in '%s' has been replaced with 'nullable: true'", keyword)
+}
+
+// isNullSchema returns true if a schema is {type: 'null'}, and nothing else.
+func isNullSchema(proxy *base.SchemaProxy) bool {
+	if proxy == nil || proxy.IsReference() {
+		return false
+	}
+	s := proxy.Schema()
+	if s == nil || len(s.Type) != 1 || s.Type[0] != "null" {
+		return false
+	}
+	rendered, err := proxy.MarshalYAML()
+	node, ok := rendered.(*yaml.Node)
+	return err == nil && ok && len(node.Content) == 2
+}
+
+// exclusive downgrades a numeric exclusiveMinimum or exclusiveMaximum into a boolean one, which applies to the
+// minimum or maximum. tighter returns true if the exclusive bound is stricter than the limit.
+func (d *downgrader) exclusive(location string, node *yaml.Node, keyword, limit string, value *float64,
+	exclusive *base.DynamicValue[bool, float64],
+	tighter func(exclusive, limit float64) bool) (*float64, *base.DynamicValue[bool, float64]) {
+	if exclusive == nil || !exclusive.IsB() {
+		return value, exclusive
+	}
+	n := strconv.FormatFloat(exclusive.B, 'f', -1, 64)
+	if value != nil && !tighter(exclusive.B, *value) {
+		d.rewrite(location, node, "'%s: %s' has been removed, '%s: %s' is stricter", keyword, n, limit,
+			strconv.FormatFloat(*value, 'f', -1, 64))
+		return value, nil
+	}
+	d.rewrite(location, node, "'%s: %s' has been replaced with '%s: %s' and '%s: true'", keyword, n, limit, n,
+		keyword)
+	bound := exclusive.B
+	return &bound, &base.DynamicValue[bool, float64]{A: true}
+}
+
+// content converts contentEncoding and contentMediaType into the formats OpenAPI 3.0 uses for binary data.
+func (d *downgrader) content(location string, l *lowbase.Schema, s *base.Schema) {
+	if s.ContentEncoding == "" && s.ContentMediaType == "" {
+		return
+	}
+	encoding, mediaType := l.ContentEncoding.KeyNode, l.ContentMediaType.KeyNode
+	switch {
+	case s.Format != "":
+		if s.ContentEncoding != "" {
+			d.warnAt(location, encoding, "'contentEncoding' cannot be expressed in OpenAPI 3.0 alongside "+
+				"'format', it has been dropped")
+		}
+		if s.ContentMediaType != "" {
+			d.warnAt(location, mediaType, "'contentMediaType' cannot be expressed in OpenAPI 3.0 alongside "+
+				"'format', it has been dropped")
+		}
+	case s.ContentEncoding == "base64":
+		s.Format = "byte"
+		d.rewrite(location, encoding, "'contentEncoding: base64' has been replaced with 'format: byte'")
+		if s.ContentMediaType != "" {
+			d.warnAt(location, mediaType, "'contentMediaType' cannot be expressed in OpenAPI 3.0 alongside "+
+				"'contentEncoding', it has been dropped")
+		}
+	case s.ContentEncoding != "":
+		d.warnAt(location, encoding, "'contentEncoding: %s' cannot be expressed in OpenAPI 3.0, it has been "+
+			"dropped", s.ContentEncoding)
+		if s.ContentMediaType != "" {
+			d.warnAt(location, mediaType, "'contentMediaType' cannot be expressed in OpenAPI 3.0 alongside "+
+				"'contentEncoding', it has been dropped")
+		}
+	default:
+		s.Format = "binary"
+		d.rewrite(location, mediaType, "'contentMediaType: %s' has been replaced with 'format: binary'",
+			s.ContentMediaType)
+	}
+	s.ContentEncoding, s.ContentMediaType = "", ""
+}
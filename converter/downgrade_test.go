@@ -0,0 +1,209 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package converter
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+var downgradeSpec = `openapi: 3.1.0
+jsonSchemaDialect: https://json-schema.org/draft/2020-12/schema
+info:
+  title: pets
+  summary: all about pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: [integer, "null"]
+            minimum: 1
+            exclusiveMinimum: 0
+            exclusiveMaximum: 100
+      responses:
+        "200":
+          description: pets
+          content:
+            application/json:
+              schema:
+                type: array
+                prefixItems:
+                  - type: string
+                items:
+                  $ref: '#/components/schemas/Pet'
+webhooks:
+  newPet:
+    post:
+      responses:
+        "200":
+          description: ok
+components:
+  schemas:
+    Pet:
+      type: object
+      if:
+        required: [tag]
+      then:
+        required: [name]
+      properties:
+        id:
+          type: [integer, string]
+        kind:
+          const: dog
+        photo:
+          type: string
+          contentMediaType: image/png
+        tag:
+          type: string
+          examples: [good, bad]
+        code:
+          type: [integer, string]
+          anyOf:
+            - minimum: 1
+            - minLength: 1
+        owner:
+          anyOf:
+            - $ref: '#/components/schemas/Owner'
+            - type: "null"
+    Owner:
+      type: object`
+
+func TestDowngradeDocument(t *testing.T) {
+	doc, err := libopenapi.NewDocument([]byte(downgradeSpec))
+	assert.NoError(t, err)
+	model, errs := doc.BuildV3Model()
+	assert.Empty(t, errs)
+
+	rewrites, warnings, err := DowngradeDocument(&model.Model)
+	assert.NoError(t, err)
+
+	var log []string
+	for _, r := range rewrites {
+		log = append(log, r.String())
+	}
+	assert.Equal(t, []string{
+		"/openapi: version '3.1.0' has been downgraded to '3.0.3' (line 1, column 10)",
+		"/paths/~1pets/get/parameters/0/schema: type 'null' has been replaced with 'nullable: true' (line 14, column 13)",
+		"/paths/~1pets/get/parameters/0/schema: 'exclusiveMinimum: 0' has been removed, 'minimum: 1' is stricter " +
+			"(line 16, column 13)",
+		"/paths/~1pets/get/parameters/0/schema: 'exclusiveMaximum: 100' has been replaced with 'maximum: 100' " +
+			"and 'exclusiveMaximum: true' (line 17, column 13)",
+		"/components/schemas/Pet/properties/id: types 'integer', 'string' have been replaced with 'anyOf' " +
+			"(line 45, column 11)",
+		"/components/schemas/Pet/properties/kind: 'const' has been replaced with a single value 'enum' " +
+			"(line 47, column 11)",
+		"/components/schemas/Pet/properties/photo: 'contentMediaType: image/png' has been replaced with " +
+			"'format: binary' (line 50, column 11)",
+		"/components/schemas/Pet/properties/tag: 'examples' has been replaced with 'example' (line 53, column 11)",
+		"/components/schemas/Pet/properties/code: types 'integer', 'string' have been replaced with an 'anyOf' " +
+			"in 'allOf' (line 55, column 11)",
+		"/components/schemas/Pet/properties/owner: a 'null' type in 'anyOf' has been replaced with " +
+			"'nullable: true', and 'allOf' (line 60, column 11)",
+	}, log)
+
+	log = nil
+	for _, w := range warnings {
+		log = append(log, w.String())
+	}
+	assert.Equal(t, []string{
+		"/jsonSchemaDialect: 'jsonSchemaDialect' cannot be expressed in OpenAPI 3.0, it has been dropped " +
+			"(line 2, column 1)",
+		"/info: 'summary' cannot be expressed in OpenAPI 3.0, it has been dropped (line 5, column 3)",
+		"/webhooks/newPet: webhook 'newPet' cannot be expressed in OpenAPI 3.0, it has been dropped " +
+			"(line 30, column 3)",
+		"/paths/~1pets/get/responses/200/content/application~1json/schema: 'prefixItems' cannot be expressed " +
+			"in OpenAPI 3.0, it has been dropped (line 25, column 17)",
+		"/components/schemas/Pet: 'if' cannot be expressed in OpenAPI 3.0, it has been dropped (line 39, column 7)",
+		"/components/schemas/Pet: 'then' cannot be expressed in OpenAPI 3.0, it has been dropped (line 41, column 7)",
+		"/components/schemas/Pet/properties/tag: OpenAPI 3.0 schemas only have a single example, 1 other " +
+			"examples have been dropped (line 53, column 11)",
+	}, log)
+
+	_, _, err = DowngradeDocument(&model.Model)
+	assert.Error(t, err)
+	_, _, err = DowngradeDocument(nil)
+	assert.Error(t, err)
+}
+
+func TestDowngradeDocument_RenderAndReload(t *testing.T) {
+	doc, err := libopenapi.NewDocument([]byte(downgradeSpec))
+	assert.NoError(t, err)
+	model, _ := doc.BuildV3Model()
+	_, _, err = DowngradeDocument(&model.Model)
+	assert.NoError(t, err)
+
+	spec, newDoc, newModel, errs := doc.RenderAndReload()
+	assert.Empty(t, errs)
+	assert.Equal(t, "3.0.3", newDoc.GetVersion())
+	assert.NotContains(t, string(spec), "webhooks")
+	assert.NotContains(t, string(spec), "jsonSchemaDialect")
+	assert.NotContains(t, string(spec), "prefixItems")
+
+	limit := newModel.Model.Paths.PathItems.GetOrZero("/pets").Get.Parameters[0].Schema.Schema()
+	assert.Equal(t, []string{"integer"}, limit.Type)
+	assert.True(t, *limit.Nullable)
+	assert.Equal(t, 1.0, *limit.Minimum)
+	assert.Nil(t, limit.ExclusiveMinimum)
+	assert.Equal(t, 100.0, *limit.Maximum)
+	assert.True(t, limit.ExclusiveMaximum.IsA())
+	assert.True(t, limit.ExclusiveMaximum.A)
+
+	pet := newModel.Model.Components.Schemas.GetOrZero("Pet").Schema()
+	assert.Nil(t, pet.If)
+	assert.Nil(t, pet.Then)
+	id := pet.Properties.GetOrZero("id").Schema()
+	assert.Empty(t, id.Type)
+	assert.Len(t, id.AnyOf, 2)
+	assert.Equal(t, []string{"string"}, id.AnyOf[1].Schema().Type)
+	assert.Equal(t, []any{"dog"}, pet.Properties.GetOrZero("kind").Schema().Enum)
+	assert.Equal(t, "binary", pet.Properties.GetOrZero("photo").Schema().Format)
+	tag := pet.Properties.GetOrZero("tag").Schema()
+	assert.Equal(t, "good", tag.Example)
+	assert.Empty(t, tag.Examples)
+	code := pet.Properties.GetOrZero("code").Schema()
+	assert.Empty(t, code.Type)
+	assert.Len(t, code.AnyOf, 2)
+	if assert.Len(t, code.AllOf, 1) {
+		assert.Len(t, code.AllOf[0].Schema().AnyOf, 2)
+	}
+	owner := pet.Properties.GetOrZero("owner").Schema()
+	assert.True(t, *owner.Nullable)
+	assert.Empty(t, owner.AnyOf)
+	if assert.Len(t, owner.AllOf, 1) {
+		assert.Equal(t, "#/components/schemas/Owner", owner.AllOf[0].GetReference())
+	}
+}
+
+func TestDowngradeDocument_UpgradedNullableReference(t *testing.T) {
+	doc, err := libopenapi.NewDocument([]byte(upgradeSpec))
+	assert.NoError(t, err)
+	_, upgraded, model, _, errs := Upgrade(doc)
+	assert.Empty(t, errs)
+
+	_, _, err = DowngradeDocument(&model.Model)
+	assert.NoError(t, err)
+	_, _, newModel, errs := upgraded.RenderAndReload()
+	assert.Empty(t, errs)
+
+	pet := newModel.Model.Components.Schemas.GetOrZero("Pet").Schema()
+	owner := pet.Properties.GetOrZero("owner")
+	assert.True(t, *owner.Schema().Nullable)
+	assert.Empty(t, owner.Schema().AnyOf)
+	if assert.Len(t, owner.Schema().AllOf, 1) {
+		assert.Equal(t, "#/components/schemas/Owner", owner.Schema().AllOf[0].GetReference())
+	}
+
+	sv := validator.NewSchemaValidator()
+	assert.Empty(t, sv.ValidateSchema(owner, nil))
+	assert.Empty(t, sv.ValidateSchema(owner, map[string]any{"age": 3}))
+	assert.NotEmpty(t, sv.ValidateSchema(owner, "fido"))
+	assert.NotEmpty(t, sv.ValidateSchema(owner, map[string]any{"age": "three"}))
+}
@@ -0,0 +1,711 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package converter
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/datamodel/high"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v2high "github.com/pb33f/libopenapi/datamodel/high/v2"
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/datamodel/low"
+	v2low "github.com/pb33f/libopenapi/datamodel/low/v2"
+	v3low "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// OpenAPIVersion is the version of OpenAPI that Swagger documents are converted into.
+const OpenAPIVersion = "3.0.3"
+
+// defaultMediaType is used when a Swagger document does not define what an operation consumes or produces.
+const defaultMediaType = "application/json"
+
+const (
+	formURLEncoded = "application/x-www-form-urlencoded"
+	multipartForm  = "multipart/form-data"
+)
+
+// ConvertSwagger converts a Swagger / OpenAPI 2 document into an OpenAPI 3 document.
+//
+//   - definitions become components/schemas, and every reference to them is updated.
+//   - parameters and responses definitions become components/parameters, requestBodies and responses.
+//   - body and formData parameters become request bodies, using the media types the operation consumes.
+//   - response schemas and examples become content, using the media types the operation produces.
+//   - securityDefinitions become components/securitySchemes.
+//   - host, basePath and schemes become servers.
+//
+// The OpenAPI 3 document is built from scratch and has its own index, nothing is shared with the Swagger
+// document. Anything that cannot be converted is returned as a *ConversionWarning. An error is only returned
+// if the OpenAPI 3 document cannot be built.
+func ConvertSwagger(swagger *v2high.Swagger) (*v3high.Document, []*ConversionWarning, error) {
+	if swagger == nil {
+		return nil, nil, errors.New("unable to convert, no swagger document was supplied")
+	}
+	c := &swaggerConverter{swagger: swagger}
+	root := c.documentNode()
+
+	spec, err := yaml.Marshal(root)
+	if err != nil {
+		return nil, c.warnings.warnings, fmt.Errorf("unable to render converted document: %s", err.Error())
+	}
+	info, err := datamodel.ExtractSpecInfo(spec)
+	if err != nil {
+		return nil, c.warnings.warnings, fmt.Errorf("unable to read converted document: %s", err.Error())
+	}
+	doc, errs := v3low.CreateDocumentFromConfig(info, &datamodel.DocumentConfiguration{})
+	if doc == nil {
+		return nil, c.warnings.warnings, fmt.Errorf("unable to build converted document: %v", errs)
+	}
+	for _, e := range errs {
+		c.warn(nil, "the converted document has a problem: %s", e.Error())
+	}
+	return v3high.NewDocument(doc), c.warnings.warnings, nil
+}
+
+// ConvertLowSwagger is the same as ConvertSwagger, except it converts a low-level Swagger document.
+func ConvertLowSwagger(swagger *v2low.Swagger) (*v3high.Document, []*ConversionWarning, error) {
+	if swagger == nil {
+		return nil, nil, errors.New("unable to convert, no swagger document was supplied")
+	}
+	return ConvertSwagger(v2high.NewSwaggerDocument(swagger))
+}
+
+// swaggerConverter builds the YAML tree of an OpenAPI 3 document from a Swagger document.
+type swaggerConverter struct {
+	warnings
+	swagger *v2high.Swagger
+}
+
+// swaggerParameter is a parameter, with the reference it was defined by (if any) and its position.
+type swaggerParameter struct {
+	param *v2high.Parameter
+	ref   string
+	node  *yaml.Node
+}
+
+func (c *swaggerConverter) documentNode() *yaml.Node {
+	s := c.swagger
+	var l v2low.Swagger
+	if s.GoLow() != nil {
+		l = *s.GoLow()
+	}
+	n := utils.CreateEmptyMapNode()
+	addString(n, "openapi", OpenAPIVersion)
+	addValue(n, "info", s.Info)
+	addNode(n, "servers", c.serversNode(s.Schemes, l.Schemes.ValueNode))
+	addNode(n, "security", securityNode(s.Security, l.Security.ValueNode))
+	if len(s.Tags) > 0 {
+		addValue(n, "tags", s.Tags)
+	}
+	addValue(n, "externalDocs", s.ExternalDocs)
+	addNode(n, "paths", c.pathsNode())
+	addNode(n, "components", c.componentsNode())
+	addExtensions(n, s.Extensions, l.Extensions)
+	return n
+}
+
+// serversNode converts the host, basePath and schemes into servers.
+func (c *swaggerConverter) serversNode(schemes []string, schemesNode *yaml.Node) *yaml.Node {
+	host, basePath := c.swagger.Host, c.swagger.BasePath
+	if host == "" && basePath == "" && len(schemes) == 0 {
+		return nil
+	}
+	var urls []string
+	switch {
+	case host == "":
+		if len(schemes) > 0 {
+			c.warn(schemesNode, "schemes cannot be converted without a host, they have been dropped")
+		}
+		if basePath == "" {
+			basePath = "/"
+		}
+		urls = append(urls, basePath)
+	case len(schemes) == 0:
+		// the scheme used to access the document is used.
+		urls = append(urls, "//"+host+basePath)
+	default:
+		for _, scheme := range schemes {
+			urls = append(urls, scheme+"://"+host+basePath)
+		}
+	}
+	servers := utils.CreateEmptySequenceNode()
+	for _, u := range urls {
+		server := utils.CreateEmptyMapNode()
+		addString(server, "url", u)
+		servers.Content = append(servers.Content, server)
+	}
+	return servers
+}
+
+// securityNode converts security requirements, an empty list is kept, as it removes security.
+func securityNode(requirements []*base.SecurityRequirement, node *yaml.Node) *yaml.Node {
+	if len(requirements) == 0 {
+		if node != nil && node.Kind == yaml.SequenceNode {
+			return utils.CreateEmptySequenceNode()
+		}
+		return nil
+	}
+	return encodeNode(requirements)
+}
+
+func (c *swaggerConverter) pathsNode() *yaml.Node {
+	n := utils.CreateEmptyMapNode()
+	paths := c.swagger.Paths
+	if paths == nil {
+		return n
+	}
+	var l v2low.Paths
+	if paths.GoLow() != nil {
+		l = *paths.GoLow()
+	}
+	for _, path := range paths.PathItems.Keys() {
+		addNode(n, path, c.pathItemNode(paths.PathItems.GetOrZero(path)))
+	}
+	addExtensions(n, paths.Extensions, l.Extensions)
+	return n
+}
+
+func (c *swaggerConverter) pathItemNode(item *v2high.PathItem) *yaml.Node {
+	var l v2low.PathItem
+	if item.GoLow() != nil {
+		l = *item.GoLow()
+	}
+	n := utils.CreateEmptyMapNode()
+	if item.Ref != "" {
+		addString(n, "$ref", c.convertRef(item.Ref, l.Ref.ValueNode))
+	}
+
+	// body and formData parameters cannot be shared by a path item, they are added to every operation.
+	var shared []*swaggerParameter
+	params := collectParameters(item.Parameters, l.Parameters)
+	for _, p := range params {
+		if p.param.In != "body" && p.param.In != "formData" {
+			shared = append(shared, p)
+		}
+	}
+	operations := []struct {
+		method string
+		op     *v2high.Operation
+	}{
+		{"get", item.Get}, {"put", item.Put}, {"post", item.Post}, {"delete", item.Delete},
+		{"options", item.Options}, {"head", item.Head}, {"patch", item.Patch},
+	}
+	for _, o := range operations {
+		if o.op != nil {
+			addNode(n, o.method, c.operationNode(o.op, params))
+		}
+	}
+	addNode(n, "parameters", c.parametersNode(shared))
+	addExtensions(n, item.Extensions, l.Extensions)
+	return n
+}
+
+func (c *swaggerConverter) operationNode(op *v2high.Operation, pathParams []*swaggerParameter) *yaml.Node {
+	var l v2low.Operation
+	if op.GoLow() != nil {
+		l = *op.GoLow()
+	}
+	n := utils.CreateEmptyMapNode()
+	if len(op.Tags) > 0 {
+		addNode(n, "tags", stringsNode(op.Tags))
+	}
+	addString(n, "summary", op.Summary)
+	addString(n, "description", op.Description)
+	addValue(n, "externalDocs", op.ExternalDocs)
+	addString(n, "operationId", op.OperationId)
+
+	var shared, form []*swaggerParameter
+	var body *swaggerParameter
+	for _, p := range mergeParameters(pathParams, collectParameters(op.Parameters, l.Parameters)) {
+		switch p.param.In {
+		case "body":
+			if body != nil {
+				c.warn(p.node, "only one body parameter is allowed, '%s' has been dropped", p.param.Name)
+				continue
+			}
+			body = p
+		case "formData":
+			form = append(form, p)
+		default:
+			shared = append(shared, p)
+		}
+	}
+	addNode(n, "parameters", c.parametersNode(shared))
+
+	consumes := op.Consumes
+	if len(consumes) == 0 {
+		consumes = c.swagger.Consumes
+	}
+	if body != nil {
+		if len(form) > 0 {
+			c.warn(form[0].node, "formData parameters cannot be combined with a body parameter, they have been dropped")
+		}
+		addNode(n, "requestBody", c.bodyNode(body, consumes))
+	} else if len(form) > 0 {
+		addNode(n, "requestBody", c.formNode(form, consumes))
+	}
+
+	produces := op.Produces
+	if len(produces) == 0 {
+		produces = c.swagger.Produces
+	}
+	addNode(n, "responses", c.responsesNode(op.Responses, produces))
+	addBool(n, "deprecated", op.Deprecated)
+	addNode(n, "security", securityNode(op.Security, l.Security.ValueNode))
+	if len(op.Schemes) > 0 && !equalStrings(op.Schemes, c.swagger.Schemes) {
+		addNode(n, "servers", c.serversNode(op.Schemes, l.Schemes.ValueNode))
+	}
+	addExtensions(n, op.Extensions, l.Extensions)
+	return n
+}
+
+// collectParameters pairs high-level parameters with the references they were defined by.
+func collectParameters(params []*v2high.Parameter,
+	lowParams low.NodeReference[[]low.ValueReference[*v2low.Parameter]]) []*swaggerParameter {
+	collected := make([]*swaggerParameter, 0, len(params))
+	for i, param := range params {
+		p := &swaggerParameter{param: param}
+		if i < len(lowParams.Value) {
+			p.ref = lowParams.Value[i].Reference
+			p.node = lowParams.Value[i].ValueNode
+		}
+		collected = append(collected, p)
+	}
+	return collected
+}
+
+// mergeParameters adds the body and formData parameters of a path item to the parameters of an operation,
+// unless the operation overrides them.
+func mergeParameters(pathParams, opParams []*swaggerParameter) []*swaggerParameter {
+	merged := append([]*swaggerParameter{}, opParams...)
+	for _, pp := range pathParams {
+		if pp.param.In != "body" && pp.param.In != "formData" {
+			continue
+		}
+		overridden := false
+		for _, op := range opParams {
+			if op.param.In == pp.param.In && (op.param.In == "body" || op.param.Name == pp.param.Name) {
+				overridden = true
+				break
+			}
+		}
+		if !overridden {
+			merged = append(merged, pp)
+		}
+	}
+	return merged
+}
+
+func (c *swaggerConverter) parametersNode(params []*swaggerParameter) *yaml.Node {
+	if len(params) == 0 {
+		return nil
+	}
+	seq := utils.CreateEmptySequenceNode()
+	for _, p := range params {
+		if strings.HasPrefix(p.ref, "#/parameters/") {
+			seq.Content = append(seq.Content, utils.CreateRefNode(c.convertRef(p.ref, p.node)))
+			continue
+		}
+		seq.Content = append(seq.Content, c.parameterNode(p.param))
+	}
+	return seq
+}
+
+func (c *swaggerConverter) parameterNode(param *v2high.Parameter) *yaml.Node {
+	var l v2low.Parameter
+	if param.GoLow() != nil {
+		l = *param.GoLow()
+	}
+	n := utils.CreateEmptyMapNode()
+	addString(n, "name", param.Name)
+	addString(n, "in", param.In)
+	addString(n, "description", param.Description)
+	addBool(n, "required", param.In == "path" || (param.Required != nil && *param.Required))
+	if param.AllowEmptyValue != nil && *param.AllowEmptyValue && param.In == "query" {
+		addBool(n, "allowEmptyValue", true)
+	}
+	if param.Type == "array" {
+		style, explode := c.parameterStyle(param, l.CollectionFormat.ValueNode)
+		addString(n, "style", style)
+		addValue(n, "explode", explode)
+	}
+	addNode(n, "schema", c.simpleSchema(parameterType(param)))
+	addExtensions(n, param.Extensions, l.Extensions)
+	return n
+}
+
+// parameterStyle converts the collectionFormat of an array parameter into a style and explode.
+func (c *swaggerConverter) parameterStyle(param *v2high.Parameter, node *yaml.Node) (string, *bool) {
+	format := param.CollectionFormat
+	if format == "" {
+		format = "csv"
+	}
+	explode, noExplode := true, false
+	if param.In == "query" || param.In == "formData" {
+		switch format {
+		case "csv":
+			return "form", &noExplode
+		case "ssv":
+			return "spaceDelimited", &noExplode
+		case "pipes":
+			return "pipeDelimited", &noExplode
+		case "multi":
+			return "form", &explode
+		}
+	} else if format == "csv" {
+		return "", nil
+	}
+	c.warn(node, "collectionFormat '%s' of %s parameter '%s' cannot be converted, it has been dropped",
+		format, param.In, param.Name)
+	return "", nil
+}
+
+// bodyNode converts a body parameter into a request body, a reference to a body parameter becomes a
+// reference to a request body.
+func (c *swaggerConverter) bodyNode(p *swaggerParameter, consumes []string) *yaml.Node {
+	if strings.HasPrefix(p.ref, "#/parameters/") {
+		return utils.CreateRefNode("#/components/requestBodies/" + strings.TrimPrefix(p.ref, "#/parameters/"))
+	}
+	return c.requestBodyNode(p.param, consumes)
+}
+
+func (c *swaggerConverter) requestBodyNode(param *v2high.Parameter, consumes []string) *yaml.Node {
+	var l v2low.Parameter
+	if param.GoLow() != nil {
+		l = *param.GoLow()
+	}
+	n := utils.CreateEmptyMapNode()
+	addString(n, "description", param.Description)
+	content := utils.CreateEmptyMapNode()
+	for _, mt := range mediaTypes(consumes) {
+		m := utils.CreateEmptyMapNode()
+		addNode(m, "schema", c.schemaNode(param.Schema))
+		addNode(content, mt, m)
+	}
+	addNode(n, "content", content)
+	addBool(n, "required", param.Required != nil && *param.Required)
+	addExtensions(n, param.Extensions, l.Extensions)
+	return n
+}
+
+// formNode converts formData parameters into a request body, with a property for every parameter.
+func (c *swaggerConverter) formNode(form []*swaggerParameter, consumes []string) *yaml.Node {
+	var types []string
+	for _, mt := range consumes {
+		if parsed, _, err := mime.ParseMediaType(mt); err == nil && (parsed == formURLEncoded || parsed == multipartForm) {
+			types = append(types, mt)
+		}
+	}
+	if len(types) == 0 {
+		types = []string{formURLEncoded}
+		for _, p := range form {
+			if p.param.Type == "file" {
+				types = []string{multipartForm}
+				break
+			}
+		}
+	}
+
+	schema := utils.CreateEmptyMapNode()
+	addString(schema, "type", "object")
+	properties := utils.CreateEmptyMapNode()
+	encoding := utils.CreateEmptyMapNode()
+	var required []string
+	for _, p := range form {
+		param := p.param
+		property := c.simpleSchema(parameterType(param))
+		addString(property, "description", param.Description)
+		addNode(properties, param.Name, property)
+		if param.Required != nil && *param.Required {
+			required = append(required, param.Name)
+		}
+		if param.AllowEmptyValue != nil && *param.AllowEmptyValue {
+			c.warn(p.node, "allowEmptyValue of formData parameter '%s' cannot be converted, it has been dropped",
+				param.Name)
+		}
+		if param.Type == "array" {
+			var node *yaml.Node
+			if param.GoLow() != nil {
+				node = param.GoLow().CollectionFormat.ValueNode
+			}
+			if style, explode := c.parameterStyle(param, node); style != "" {
+				e := utils.CreateEmptyMapNode()
+				addString(e, "style", style)
+				addValue(e, "explode", explode)
+				addNode(encoding, param.Name, e)
+			}
+		}
+	}
+	addNode(schema, "properties", properties)
+	if len(required) > 0 {
+		addNode(schema, "required", stringsNode(required))
+	}
+
+	content := utils.CreateEmptyMapNode()
+	for _, mt := range types {
+		m := utils.CreateEmptyMapNode()
+		addNode(m, "schema", utils.ResetNodeStyle(utils.CopyNode(schema)))
+		// encoding styles only apply to url encoded forms.
+		if parsed, _, _ := mime.ParseMediaType(mt); parsed == formURLEncoded && len(encoding.Content) > 0 {
+			addNode(m, "encoding", utils.ResetNodeStyle(utils.CopyNode(encoding)))
+		}
+		addNode(content, mt, m)
+	}
+	n := utils.CreateEmptyMapNode()
+	addNode(n, "content", content)
+	addBool(n, "required", len(required) > 0)
+	return n
+}
+
+func (c *swaggerConverter) responsesNode(responses *v2high.Responses, produces []string) *yaml.Node {
+	if responses == nil {
+		return nil
+	}
+	var l v2low.Responses
+	if responses.GoLow() != nil {
+		l = *responses.GoLow()
+	}
+	n := utils.CreateEmptyMapNode()
+	for _, code := range responses.Codes.Keys() {
+		_, ref := lowEntry(l.Codes, code)
+		addNode(n, code, c.responseRefNode(responses.Codes.GetOrZero(code), ref, produces))
+	}
+	if responses.Default != nil {
+		addNode(n, "default", c.responseRefNode(responses.Default, l.Default.Reference, produces))
+	}
+	addExtensions(n, responses.Extensions, l.Extensions)
+	return n
+}
+
+// responseRefNode converts a response, a reference to a response definition is kept as a reference.
+func (c *swaggerConverter) responseRefNode(response *v2high.Response, ref string, produces []string) *yaml.Node {
+	if strings.HasPrefix(ref, "#/responses/") {
+		return utils.CreateRefNode(c.convertRef(ref, nil))
+	}
+	return c.responseNode(response, produces)
+}
+
+func (c *swaggerConverter) responseNode(response *v2high.Response, produces []string) *yaml.Node {
+	var l v2low.Response
+	if response.GoLow() != nil {
+		l = *response.GoLow()
+	}
+	n := utils.CreateEmptyMapNode()
+	addNode(n, "description", utils.CreateStringNode(response.Description))
+	if response.Headers.Len() > 0 {
+		headers := utils.CreateEmptyMapNode()
+		for _, name := range response.Headers.Keys() {
+			addNode(headers, name, c.headerNode(name, response.Headers.GetOrZero(name)))
+		}
+		addNode(n, "headers", headers)
+	}
+
+	var examples *high.OrderedMap[string, any]
+	if response.Examples != nil {
+		examples = response.Examples.Values
+	}
+	content := utils.CreateEmptyMapNode()
+	mediaType := func(mt string) {
+		m := utils.CreateEmptyMapNode()
+		addNode(m, "schema", c.schemaNode(response.Schema))
+		addValue(m, "example", examples.GetOrZero(mt))
+		addNode(content, mt, m)
+	}
+	var produced []string
+	if response.Schema != nil {
+		produced = mediaTypes(produces)
+		for _, mt := range produced {
+			mediaType(mt)
+		}
+	}
+	// examples can be provided for media types that are not produced.
+	for _, mt := range examples.Keys() {
+		if !containsString(produced, mt) {
+			mediaType(mt)
+		}
+	}
+	if len(content.Content) > 0 {
+		addNode(n, "content", content)
+	}
+	addExtensions(n, response.Extensions, l.Extensions)
+	return n
+}
+
+func (c *swaggerConverter) headerNode(name string, header *v2high.Header) *yaml.Node {
+	var l v2low.Header
+	if header.GoLow() != nil {
+		l = *header.GoLow()
+	}
+	n := utils.CreateEmptyMapNode()
+	addString(n, "description", header.Description)
+	if header.CollectionFormat != "" && header.CollectionFormat != "csv" {
+		c.warn(l.CollectionFormat.ValueNode, "collectionFormat '%s' of header '%s' cannot be converted, "+
+			"it has been dropped", header.CollectionFormat, name)
+	}
+	addNode(n, "schema", c.simpleSchema(headerType(header)))
+	addExtensions(n, header.Extensions, l.Extensions)
+	return n
+}
+
+func (c *swaggerConverter) componentsNode() *yaml.Node {
+	s := c.swagger
+	n := utils.CreateEmptyMapNode()
+	if s.Definitions != nil && s.Definitions.Definitions.Len() > 0 {
+		schemas := utils.CreateEmptyMapNode()
+		for _, name := range s.Definitions.Definitions.Keys() {
+			addNode(schemas, name, c.schemaNode(s.Definitions.Definitions.GetOrZero(name)))
+		}
+		addNode(n, "schemas", schemas)
+	}
+	if s.Responses != nil && s.Responses.Definitions.Len() > 0 {
+		responses := utils.CreateEmptyMapNode()
+		for _, name := range s.Responses.Definitions.Keys() {
+			addNode(responses, name, c.responseNode(s.Responses.Definitions.GetOrZero(name), s.Produces))
+		}
+		addNode(n, "responses", responses)
+	}
+	if s.Parameters != nil && s.Parameters.Definitions.Len() > 0 {
+		var lowParams map[low.KeyReference[string]]low.ValueReference[*v2low.Parameter]
+		if s.Parameters.GoLow() != nil {
+			lowParams = s.Parameters.GoLow().Definitions
+		}
+		params, bodies := utils.CreateEmptyMapNode(), utils.CreateEmptyMapNode()
+		for _, name := range s.Parameters.Definitions.Keys() {
+			param := s.Parameters.Definitions.GetOrZero(name)
+			switch param.In {
+			case "body":
+				addNode(bodies, name, c.requestBodyNode(param, s.Consumes))
+			case "formData":
+				key, _ := lowEntry(lowParams, name)
+				c.warn(key.KeyNode, "formData parameter '%s' cannot be a component, it has been copied into "+
+					"every operation that uses it", name)
+			default:
+				addNode(params, name, c.parameterNode(param))
+			}
+		}
+		if len(params.Content) > 0 {
+			addNode(n, "parameters", params)
+		}
+		if len(bodies.Content) > 0 {
+			addNode(n, "requestBodies", bodies)
+		}
+	}
+	if s.SecurityDefinitions != nil && s.SecurityDefinitions.Definitions.Len() > 0 {
+		schemes := utils.CreateEmptyMapNode()
+		for _, name := range s.SecurityDefinitions.Definitions.Keys() {
+			addNode(schemes, name, c.securitySchemeNode(name, s.SecurityDefinitions.Definitions.GetOrZero(name)))
+		}
+		if len(schemes.Content) > 0 {
+			addNode(n, "securitySchemes", schemes)
+		}
+	}
+	if len(n.Content) == 0 {
+		return nil
+	}
+	return n
+}
+
+// oauthFlows maps Swagger OAuth2 flows to their OpenAPI 3 names.
+var oauthFlows = map[string]string{
+	"implicit":    "implicit",
+	"password":    "password",
+	"application": "clientCredentials",
+	"accessCode":  "authorizationCode",
+}
+
+func (c *swaggerConverter) securitySchemeNode(name string, scheme *v2high.SecurityScheme) *yaml.Node {
+	var l v2low.SecurityScheme
+	if scheme.GoLow() != nil {
+		l = *scheme.GoLow()
+	}
+	n := utils.CreateEmptyMapNode()
+	switch scheme.Type {
+	case "basic":
+		addString(n, "type", "http")
+		addString(n, "description", scheme.Description)
+		addString(n, "scheme", "basic")
+	case "apiKey":
+		addString(n, "type", "apiKey")
+		addString(n, "description", scheme.Description)
+		addString(n, "name", scheme.Name)
+		addString(n, "in", scheme.In)
+	case "oauth2":
+		flowName, ok := oauthFlows[scheme.Flow]
+		if !ok {
+			c.warn(l.Flow.ValueNode, "security scheme '%s' has an unknown oauth2 flow '%s', it has been dropped",
+				name, scheme.Flow)
+			return nil
+		}
+		flow := utils.CreateEmptyMapNode()
+		if scheme.Flow == "implicit" || scheme.Flow == "accessCode" {
+			addString(flow, "authorizationUrl", scheme.AuthorizationUrl)
+		}
+		if scheme.Flow != "implicit" {
+			addString(flow, "tokenUrl", scheme.TokenUrl)
+		}
+		scopes := utils.CreateEmptyMapNode()
+		if scheme.Scopes != nil {
+			for _, scope := range scheme.Scopes.Values.Keys() {
+				addNode(scopes, scope, utils.CreateStringNode(scheme.Scopes.Values.GetOrZero(scope)))
+			}
+		}
+		addNode(flow, "scopes", scopes)
+		flows := utils.CreateEmptyMapNode()
+		addNode(flows, flowName, flow)
+		addString(n, "type", "oauth2")
+		addString(n, "description", scheme.Description)
+		addNode(n, "flows", flows)
+	default:
+		c.warn(l.Type.ValueNode, "security scheme '%s' has an unknown type '%s', it has been dropped",
+			name, scheme.Type)
+		return nil
+	}
+	addExtensions(n, scheme.Extensions, l.Extensions)
+	return n
+}
+
+// lowEntry finds the key and reference of an entry in a low-level map.
+func lowEntry[T any](m map[low.KeyReference[string]]low.ValueReference[T], key string) (low.KeyReference[string], string) {
+	for k, v := range m {
+		if k.Value == key {
+			return k, v.Reference
+		}
+	}
+	return low.KeyReference[string]{}, ""
+}
+
+// mediaTypes returns the media types an operation consumes or produces, or the default media type.
+func mediaTypes(types []string) []string {
+	if len(types) == 0 {
+		return []string{defaultMediaType}
+	}
+	return types
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
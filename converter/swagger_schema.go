@@ -0,0 +1,244 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package converter
+
+import (
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v2high "github.com/pb33f/libopenapi/datamodel/high/v2"
+	"github.com/pb33f/libopenapi/datamodel/low"
+	v2low "github.com/pb33f/libopenapi/datamodel/low/v2"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// swaggerRefs maps the locations of Swagger components to their OpenAPI 3 equivalent.
+var swaggerRefs = [][2]string{
+	{"#/definitions/", "#/components/schemas/"},
+	{"#/parameters/", "#/components/parameters/"},
+	{"#/responses/", "#/components/responses/"},
+}
+
+// convertRef converts a local Swagger reference into an OpenAPI 3 reference. References to other documents
+// are kept as they are, the documents they point to are not converted.
+func (c *swaggerConverter) convertRef(ref string, node *yaml.Node) string {
+	for _, r := range swaggerRefs {
+		if strings.HasPrefix(ref, r[0]) {
+			return r[1] + strings.TrimPrefix(ref, r[0])
+		}
+	}
+	if !strings.HasPrefix(ref, "#/") {
+		c.warn(node, "reference '%s' points to another document, which has not been converted", ref)
+	}
+	return ref
+}
+
+// schemaNode returns an OpenAPI 3 copy of a Swagger schema.
+func (c *swaggerConverter) schemaNode(proxy *base.SchemaProxy) *yaml.Node {
+	if proxy == nil {
+		return nil
+	}
+	var node *yaml.Node
+	if lp := proxy.GoLow(); lp != nil && lp.GetValueNode() != nil {
+		if proxy.IsReference() {
+			return utils.CreateRefNode(c.convertRef(proxy.GetReference(), lp.GetValueNode()))
+		}
+		node = utils.ResetNodeStyle(utils.CopyNode(lp.GetValueNode()))
+	} else {
+		rendered, err := proxy.MarshalYAML()
+		if err != nil {
+			c.warn(nil, "schema cannot be rendered: %s", err.Error())
+			return nil
+		}
+		node, _ = rendered.(*yaml.Node)
+	}
+	c.convertSchema(node)
+	return node
+}
+
+// convertSchema converts a Swagger schema node (and every schema inside it) into an OpenAPI 3 schema, in place.
+func (c *swaggerConverter) convertSchema(node *yaml.Node) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return
+	}
+	var file *yaml.Node
+	var hasFormat bool
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		switch key.Value {
+		case "$ref":
+			value.Value = c.convertRef(value.Value, value)
+		case "x-nullable":
+			key.Value = "nullable"
+		case "type":
+			if value.Value == "file" {
+				value.Value = "string"
+				file = value
+			}
+		case "format":
+			hasFormat = true
+		case "discriminator":
+			// a Swagger discriminator is only the name of the property.
+			if value.Kind == yaml.ScalarNode {
+				d := utils.CreateEmptyMapNode()
+				addString(d, "propertyName", value.Value)
+				node.Content[i+1] = d
+			}
+		case "properties":
+			for j := 1; j < len(value.Content); j += 2 {
+				c.convertSchema(value.Content[j])
+			}
+		case "items", "additionalProperties", "not", "allOf", "anyOf", "oneOf":
+			if value.Kind == yaml.SequenceNode {
+				for _, s := range value.Content {
+					c.convertSchema(s)
+				}
+			} else {
+				c.convertSchema(value)
+			}
+		}
+	}
+	if file != nil && !hasFormat {
+		addString(node, "format", "binary")
+	}
+}
+
+// simpleType contains the subset of JSON Schema that Swagger allows directly on parameters, headers and items.
+type simpleType struct {
+	typ, format, pattern                                                   string
+	items                                                                  *v2high.Items
+	def                                                                    any
+	enum                                                                   []any
+	maximum, minimum, maxLength, minLength, maxItems, minItems, multipleOf *int
+	exclusiveMaximum, exclusiveMinimum, uniqueItems                        *bool
+}
+
+// simpleSchema converts a simpleType into an OpenAPI 3 schema.
+func (c *swaggerConverter) simpleSchema(s *simpleType) *yaml.Node {
+	n := utils.CreateEmptyMapNode()
+	if s.typ == "file" {
+		addString(n, "type", "string")
+		addString(n, "format", "binary")
+	} else {
+		addString(n, "type", s.typ)
+		addString(n, "format", s.format)
+	}
+	if s.items != nil {
+		addNode(n, "items", c.itemsSchema(s.items))
+	}
+	addValue(n, "default", s.def)
+	addValue(n, "maximum", s.maximum)
+	addValue(n, "exclusiveMaximum", s.exclusiveMaximum)
+	addValue(n, "minimum", s.minimum)
+	addValue(n, "exclusiveMinimum", s.exclusiveMinimum)
+	addValue(n, "maxLength", s.maxLength)
+	addValue(n, "minLength", s.minLength)
+	addString(n, "pattern", s.pattern)
+	addValue(n, "maxItems", s.maxItems)
+	addValue(n, "minItems", s.minItems)
+	addValue(n, "uniqueItems", s.uniqueItems)
+	if len(s.enum) > 0 {
+		addValue(n, "enum", s.enum)
+	}
+	addValue(n, "multipleOf", s.multipleOf)
+	return n
+}
+
+// itemsSchema converts Swagger items into an OpenAPI 3 schema.
+func (c *swaggerConverter) itemsSchema(items *v2high.Items) *yaml.Node {
+	var l v2low.Items
+	if items.GoLow() != nil {
+		l = *items.GoLow()
+	}
+	if items.CollectionFormat != "" && items.CollectionFormat != "csv" {
+		c.warn(l.CollectionFormat.ValueNode, "collectionFormat '%s' of nested items cannot be converted, "+
+			"it has been dropped", items.CollectionFormat)
+	}
+	s := &simpleType{
+		typ:              items.Type,
+		format:           items.Format,
+		pattern:          items.Pattern,
+		items:            items.Items,
+		def:              items.Default,
+		enum:             items.Enum,
+		maximum:          intValue(items.Maximum, l.Maximum),
+		minimum:          intValue(items.Minimum, l.Minimum),
+		maxLength:        intValue(items.MaxLength, l.MaxLength),
+		minLength:        intValue(items.MinLength, l.MinLength),
+		maxItems:         intValue(items.MaxItems, l.MaxItems),
+		minItems:         intValue(items.MinItems, l.MinItems),
+		multipleOf:       intValue(items.MultipleOf, l.MultipleOf),
+		exclusiveMaximum: boolValue(items.ExclusiveMaximum, l.ExclusiveMaximum),
+		exclusiveMinimum: boolValue(items.ExclusiveMinimum, l.ExclusiveMinimum),
+		uniqueItems:      boolValue(items.UniqueItems, l.UniqueItems),
+	}
+	return c.simpleSchema(s)
+}
+
+// headerType extracts the simpleType of a Swagger header.
+func headerType(header *v2high.Header) *simpleType {
+	var l v2low.Header
+	if header.GoLow() != nil {
+		l = *header.GoLow()
+	}
+	return &simpleType{
+		typ:              header.Type,
+		format:           header.Format,
+		pattern:          header.Pattern,
+		items:            header.Items,
+		def:              header.Default,
+		enum:             header.Enum,
+		maximum:          intValue(header.Maximum, l.Maximum),
+		minimum:          intValue(header.Minimum, l.Minimum),
+		maxLength:        intValue(header.MaxLength, l.MaxLength),
+		minLength:        intValue(header.MinLength, l.MinLength),
+		maxItems:         intValue(header.MaxItems, l.MaxItems),
+		minItems:         intValue(header.MinItems, l.MinItems),
+		multipleOf:       intValue(header.MultipleOf, l.MultipleOf),
+		exclusiveMaximum: boolValue(header.ExclusiveMaximum, l.ExclusiveMaximum),
+		exclusiveMinimum: boolValue(header.ExclusiveMinimum, l.ExclusiveMinimum),
+		uniqueItems:      boolValue(header.UniqueItems, l.UniqueItems),
+	}
+}
+
+// parameterType extracts the simpleType of a Swagger parameter.
+func parameterType(param *v2high.Parameter) *simpleType {
+	return &simpleType{
+		typ:              param.Type,
+		format:           param.Format,
+		pattern:          param.Pattern,
+		items:            param.Items,
+		def:              param.Default,
+		enum:             param.Enum,
+		maximum:          param.Maximum,
+		minimum:          param.Minimum,
+		maxLength:        param.MaxLength,
+		minLength:        param.MinLength,
+		maxItems:         param.MaxItems,
+		minItems:         param.MinItems,
+		multipleOf:       param.MultipleOf,
+		exclusiveMaximum: param.ExclusiveMaximum,
+		exclusiveMinimum: param.ExclusiveMinimum,
+		uniqueItems:      param.UniqueItems,
+	}
+}
+
+// intValue returns a pointer to a value if it was set in the specification. Without a low-level model, zero
+// values are treated as not set.
+func intValue(value int, ref low.NodeReference[int]) *int {
+	if ref.ValueNode == nil && value == 0 {
+		return nil
+	}
+	return &value
+}
+
+// boolValue returns a pointer to a value if it was set in the specification. Without a low-level model, false
+// is treated as not set.
+func boolValue(value bool, ref low.NodeReference[bool]) *bool {
+	if ref.ValueNode == nil && !value {
+		return nil
+	}
+	return &value
+}
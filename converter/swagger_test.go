@@ -0,0 +1,310 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package converter
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	v2high "github.com/pb33f/libopenapi/datamodel/high/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+var petstoreSwagger = `swagger: "2.0"
+info:
+  title: petstore
+  version: 1.0.0
+host: petstore.pb33f.io
+basePath: /v2
+schemes: [https, http]
+consumes: [application/json]
+produces: [application/json, application/xml]
+security:
+  - api_key: []
+tags:
+  - name: pets
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      tags: [pets]
+      parameters:
+        - $ref: '#/parameters/limit'
+        - name: tags
+          in: query
+          type: array
+          items:
+            type: string
+        - name: status
+          in: query
+          type: array
+          collectionFormat: tsv
+          items:
+            type: string
+      responses:
+        "200":
+          description: the pets
+          headers:
+            X-Rate-Limit:
+              type: integer
+              minimum: 0
+          schema:
+            type: array
+            items:
+              $ref: '#/definitions/Pet'
+          examples:
+            application/json:
+              - name: fido
+        default:
+          $ref: '#/responses/Error'
+    post:
+      operationId: createPet
+      parameters:
+        - name: pet
+          in: body
+          required: true
+          schema:
+            $ref: '#/definitions/Pet'
+      responses:
+        "201":
+          description: created
+      security: []
+  /pets/{id}/photo:
+    parameters:
+      - name: id
+        in: path
+        type: integer
+        format: int64
+    put:
+      operationId: uploadPhoto
+      consumes: [multipart/form-data]
+      schemes: [https]
+      parameters:
+        - name: photo
+          in: formData
+          type: file
+          required: true
+        - name: labels
+          in: formData
+          type: array
+          collectionFormat: multi
+          items:
+            type: string
+      responses:
+        "204":
+          description: uploaded
+parameters:
+  limit:
+    name: limit
+    in: query
+    type: integer
+    maximum: 100
+  petBody:
+    name: pet
+    in: body
+    schema:
+      $ref: '#/definitions/Pet'
+responses:
+  Error:
+    description: an error
+    schema:
+      type: object
+      properties:
+        message:
+          type: string
+definitions:
+  Pet:
+    type: object
+    discriminator: kind
+    required: [name, kind]
+    properties:
+      name:
+        type: string
+      kind:
+        type: string
+      owner:
+        $ref: '#/definitions/Owner'
+      nickname:
+        type: string
+        x-nullable: true
+  Owner:
+    type: object
+    properties:
+      name:
+        type: string
+securityDefinitions:
+  basic:
+    type: basic
+  api_key:
+    type: apiKey
+    name: X-API-KEY
+    in: header
+  oauth:
+    type: oauth2
+    flow: accessCode
+    authorizationUrl: https://pb33f.io/auth
+    tokenUrl: https://pb33f.io/token
+    scopes:
+      read: read things
+  legacy:
+    type: oauth2
+    flow: magic
+x-api-id: pets`
+
+func buildSwagger(t *testing.T, spec string) *v2high.Swagger {
+	doc, err := libopenapi.NewDocument([]byte(spec))
+	assert.NoError(t, err)
+	model, errs := doc.BuildV2Model()
+	assert.Empty(t, errs)
+	return &model.Model
+}
+
+func TestConvertSwagger(t *testing.T) {
+	doc, warnings, err := ConvertSwagger(buildSwagger(t, petstoreSwagger))
+	assert.NoError(t, err)
+	assert.Equal(t, "3.0.3", doc.Version)
+	assert.Equal(t, "petstore", doc.Info.Title)
+	assert.Equal(t, "pets", doc.Extensions["x-api-id"])
+	assert.Equal(t, "https://petstore.pb33f.io/v2", doc.Servers[0].URL)
+	assert.Equal(t, "http://petstore.pb33f.io/v2", doc.Servers[1].URL)
+	assert.NotNil(t, doc.Index)
+
+	// definitions, and every reference to them.
+	assert.Equal(t, []string{"Pet", "Owner"}, doc.Components.Schemas.Keys())
+	pet := doc.Components.Schemas.GetOrZero("Pet").Schema()
+	assert.Equal(t, "kind", pet.Discriminator.PropertyName)
+	assert.True(t, *pet.Properties.GetOrZero("nickname").Schema().Nullable)
+	assert.Equal(t, "#/components/schemas/Owner", pet.Properties.GetOrZero("owner").GetReference())
+
+	pets := doc.Paths.PathItems.GetOrZero("/pets")
+	assert.Equal(t, "#/components/parameters/limit", pets.Get.Parameters[0].GoLow().GetReference())
+	tags := pets.Get.Parameters[1]
+	assert.Equal(t, "form", tags.Style)
+	assert.False(t, tags.IsExploded())
+
+	ok := pets.Get.Responses.Codes.GetOrZero("200")
+	assert.Equal(t, []string{"application/json", "application/xml"}, ok.Content.Keys())
+	items := ok.Content.GetOrZero("application/json").Schema.Schema().Items.A
+	assert.Equal(t, "#/components/schemas/Pet", items.GetReference())
+	assert.Len(t, ok.Content.GetOrZero("application/json").Example, 1)
+	assert.Equal(t, "integer", ok.Headers.GetOrZero("X-Rate-Limit").Schema.Schema().Type[0])
+	assert.Equal(t, "#/components/responses/Error", pets.Get.Responses.Default.GoLow().GetReference())
+
+	// body and formData parameters become request bodies.
+	body := pets.Post.RequestBody
+	assert.True(t, *body.Required)
+	assert.Equal(t, "#/components/schemas/Pet", body.Content.GetOrZero("application/json").Schema.GetReference())
+	assert.NotNil(t, doc.Components.RequestBodies.GetOrZero("petBody"))
+
+	photo := doc.Paths.PathItems.GetOrZero("/pets/{id}/photo")
+	assert.True(t, photo.Parameters[0].Required)
+	form := photo.Put.RequestBody.Content.GetOrZero("multipart/form-data").Schema.Schema()
+	assert.Equal(t, []string{"photo"}, form.Required)
+	assert.Equal(t, "binary", form.Properties.GetOrZero("photo").Schema().Format)
+	assert.Len(t, photo.Put.Servers, 1)
+
+	// security definitions.
+	schemes := doc.Components.SecuritySchemes
+	assert.Equal(t, "http", schemes.GetOrZero("basic").Type)
+	assert.Equal(t, "basic", schemes.GetOrZero("basic").Scheme)
+	assert.Equal(t, "header", schemes.GetOrZero("api_key").In)
+	assert.Equal(t, "https://pb33f.io/token", schemes.GetOrZero("oauth").Flows.AuthorizationCode.TokenUrl)
+	assert.Equal(t, "read things", schemes.GetOrZero("oauth").Flows.AuthorizationCode.Scopes.GetOrZero("read"))
+	assert.Nil(t, schemes.GetOrZero("legacy"))
+
+	assert.Len(t, warnings, 2)
+	assert.Equal(t, "collectionFormat 'tsv' of query parameter 'status' cannot be converted, it has been dropped",
+		warnings[0].Message)
+	assert.Equal(t, 29, warnings[0].Line)
+	assert.Equal(t, "security scheme 'legacy' has an unknown oauth2 flow 'magic', it has been dropped "+
+		"(line 139, column 11)", warnings[1].String())
+
+	// the converted document can be rendered.
+	rendered, err := doc.Render()
+	assert.NoError(t, err)
+	assert.Contains(t, string(rendered), "explode: false")
+}
+
+func TestConvertSwagger_Servers(t *testing.T) {
+	spec := `swagger: "2.0"
+info:
+  title: servers
+  version: 1.0.0
+basePath: /api
+schemes: [https]
+paths: {}`
+	doc, warnings, err := ConvertSwagger(buildSwagger(t, spec))
+	assert.NoError(t, err)
+	assert.Equal(t, "/api", doc.Servers[0].URL)
+	assert.Len(t, warnings, 1)
+	assert.Equal(t, 6, warnings[0].Line)
+
+	doc, warnings, err = ConvertSwagger(buildSwagger(t, `swagger: "2.0"
+host: pb33f.io
+paths: {}`))
+	assert.NoError(t, err)
+	assert.Empty(t, warnings)
+	assert.Equal(t, "//pb33f.io", doc.Servers[0].URL)
+}
+
+func TestConvertSwagger_FormData(t *testing.T) {
+	spec := `swagger: "2.0"
+paths:
+  /login:
+    post:
+      parameters:
+        - name: user
+          in: formData
+          type: string
+          allowEmptyValue: true
+        - name: roles
+          in: formData
+          type: array
+          items:
+            type: string
+        - name: extra
+          in: body
+          schema:
+            type: object
+      responses:
+        "200":
+          description: ok
+    put:
+      consumes: [application/x-www-form-urlencoded]
+      parameters:
+        - name: roles
+          in: formData
+          type: array
+          collectionFormat: pipes
+          items:
+            type: string
+      responses:
+        "200":
+          description: ok`
+	doc, warnings, err := ConvertSwagger(buildSwagger(t, spec))
+	assert.NoError(t, err)
+	login := doc.Paths.PathItems.GetOrZero("/login")
+
+	// a body parameter wins over formData parameters.
+	assert.Equal(t, []string{"application/json"}, login.Post.RequestBody.Content.Keys())
+	assert.Len(t, warnings, 1)
+	assert.Equal(t, 6, warnings[0].Line)
+
+	mt := login.Put.RequestBody.Content.GetOrZero("application/x-www-form-urlencoded")
+	assert.Equal(t, "pipeDelimited", mt.Encoding.GetOrZero("roles").Style)
+	assert.False(t, *mt.Encoding.GetOrZero("roles").Explode)
+}
+
+func TestConvertLowSwagger(t *testing.T) {
+	_, _, err := ConvertSwagger(nil)
+	assert.Error(t, err)
+	_, _, err = ConvertLowSwagger(nil)
+	assert.Error(t, err)
+
+	swagger := buildSwagger(t, petstoreSwagger)
+	doc, warnings, err := ConvertLowSwagger(swagger.GoLow())
+	assert.NoError(t, err)
+	assert.Len(t, warnings, 2)
+	assert.Equal(t, 2, doc.Paths.PathItems.Len())
+}
@@ -0,0 +1,203 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package converter
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"gopkg.in/yaml.v3"
+)
+
+// UpgradeVersion is the version of OpenAPI that 3.0 documents are upgraded to.
+const UpgradeVersion = "3.1.0"
+
+// Rewrite describes a change made to a document when it was upgraded.
+type Rewrite struct {
+	// Location is a JSON pointer to the object that was rewritten.
+	Location string
+
+	// Message describes what was rewritten.
+	Message string
+
+	// Line is the line of the rewritten construct in the original document.
+	Line int
+
+	// Column is the column of the rewritten construct in the original document.
+	Column int
+}
+
+// String returns a readable representation of the rewrite, including the position if known.
+func (r *Rewrite) String() string {
+	if r.Line == 0 {
+		return fmt.Sprintf("%s: %s", r.Location, r.Message)
+	}
+	return fmt.Sprintf("%s: %s (line %d, column %d)", r.Location, r.Message, r.Line, r.Column)
+}
+
+// rewrites collects Rewrite instances as a document is changed.
+type rewrites struct {
+	rewrites []*Rewrite
+}
+
+// rewrite adds a rewrite, positioned at a node from the original document (which can be nil).
+func (r *rewrites) rewrite(location string, node *yaml.Node, format string, args ...any) {
+	rw := &Rewrite{Location: location, Message: fmt.Sprintf(format, args...)}
+	if node != nil {
+		rw.Line, rw.Column = node.Line, node.Column
+	}
+	r.rewrites = append(r.rewrites, rw)
+}
+
+// UpgradeDocument upgrades an OpenAPI 3.0 document model to OpenAPI 3.1, in place.
+//
+//   - the openapi version becomes 3.1.0.
+//   - 'nullable: true' adds 'null' to the type of a schema (and to its enum, if it has one). A schema without a
+//     type that uses allOf (like a nullable $ref) becomes an anyOf of the allOf and a 'null' type, and a 'null'
+//     type is added to anyOf or oneOf.
+//   - boolean exclusiveMinimum and exclusiveMaximum values are replaced with numeric ones.
+//   - 'example' becomes a single value in 'examples'.
+//
+// Every change is returned as a *Rewrite. The model is changed, but the low-level model and the index are not,
+// use RenderAndReload (or Upgrade) to build a new document from the upgraded model.
+func UpgradeDocument(doc *v3high.Document) ([]*Rewrite, error) {
+	if doc == nil {
+		return nil, errors.New("unable to upgrade, no document was supplied")
+	}
+	if !strings.HasPrefix(doc.Version, "3.0") {
+		return nil, fmt.Errorf("unable to upgrade, the document is version '%s', only 3.0 documents "+
+			"can be upgraded", doc.Version)
+	}
+	u := new(upgrader)
+	var versionNode *yaml.Node
+	if doc.GoLow() != nil {
+		versionNode = doc.GoLow().Version.ValueNode
+	}
+	u.rewrite("/openapi", versionNode, "version '%s' has been upgraded to '%s'", doc.Version, UpgradeVersion)
+	doc.Version = UpgradeVersion
+
+	w := &schemaWalker{visit: u.schema}
+	w.document(doc)
+	return u.rewrites.rewrites, nil
+}
+
+// Upgrade upgrades the OpenAPI 3.0 model of a document to OpenAPI 3.1 using UpgradeDocument, and then calls
+// RenderAndReload on the document. The rendered bytes, the new document and its model are returned (exactly as
+// RenderAndReload returns them) along with every rewrite that was made.
+func Upgrade(document libopenapi.Document) ([]byte, libopenapi.Document,
+	*libopenapi.DocumentModel[v3high.Document], []*Rewrite, []error) {
+	if document == nil {
+		return nil, nil, nil, nil, []error{errors.New("unable to upgrade, no document was supplied")}
+	}
+	model, errs := document.BuildV3Model()
+	if model == nil {
+		return nil, nil, nil, nil, errs
+	}
+	rewritten, err := UpgradeDocument(&model.Model)
+	if err != nil {
+		return nil, nil, nil, nil, []error{err}
+	}
+	spec, newDoc, newModel, errs := document.RenderAndReload()
+	return spec, newDoc, newModel, rewritten, errs
+}
+
+// upgrader rewrites OpenAPI 3.0 schemas into OpenAPI 3.1 schemas.
+type upgrader struct {
+	rewrites
+}
+
+func (u *upgrader) schema(location string, s *base.Schema) {
+	var nullable, exclusiveMinimum, exclusiveMaximum, example *yaml.Node
+	if l := s.GoLow(); l != nil {
+		nullable = l.Nullable.KeyNode
+		exclusiveMinimum = l.ExclusiveMinimum.KeyNode
+		exclusiveMaximum = l.ExclusiveMaximum.KeyNode
+		example = l.Example.KeyNode
+	}
+
+	if s.Nullable != nil {
+		switch {
+		case !*s.Nullable:
+			u.rewrite(location, nullable, "'nullable: false' has been removed")
+		case len(s.Type) == 0 && len(s.AllOf) > 0 && len(s.AnyOf) == 0:
+			// 'allOf: [$ref]' with 'nullable: true' is how 3.0 makes a reference nullable.
+			option := s.AllOf[0]
+			if len(s.AllOf) > 1 {
+				option = base.CreateSchemaProxy(&base.Schema{AllOf: s.AllOf})
+			}
+			s.AnyOf = []*base.SchemaProxy{option, base.CreateSchemaProxy(&base.Schema{Type: []string{"null"}})}
+			s.AllOf = nil
+			u.rewrite(location, nullable, "'nullable: true' and 'allOf' have been replaced with an 'anyOf' "+
+				"that also allows a 'null' type")
+		case len(s.Type) == 0 && (len(s.AnyOf) > 0 || len(s.OneOf) > 0):
+			keyword := "anyOf"
+			if len(s.AnyOf) > 0 {
+				s.AnyOf = append(s.AnyOf, base.CreateSchemaProxy(&base.Schema{Type: []string{"null"}}))
+			} else {
+				keyword = "oneOf"
+				s.OneOf = append(s.OneOf, base.CreateSchemaProxy(&base.Schema{Type: []string{"null"}}))
+			}
+			u.rewrite(location, nullable, "'nullable: true' has been replaced with a 'null' type in '%s'", keyword)
+		case len(s.Type) == 0:
+			u.rewrite(location, nullable, "'nullable: true' has been removed, it has no effect without a type")
+		default:
+			message := "'nullable: true' has been replaced with a 'null' type"
+			if !containsString(s.Type, "null") {
+				s.Type = append(s.Type, "null")
+			}
+			if len(s.Enum) > 0 && !containsNil(s.Enum) {
+				s.Enum = append(s.Enum, nil)
+				message += ", and null has been added to the enum"
+			}
+			u.rewrite(location, nullable, message)
+		}
+		s.Nullable = nil
+	}
+
+	s.Minimum, s.ExclusiveMinimum = u.exclusive(location, exclusiveMinimum, "exclusiveMinimum", "minimum",
+		s.Minimum, s.ExclusiveMinimum)
+	s.Maximum, s.ExclusiveMaximum = u.exclusive(location, exclusiveMaximum, "exclusiveMaximum", "maximum",
+		s.Maximum, s.ExclusiveMaximum)
+
+	if s.Example != nil {
+		s.Examples = append([]any{s.Example}, s.Examples...)
+		s.Example = nil
+		u.rewrite(location, example, "'example' has been replaced with 'examples'")
+	}
+}
+
+// exclusive upgrades a boolean exclusiveMinimum or exclusiveMaximum into a numeric one, which replaces the
+// minimum or maximum it applied to.
+func (u *upgrader) exclusive(location string, node *yaml.Node, keyword, limit string, value *float64,
+	exclusive *base.DynamicValue[bool, float64]) (*float64, *base.DynamicValue[bool, float64]) {
+	if exclusive == nil || !exclusive.IsA() {
+		return value, exclusive
+	}
+	if !exclusive.A {
+		u.rewrite(location, node, "'%s: false' has been removed", keyword)
+		return value, nil
+	}
+	if value == nil {
+		u.rewrite(location, node, "'%s: true' has been removed, it has no effect without '%s'", keyword, limit)
+		return nil, nil
+	}
+	n := strconv.FormatFloat(*value, 'f', -1, 64)
+	u.rewrite(location, node, "'%s: true' and '%s: %s' have been replaced with '%s: %s'", keyword, limit, n,
+		keyword, n)
+	return nil, &base.DynamicValue[bool, float64]{N: 1, B: *value}
+}
+
+func containsNil(values []any) bool {
+	for _, v := range values {
+		if v == nil {
+			return true
+		}
+	}
+	return false
+}
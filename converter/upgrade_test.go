@@ -0,0 +1,139 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package converter
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/stretchr/testify/assert"
+)
+
+var upgradeSpec = `openapi: 3.0.3
+info:
+  title: pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      parameters:
+        - $ref: '#/components/parameters/limit'
+        - name: name
+          in: query
+          schema:
+            type: string
+            nullable: true
+      responses:
+        "200":
+          description: pets
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+components:
+  parameters:
+    limit:
+      name: limit
+      in: query
+      schema:
+        type: integer
+        minimum: 1
+        exclusiveMinimum: true
+        maximum: 100
+        exclusiveMaximum: false
+  schemas:
+    Pet:
+      type: object
+      example:
+        name: fido
+      properties:
+        colour:
+          type: string
+          enum: [brown, black]
+          nullable: true
+        owner:
+          nullable: true
+          allOf:
+            - $ref: '#/components/schemas/Owner'
+    Owner:
+      type: object
+      properties:
+        age:
+          type: number
+          exclusiveMaximum: true`
+
+func TestUpgradeDocument(t *testing.T) {
+	doc, err := libopenapi.NewDocument([]byte(upgradeSpec))
+	assert.NoError(t, err)
+	model, errs := doc.BuildV3Model()
+	assert.Empty(t, errs)
+
+	rewrites, err := UpgradeDocument(&model.Model)
+	assert.NoError(t, err)
+	var log []string
+	for _, r := range rewrites {
+		log = append(log, r.String())
+	}
+	assert.Equal(t, []string{
+		"/openapi: version '3.0.3' has been upgraded to '3.1.0' (line 1, column 10)",
+		"/paths/~1pets/get/parameters/1/schema: 'nullable: true' has been replaced with a 'null' type (line 14, column 13)",
+		"/components/schemas/Pet: 'example' has been replaced with 'examples' (line 38, column 7)",
+		"/components/schemas/Pet/properties/colour: 'nullable: true' has been replaced with a 'null' type, " +
+			"and null has been added to the enum (line 44, column 11)",
+		"/components/schemas/Pet/properties/owner: 'nullable: true' and 'allOf' have been replaced with an " +
+			"'anyOf' that also allows a 'null' type (line 46, column 11)",
+		"/components/schemas/Owner/properties/age: 'exclusiveMaximum: true' has been removed, it has no effect " +
+			"without 'maximum' (line 54, column 11)",
+		"/components/parameters/limit/schema: 'exclusiveMinimum: true' and 'minimum: 1' have been replaced with " +
+			"'exclusiveMinimum: 1' (line 32, column 9)",
+		"/components/parameters/limit/schema: 'exclusiveMaximum: false' has been removed (line 34, column 9)",
+	}, log)
+
+	_, err = UpgradeDocument(&model.Model)
+	assert.Error(t, err)
+	_, err = UpgradeDocument(nil)
+	assert.Error(t, err)
+}
+
+func TestUpgrade(t *testing.T) {
+	doc, err := libopenapi.NewDocument([]byte(upgradeSpec))
+	assert.NoError(t, err)
+
+	spec, newDoc, model, rewrites, errs := Upgrade(doc)
+	assert.Empty(t, errs)
+	assert.Len(t, rewrites, 8)
+	assert.Equal(t, "3.1.0", newDoc.GetVersion())
+	assert.Contains(t, string(spec), "openapi: 3.1.0")
+
+	limit := model.Model.Components.Parameters.GetOrZero("limit").Schema.Schema()
+	assert.Nil(t, limit.Minimum)
+	assert.True(t, limit.ExclusiveMinimum.IsB())
+	assert.Equal(t, 1.0, limit.ExclusiveMinimum.B)
+	assert.Nil(t, limit.ExclusiveMaximum)
+	assert.Equal(t, 100.0, *limit.Maximum)
+
+	pet := model.Model.Components.Schemas.GetOrZero("Pet").Schema()
+	assert.Nil(t, pet.Example)
+	assert.Equal(t, []any{map[string]any{"name": "fido"}}, pet.Examples)
+	colour := pet.Properties.GetOrZero("colour").Schema()
+	assert.Equal(t, []string{"string", "null"}, colour.Type)
+	assert.Equal(t, []any{"brown", "black", nil}, colour.Enum)
+	assert.Nil(t, colour.Nullable)
+	owner := pet.Properties.GetOrZero("owner").Schema()
+	assert.Nil(t, owner.Nullable)
+	assert.Empty(t, owner.AllOf)
+	if assert.Len(t, owner.AnyOf, 2) {
+		assert.Equal(t, "#/components/schemas/Owner", owner.AnyOf[0].GetReference())
+		assert.Equal(t, []string{"null"}, owner.AnyOf[1].Schema().Type)
+	}
+
+	_, _, _, _, errs = Upgrade(nil)
+	assert.Len(t, errs, 1)
+
+	swagger, _ := libopenapi.NewDocument([]byte(`swagger: "2.0"`))
+	_, _, _, _, errs = Upgrade(swagger)
+	assert.NotEmpty(t, errs)
+}
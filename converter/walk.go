@@ -0,0 +1,219 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package converter
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/utils"
+)
+
+// schemaWalker visits every schema in an OpenAPI 3 document, with a JSON pointer to where it is defined.
+// References are not followed, the objects they point to are visited where they are defined, so every schema
+// is only visited once.
+type schemaWalker struct {
+	visit func(location string, schema *base.Schema)
+}
+
+func (w *schemaWalker) document(doc *v3high.Document) {
+	if doc.Paths != nil {
+		for _, path := range doc.Paths.PathItems.Keys() {
+			w.pathItem(pointer("/paths", path), doc.Paths.PathItems.GetOrZero(path))
+		}
+	}
+	for _, name := range doc.Webhooks.Keys() {
+		w.pathItem(pointer("/webhooks", name), doc.Webhooks.GetOrZero(name))
+	}
+	c := doc.Components
+	if c == nil {
+		return
+	}
+	for _, name := range c.Schemas.Keys() {
+		w.schema(pointer("/components/schemas", name), c.Schemas.GetOrZero(name))
+	}
+	for _, name := range c.Responses.Keys() {
+		w.response(pointer("/components/responses", name), c.Responses.GetOrZero(name))
+	}
+	for _, name := range c.Parameters.Keys() {
+		w.parameter(pointer("/components/parameters", name), c.Parameters.GetOrZero(name))
+	}
+	for _, name := range c.RequestBodies.Keys() {
+		w.requestBody(pointer("/components/requestBodies", name), c.RequestBodies.GetOrZero(name))
+	}
+	for _, name := range c.Headers.Keys() {
+		w.header(pointer("/components/headers", name), c.Headers.GetOrZero(name))
+	}
+	for _, name := range c.Callbacks.Keys() {
+		w.callback(pointer("/components/callbacks", name), c.Callbacks.GetOrZero(name))
+	}
+}
+
+func (w *schemaWalker) pathItem(location string, item *v3high.PathItem) {
+	if item == nil || referenced(item.GoLow()) {
+		return
+	}
+	operations := []struct {
+		method string
+		op     *v3high.Operation
+	}{
+		{"get", item.Get}, {"put", item.Put}, {"post", item.Post}, {"delete", item.Delete},
+		{"options", item.Options}, {"head", item.Head}, {"patch", item.Patch}, {"trace", item.Trace},
+	}
+	for _, o := range operations {
+		if o.op != nil {
+			w.operation(pointer(location, o.method), o.op)
+		}
+	}
+	for i, param := range item.Parameters {
+		w.parameter(pointer(location, "parameters", strconv.Itoa(i)), param)
+	}
+}
+
+func (w *schemaWalker) operation(location string, op *v3high.Operation) {
+	for i, param := range op.Parameters {
+		w.parameter(pointer(location, "parameters", strconv.Itoa(i)), param)
+	}
+	if op.RequestBody != nil {
+		w.requestBody(pointer(location, "requestBody"), op.RequestBody)
+	}
+	if op.Responses != nil {
+		for _, code := range op.Responses.Codes.Keys() {
+			w.response(pointer(location, "responses", code), op.Responses.Codes.GetOrZero(code))
+		}
+		if op.Responses.Default != nil {
+			w.response(pointer(location, "responses", "default"), op.Responses.Default)
+		}
+	}
+	for _, name := range op.Callbacks.Keys() {
+		w.callback(pointer(location, "callbacks", name), op.Callbacks.GetOrZero(name))
+	}
+}
+
+func (w *schemaWalker) callback(location string, callback *v3high.Callback) {
+	if callback == nil || referenced(callback.GoLow()) {
+		return
+	}
+	for _, expression := range callback.Expression.Keys() {
+		w.pathItem(pointer(location, expression), callback.Expression.GetOrZero(expression))
+	}
+}
+
+func (w *schemaWalker) parameter(location string, param *v3high.Parameter) {
+	if param == nil || referenced(param.GoLow()) {
+		return
+	}
+	w.schema(pointer(location, "schema"), param.Schema)
+	w.content(pointer(location, "content"), param.Content)
+}
+
+func (w *schemaWalker) requestBody(location string, rb *v3high.RequestBody) {
+	if rb == nil || referenced(rb.GoLow()) {
+		return
+	}
+	w.content(pointer(location, "content"), rb.Content)
+}
+
+func (w *schemaWalker) response(location string, response *v3high.Response) {
+	if response == nil || referenced(response.GoLow()) {
+		return
+	}
+	for _, name := range response.Headers.Keys() {
+		w.header(pointer(location, "headers", name), response.Headers.GetOrZero(name))
+	}
+	w.content(pointer(location, "content"), response.Content)
+}
+
+func (w *schemaWalker) header(location string, header *v3high.Header) {
+	if header == nil || referenced(header.GoLow()) {
+		return
+	}
+	w.schema(pointer(location, "schema"), header.Schema)
+	w.content(pointer(location, "content"), header.Content)
+}
+
+func (w *schemaWalker) content(location string, content *high.OrderedMap[string, *v3high.MediaType]) {
+	for _, name := range content.Keys() {
+		mt := content.GetOrZero(name)
+		if mt == nil {
+			continue
+		}
+		w.schema(pointer(location, name, "schema"), mt.Schema)
+		for _, property := range mt.Encoding.Keys() {
+			if e := mt.Encoding.GetOrZero(property); e != nil {
+				for _, h := range e.Headers.Keys() {
+					w.header(pointer(location, name, "encoding", property, "headers", h), e.Headers.GetOrZero(h))
+				}
+			}
+		}
+	}
+}
+
+// schema visits a schema, and then every schema inside it.
+func (w *schemaWalker) schema(location string, proxy *base.SchemaProxy) {
+	if proxy == nil || proxy.IsReference() {
+		return
+	}
+	s := proxy.Schema()
+	if s == nil {
+		return
+	}
+	w.visit(location, s)
+
+	schemas := func(keyword string, proxies []*base.SchemaProxy) {
+		for i, p := range proxies {
+			w.schema(pointer(location, keyword, strconv.Itoa(i)), p)
+		}
+	}
+	schemaMap := func(keyword string, proxies *high.OrderedMap[string, *base.SchemaProxy]) {
+		for _, name := range proxies.Keys() {
+			w.schema(pointer(location, keyword, name), proxies.GetOrZero(name))
+		}
+	}
+	schemas("allOf", s.AllOf)
+	schemas("oneOf", s.OneOf)
+	schemas("anyOf", s.AnyOf)
+	schemas("prefixItems", s.PrefixItems)
+	schemaMap("properties", s.Properties)
+	schemaMap("patternProperties", s.PatternProperties)
+	schemaMap("dependentSchemas", s.DependentSchemas)
+	schemaMap("$defs", s.Defs)
+	if s.Items != nil && s.Items.IsA() {
+		w.schema(pointer(location, "items"), s.Items.A)
+	}
+	if ap, ok := s.AdditionalProperties.(*base.SchemaProxy); ok {
+		w.schema(pointer(location, "additionalProperties"), ap)
+	}
+	w.schema(pointer(location, "not"), s.Not)
+	w.schema(pointer(location, "contains"), s.Contains)
+	w.schema(pointer(location, "if"), s.If)
+	w.schema(pointer(location, "then"), s.Then)
+	w.schema(pointer(location, "else"), s.Else)
+	w.schema(pointer(location, "propertyNames"), s.PropertyNames)
+	w.schema(pointer(location, "unevaluatedItems"), s.UnevaluatedItems)
+	w.schema(pointer(location, "unevaluatedProperties"), s.UnevaluatedProperties)
+	w.schema(pointer(location, "contentSchema"), s.ContentSchema)
+}
+
+// referenced returns true if a low-level object was built from a reference.
+func referenced(lowObject any) bool {
+	r, ok := lowObject.(low.IsReferenced)
+	return ok && !reflect.ValueOf(lowObject).IsNil() && r.IsReference()
+}
+
+// pointer appends escaped segments to a JSON pointer.
+func pointer(location string, segments ...string) string {
+	var b strings.Builder
+	b.WriteString(location)
+	for _, s := range segments {
+		b.WriteString("/")
+		b.WriteString(utils.EscapePointerSegment(s))
+	}
+	return b.String()
+}
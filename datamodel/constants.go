@@ -43,6 +43,11 @@ var OpenAPI31SchemaData string // embedded OAS31 schema
 //go:embed schemas/swagger2-schema.json
 var OpenAPI2SchemaData string // embedded OAS3 schema
 
+// JSONSchemaDraft4Data is an embedded version of the JSON Schema draft-04 meta-schema, which is referenced by
+// the OpenAPI 2 (Swagger) Schema
+//go:embed schemas/draft-04-schema.json
+var JSONSchemaDraft4Data string // embedded JSON Schema draft-04 meta-schema
+
 // OAS3_1Format defines documents that can only be version 3.1
 var OAS3_1Format = []string{OAS31}
 
@@ -3,7 +3,12 @@
 
 package datamodel
 
-import "net/url"
+import (
+	"io/fs"
+	"net/url"
+
+	"github.com/pb33f/libopenapi/index"
+)
 
 // DocumentConfiguration is used to configure the document creation process. It was added in v0.6.0 to allow
 // for more fine-grained control over controls and new features.
@@ -24,6 +29,24 @@ type DocumentConfiguration struct {
 
 	// AllowRemoteReferences will allow the index to lookup remote references. This is disabled by default.
 	AllowRemoteReferences bool
+
+	// RemoteFetcher is used to retrieve remote references, when AllowRemoteReferences is enabled. If not set, then
+	// a default *http.Client will be used. Use this to inject a custom HTTP client with auth headers, proxies or retries.
+	RemoteFetcher index.Fetcher
+
+	// FileFetcher is used to read file references, when AllowFileReferences is enabled. If not set, then
+	// files are read from the local file system.
+	FileFetcher index.Fetcher
+
+	// FileSystem allows file references to be read from an fs.FS (like an embed.FS or a *zip.Reader) instead
+	// of the host file system. AllowFileReferences must also be enabled. If a FileFetcher is set, it takes precedence.
+	//
+	// When a FileSystem is set, the BasePath is relative to the root of the FileSystem (defaults to the root).
+	FileSystem fs.FS
+
+	// RootFileName is the (optional) name of the root specification file inside the FileSystem. If set, and no
+	// BasePath has been set, then relative references will be resolved from the directory containing this file.
+	RootFileName string
 }
 
 func NewOpenDocumentConfiguration() *DocumentConfiguration {
@@ -18,8 +18,8 @@ import (
 // When using the discriminator, inline schemas will not be considered.
 //  v3 - https://spec.openapis.org/oas/v3.1.0#discriminator-object
 type Discriminator struct {
-	PropertyName string            `json:"propertyName,omitempty" yaml:"propertyName,omitempty"`
-	Mapping      map[string]string `json:"mapping,omitempty" yaml:"mapping,omitempty"`
+	PropertyName string                           `json:"propertyName,omitempty" yaml:"propertyName,omitempty"`
+	Mapping      *low2.OrderedMap[string, string] `json:"mapping,omitempty" yaml:"mapping,omitempty"`
 	low          *low.Discriminator
 }
 
@@ -32,7 +32,7 @@ func NewDiscriminator(disc *low.Discriminator) *Discriminator {
 	for k, v := range disc.Mapping.Value {
 		mapping[k.Value] = v.Value
 	}
-	d.Mapping = mapping
+	d.Mapping = low2.NewOrderedMapFromLow(disc.Mapping.Value, mapping)
 	return d
 }
 
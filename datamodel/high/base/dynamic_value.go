@@ -5,8 +5,10 @@ package base
 
 import (
 	"github.com/pb33f/libopenapi/datamodel/high"
+	"github.com/pb33f/libopenapi/utils"
 	"gopkg.in/yaml.v3"
 	"reflect"
+	"strconv"
 )
 
 // DynamicValue is used to hold multiple possible values for a schema property. There are two values, a left
@@ -83,7 +85,12 @@ func (d *DynamicValue[A, B]) MarshalYAML() (interface{}, error) {
 	case reflect.Int64:
 		_ = n.Encode(value.(int64))
 	case reflect.Float64:
-		_ = n.Encode(value.(float64))
+		// avoid exponent notation, large or small numbers should render as written.
+		str := strconv.FormatFloat(value.(float64), 'f', -1, 64)
+		if _, e := strconv.ParseInt(str, 10, 64); e == nil {
+			return utils.CreateIntNode(str), err
+		}
+		return utils.CreateFloatNode(str), err
 	case reflect.Float32:
 		_ = n.Encode(value.(float32))
 	case reflect.Int32:
@@ -55,11 +55,12 @@ func (e *Example) MarshalYAML() (interface{}, error) {
 }
 
 // ExtractExamples will convert a low-level example map, into a high level one that is simple to navigate.
-// no fidelity is lost, everything is still available via GoLow()
-func ExtractExamples(elements map[lowmodel.KeyReference[string]]lowmodel.ValueReference[*low.Example]) map[string]*Example {
+// no fidelity is lost, everything is still available via GoLow(). The examples are in the same order as the
+// specification.
+func ExtractExamples(elements map[lowmodel.KeyReference[string]]lowmodel.ValueReference[*low.Example]) *high.OrderedMap[string, *Example] {
 	extracted := make(map[string]*Example)
 	for k, v := range elements {
 		extracted[k.Value] = NewExample(v.Value)
 	}
-	return extracted
+	return high.NewOrderedMapFromLow(elements, extracted)
 }
@@ -68,7 +68,7 @@ func TestExtractExamples(t *testing.T) {
 		Value: &lowExample,
 	}
 
-	assert.Equal(t, "herbs", ExtractExamples(examplesMap)["green"].Summary)
+	assert.Equal(t, "herbs", ExtractExamples(examplesMap).GetOrZero("green").Summary)
 
 }
 
@@ -26,12 +26,12 @@ type Schema struct {
     SchemaTypeRef string `json:"$schema,omitempty" yaml:"$schema,omitempty"`
 
     // In versions 2 and 3.0, this ExclusiveMaximum can only be a boolean.
-    // In version 3.1, ExclusiveMaximum is an integer.
-    ExclusiveMaximum *DynamicValue[bool, int64] `json:"exclusiveMaximum,omitempty" yaml:"exclusiveMaximum,omitempty"`
+    // In version 3.1, ExclusiveMaximum is a number (which may be a decimal).
+    ExclusiveMaximum *DynamicValue[bool, float64] `json:"exclusiveMaximum,omitempty" yaml:"exclusiveMaximum,omitempty"`
 
     // In versions 2 and 3.0, this ExclusiveMinimum can only be a boolean.
-    // In version 3.1, ExclusiveMinimum is an integer.
-    ExclusiveMinimum *DynamicValue[bool, int64] `json:"exclusiveMinimum,omitempty" yaml:"exclusiveMinimum,omitempty"`
+    // In version 3.1, ExclusiveMinimum is a number (which may be a decimal).
+    ExclusiveMinimum *DynamicValue[bool, float64] `json:"exclusiveMinimum,omitempty" yaml:"exclusiveMinimum,omitempty"`
 
     // In versions 2 and 3.0, this Type is a single value, so array will only ever have one value
     // in version 3.1, Type can be multiple values
@@ -52,17 +52,17 @@ type Schema struct {
     PrefixItems []*SchemaProxy `json:"prefixItems,omitempty" yaml:"prefixItems,omitempty"`
 
     // 3.1 Specific properties
-    Contains              *SchemaProxy            `json:"contains,omitempty" yaml:"contains,omitempty"`
-    MinContains           *int64                  `json:"minContains,omitempty" yaml:"minContains,omitempty"`
-    MaxContains           *int64                  `json:"maxContains,omitempty" yaml:"maxContains,omitempty"`
-    If                    *SchemaProxy            `json:"if,omitempty" yaml:"if,omitempty"`
-    Else                  *SchemaProxy            `json:"else,omitempty" yaml:"else,omitempty"`
-    Then                  *SchemaProxy            `json:"then,omitempty" yaml:"then,omitempty"`
-    DependentSchemas      map[string]*SchemaProxy `json:"dependentSchemas,omitempty" yaml:"dependentSchemas,omitempty"`
-    PatternProperties     map[string]*SchemaProxy `json:"patternProperties,omitempty" yaml:"patternProperties,omitempty"`
-    PropertyNames         *SchemaProxy            `json:"propertyNames,omitempty" yaml:"propertyNames,omitempty"`
-    UnevaluatedItems      *SchemaProxy            `json:"unevaluatedItems,omitempty" yaml:"unevaluatedItems,omitempty"`
-    UnevaluatedProperties *SchemaProxy            `json:"unevaluatedProperties,omitempty" yaml:"unevaluatedProperties,omitempty"`
+    Contains              *SchemaProxy                           `json:"contains,omitempty" yaml:"contains,omitempty"`
+    MinContains           *int64                                 `json:"minContains,omitempty" yaml:"minContains,omitempty"`
+    MaxContains           *int64                                 `json:"maxContains,omitempty" yaml:"maxContains,omitempty"`
+    If                    *SchemaProxy                           `json:"if,omitempty" yaml:"if,omitempty"`
+    Else                  *SchemaProxy                           `json:"else,omitempty" yaml:"else,omitempty"`
+    Then                  *SchemaProxy                           `json:"then,omitempty" yaml:"then,omitempty"`
+    DependentSchemas      *high.OrderedMap[string, *SchemaProxy] `json:"dependentSchemas,omitempty" yaml:"dependentSchemas,omitempty"`
+    PatternProperties     *high.OrderedMap[string, *SchemaProxy] `json:"patternProperties,omitempty" yaml:"patternProperties,omitempty"`
+    PropertyNames         *SchemaProxy                           `json:"propertyNames,omitempty" yaml:"propertyNames,omitempty"`
+    UnevaluatedItems      *SchemaProxy                           `json:"unevaluatedItems,omitempty" yaml:"unevaluatedItems,omitempty"`
+    UnevaluatedProperties *SchemaProxy                           `json:"unevaluatedProperties,omitempty" yaml:"unevaluatedProperties,omitempty"`
 
     // in 3.1 Items can be a Schema or a boolean
     Items *DynamicValue[*SchemaProxy, bool] `json:"items,omitempty" yaml:"items,omitempty"`
@@ -70,35 +70,48 @@ type Schema struct {
     // 3.1 only, part of the JSON Schema spec provides a way to identify a subschema
     Anchor string `json:"$anchor,omitempty" yaml:"$anchor,omitempty"`
 
+    // 3.1 only, JSON Schema identifiers, definitions and annotations.
+    Id                string                                 `json:"$id,omitempty" yaml:"$id,omitempty"`
+    Defs              *high.OrderedMap[string, *SchemaProxy] `json:"$defs,omitempty" yaml:"$defs,omitempty"`
+    DynamicRef        string                                 `json:"$dynamicRef,omitempty" yaml:"$dynamicRef,omitempty"`
+    DynamicAnchor     string                                 `json:"$dynamicAnchor,omitempty" yaml:"$dynamicAnchor,omitempty"`
+    Vocabulary        *high.OrderedMap[string, bool]         `json:"$vocabulary,omitempty" yaml:"$vocabulary,omitempty"`
+    Comment           string                                 `json:"$comment,omitempty" yaml:"$comment,omitempty"`
+    Const             any                                    `json:"const,omitempty" yaml:"const,renderZero,omitempty"`
+    ContentSchema     *SchemaProxy                           `json:"contentSchema,omitempty" yaml:"contentSchema,omitempty"`
+    DependentRequired *high.OrderedMap[string, []string]     `json:"dependentRequired,omitempty" yaml:"dependentRequired,omitempty"`
+
     // Compatible with all versions
-    Not                  *SchemaProxy            `json:"not,omitempty" yaml:"not,omitempty"`
-    Properties           map[string]*SchemaProxy `json:"properties,omitempty" yaml:"properties,omitempty"`
-    Title                string                  `json:"title,omitempty" yaml:"title,omitempty"`
-    MultipleOf           *int64                  `json:"multipleOf,omitempty" yaml:"multipleOf,omitempty"`
-    Maximum              *int64                  `json:"maximum,omitempty" yaml:"maximum,omitempty"`
-    Minimum              *int64                  `json:"minimum,omitempty" yaml:"minimum,omitempty"`
-    MaxLength            *int64                  `json:"maxLength,omitempty" yaml:"maxLength,omitempty"`
-    MinLength            *int64                  `json:"minLength,omitempty" yaml:"minLength,omitempty"`
-    Pattern              string                  `json:"pattern,omitempty" yaml:"pattern,omitempty"`
-    Format               string                  `json:"format,omitempty" yaml:"format,omitempty"`
-    MaxItems             *int64                  `json:"maxItems,omitempty" yaml:"maxItems,omitempty"`
-    MinItems             *int64                  `json:"minItems,omitempty" yaml:"minItems,omitempty"`
-    UniqueItems          *bool                   `json:"uniqueItems,omitempty" yaml:"uniqueItems,omitempty"`
-    MaxProperties        *int64                  `json:"maxProperties,omitempty" yaml:"maxProperties,omitempty"`
-    MinProperties        *int64                  `json:"minProperties,omitempty" yaml:"minProperties,omitempty"`
-    Required             []string                `json:"required,omitempty" yaml:"required,omitempty"`
-    Enum                 []any                   `json:"enum,omitempty" yaml:"enum,omitempty"`
-    AdditionalProperties any                     `json:"additionalProperties,omitempty" yaml:"additionalProperties,renderZero,omitempty"`
-    Description          string                  `json:"description,omitempty" yaml:"description,omitempty"`
-    Default              any                     `json:"default,omitempty" yaml:"default,renderZero,omitempty"`
-    Nullable             *bool                   `json:"nullable,omitempty" yaml:"nullable,omitempty"`
-    ReadOnly             bool                    `json:"readOnly,omitempty" yaml:"readOnly,omitempty"`   // https://github.com/pb33f/libopenapi/issues/30
-    WriteOnly            bool                    `json:"writeOnly,omitempty" yaml:"writeOnly,omitempty"` // https://github.com/pb33f/libopenapi/issues/30
-    XML                  *XML                    `json:"xml,omitempty" yaml:"xml,omitempty"`
-    ExternalDocs         *ExternalDoc            `json:"externalDocs,omitempty" yaml:"externalDocs,omitempty"`
-    Example              any                     `json:"example,omitempty" yaml:"example,omitempty"`
-    Deprecated           *bool                   `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
-    Extensions           map[string]any          `json:"-" yaml:"-"`
+    Not                  *SchemaProxy                           `json:"not,omitempty" yaml:"not,omitempty"`
+    Properties           *high.OrderedMap[string, *SchemaProxy] `json:"properties,omitempty" yaml:"properties,omitempty"`
+    Title                string                                 `json:"title,omitempty" yaml:"title,omitempty"`
+    MultipleOf           *float64                               `json:"multipleOf,omitempty" yaml:"multipleOf,omitempty"`
+    Maximum              *float64                               `json:"maximum,omitempty" yaml:"maximum,omitempty"`
+    Minimum              *float64                               `json:"minimum,omitempty" yaml:"minimum,omitempty"`
+    MaxLength            *int64                                 `json:"maxLength,omitempty" yaml:"maxLength,omitempty"`
+    MinLength            *int64                                 `json:"minLength,omitempty" yaml:"minLength,omitempty"`
+    Pattern              string                                 `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+    Format               string                                 `json:"format,omitempty" yaml:"format,omitempty"`
+    MaxItems             *int64                                 `json:"maxItems,omitempty" yaml:"maxItems,omitempty"`
+    MinItems             *int64                                 `json:"minItems,omitempty" yaml:"minItems,omitempty"`
+    UniqueItems          *bool                                  `json:"uniqueItems,omitempty" yaml:"uniqueItems,omitempty"`
+    MaxProperties        *int64                                 `json:"maxProperties,omitempty" yaml:"maxProperties,omitempty"`
+    MinProperties        *int64                                 `json:"minProperties,omitempty" yaml:"minProperties,omitempty"`
+    Required             []string                               `json:"required,omitempty" yaml:"required,omitempty"`
+    Enum                 []any                                  `json:"enum,omitempty" yaml:"enum,omitempty"`
+    AdditionalProperties any                                    `json:"additionalProperties,omitempty" yaml:"additionalProperties,renderZero,omitempty"`
+    Description          string                                 `json:"description,omitempty" yaml:"description,omitempty"`
+    ContentEncoding      string                                 `json:"contentEncoding,omitempty" yaml:"contentEncoding,omitempty"`
+    ContentMediaType     string                                 `json:"contentMediaType,omitempty" yaml:"contentMediaType,omitempty"`
+    Default              any                                    `json:"default,omitempty" yaml:"default,renderZero,omitempty"`
+    Nullable             *bool                                  `json:"nullable,omitempty" yaml:"nullable,omitempty"`
+    ReadOnly             bool                                   `json:"readOnly,omitempty" yaml:"readOnly,omitempty"`   // https://github.com/pb33f/libopenapi/issues/30
+    WriteOnly            bool                                   `json:"writeOnly,omitempty" yaml:"writeOnly,omitempty"` // https://github.com/pb33f/libopenapi/issues/30
+    XML                  *XML                                   `json:"xml,omitempty" yaml:"xml,omitempty"`
+    ExternalDocs         *ExternalDoc                           `json:"externalDocs,omitempty" yaml:"externalDocs,omitempty"`
+    Example              any                                    `json:"example,omitempty" yaml:"example,omitempty"`
+    Deprecated           *bool                                  `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+    Extensions           map[string]any                         `json:"-" yaml:"-"`
     low                  *base.Schema
 
     // Parent Proxy refers back to the low level SchemaProxy that is proxying this schema.
@@ -121,26 +134,26 @@ func NewSchema(schema *base.Schema) *Schema {
     }
     // if we're dealing with a 3.0 spec using a bool
     if !schema.ExclusiveMaximum.IsEmpty() && schema.ExclusiveMaximum.Value.IsA() {
-        s.ExclusiveMaximum = &DynamicValue[bool, int64]{
+        s.ExclusiveMaximum = &DynamicValue[bool, float64]{
             A: schema.ExclusiveMaximum.Value.A,
         }
     }
-    // if we're dealing with a 3.1 spec using an int
+    // if we're dealing with a 3.1 spec using a number
     if !schema.ExclusiveMaximum.IsEmpty() && schema.ExclusiveMaximum.Value.IsB() {
-        s.ExclusiveMaximum = &DynamicValue[bool, int64]{
+        s.ExclusiveMaximum = &DynamicValue[bool, float64]{
             N: 1,
             B: schema.ExclusiveMaximum.Value.B,
         }
     }
     // if we're dealing with a 3.0 spec using a bool
     if !schema.ExclusiveMinimum.IsEmpty() && schema.ExclusiveMinimum.Value.IsA() {
-        s.ExclusiveMinimum = &DynamicValue[bool, int64]{
+        s.ExclusiveMinimum = &DynamicValue[bool, float64]{
             A: schema.ExclusiveMinimum.Value.A,
         }
     }
-    // if we're dealing with a 3.1 spec, using an int
+    // if we're dealing with a 3.1 spec, using a number
     if !schema.ExclusiveMinimum.IsEmpty() && schema.ExclusiveMinimum.Value.IsB() {
-        s.ExclusiveMinimum = &DynamicValue[bool, int64]{
+        s.ExclusiveMinimum = &DynamicValue[bool, float64]{
             N: 1,
             B: schema.ExclusiveMinimum.Value.B,
         }
@@ -243,6 +256,8 @@ func NewSchema(schema *base.Schema) *Schema {
         }
     }
     s.Description = schema.Description.Value
+    s.ContentEncoding = schema.ContentEncoding.Value
+    s.ContentMediaType = schema.ContentMediaType.Value
     s.Default = schema.Default.Value
     if !schema.Nullable.IsEmpty() {
         s.Nullable = &schema.Nullable.Value
@@ -284,6 +299,31 @@ func NewSchema(schema *base.Schema) *Schema {
     if !schema.Anchor.IsEmpty() {
         s.Anchor = schema.Anchor.Value
     }
+    s.Id = schema.Id.Value
+    s.DynamicRef = schema.DynamicRef.Value
+    s.DynamicAnchor = schema.DynamicAnchor.Value
+    s.Comment = schema.Comment.Value
+    s.Const = schema.Const.Value
+    if !schema.ContentSchema.IsEmpty() {
+        s.ContentSchema = &SchemaProxy{schema: &lowmodel.NodeReference[*base.SchemaProxy]{
+            ValueNode: schema.ContentSchema.ValueNode,
+            Value:     schema.ContentSchema.Value,
+        }}
+    }
+    if len(schema.Vocabulary.Value) > 0 {
+        vocab := make(map[string]bool)
+        for k, v := range schema.Vocabulary.Value {
+            vocab[k.Value] = v.Value
+        }
+        s.Vocabulary = high.NewOrderedMapFromLow(schema.Vocabulary.Value, vocab)
+    }
+    if len(schema.DependentRequired.Value) > 0 {
+        depReq := make(map[string][]string)
+        for k, v := range schema.DependentRequired.Value {
+            depReq[k.Value] = v.Value
+        }
+        s.DependentRequired = high.NewOrderedMapFromLow(schema.DependentRequired.Value, depReq)
+    }
 
     // TODO: check this behavior.
     for i := range schema.Enum.Value {
@@ -338,7 +378,7 @@ func NewSchema(schema *base.Schema) *Schema {
     // props async
     var plock sync.Mutex
     buildProps := func(k lowmodel.KeyReference[string], v lowmodel.ValueReference[*base.SchemaProxy], c chan bool,
-        props map[string]*SchemaProxy, sw int,
+        props map[string]*SchemaProxy,
     ) {
         plock.Lock()
         props[k.Value] = &SchemaProxy{
@@ -350,29 +390,26 @@ func NewSchema(schema *base.Schema) *Schema {
         }
         plock.Unlock()
 
-        switch sw {
-        case 0:
-            s.Properties = props
-        case 1:
-            s.DependentSchemas = props
-        case 2:
-            s.PatternProperties = props
-        }
+        // properties are ordered once everything has been built.
         c <- true
     }
 
     props := make(map[string]*SchemaProxy)
     for k, v := range schema.Properties.Value {
-        go buildProps(k, v, propsChan, props, 0)
+        go buildProps(k, v, propsChan, props)
     }
 
     dependents := make(map[string]*SchemaProxy)
     for k, v := range schema.DependentSchemas.Value {
-        go buildProps(k, v, propsChan, dependents, 1)
+        go buildProps(k, v, propsChan, dependents)
     }
     patternProps := make(map[string]*SchemaProxy)
     for k, v := range schema.PatternProperties.Value {
-        go buildProps(k, v, propsChan, patternProps, 2)
+        go buildProps(k, v, propsChan, patternProps)
+    }
+    defs := make(map[string]*SchemaProxy)
+    for k, v := range schema.Defs.Value {
+        go buildProps(k, v, propsChan, defs)
     }
 
     var allOf []*SchemaProxy
@@ -420,7 +457,8 @@ func NewSchema(schema *base.Schema) *Schema {
 
     completeChildren := 0
     completedProps := 0
-    totalProps := len(schema.Properties.Value) + len(schema.DependentSchemas.Value) + len(schema.PatternProperties.Value)
+    totalProps := len(schema.Properties.Value) + len(schema.DependentSchemas.Value) + len(schema.PatternProperties.Value) +
+        len(schema.Defs.Value)
     if totalProps+children > 0 {
     allDone:
         for true {
@@ -438,6 +476,18 @@ func NewSchema(schema *base.Schema) *Schema {
             }
         }
     }
+    if len(props) > 0 {
+        s.Properties = high.NewOrderedMapFromLow(schema.Properties.Value, props)
+    }
+    if len(dependents) > 0 {
+        s.DependentSchemas = high.NewOrderedMapFromLow(schema.DependentSchemas.Value, dependents)
+    }
+    if len(patternProps) > 0 {
+        s.PatternProperties = high.NewOrderedMapFromLow(schema.PatternProperties.Value, patternProps)
+    }
+    if len(defs) > 0 {
+        s.Defs = high.NewOrderedMapFromLow(schema.Defs.Value, defs)
+    }
     s.OneOf = oneOf
     s.AnyOf = anyOf
     s.AllOf = allOf
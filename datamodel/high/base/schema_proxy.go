@@ -144,6 +144,9 @@ func (sp *SchemaProxy) Render() ([]byte, error) {
 func (sp *SchemaProxy) MarshalYAML() (interface{}, error) {
 	var s *Schema
 	var err error
+	if b := sp.booleanNode(); b != nil {
+		return b, nil
+	}
 	// if this schema isn't a reference, then build it out.
 	if !sp.IsReference() {
 		s, err = sp.BuildSchema()
@@ -167,6 +170,9 @@ func (sp *SchemaProxy) MarshalYAML() (interface{}, error) {
 func (sp *SchemaProxy) MarshalYAMLInline() (interface{}, error) {
 	var s *Schema
 	var err error
+	if b := sp.booleanNode(); b != nil {
+		return b, nil
+	}
 	s, err = sp.BuildSchema()
 	if err != nil {
 		return nil, err
@@ -175,3 +181,14 @@ func (sp *SchemaProxy) MarshalYAMLInline() (interface{}, error) {
 	nb.Resolve = true
 	return nb.Render(), nil
 }
+
+// booleanNode returns a boolean node if the SchemaProxy is a boolean schema (3.1+), like 'unevaluatedProperties: false'
+func (sp *SchemaProxy) booleanNode() *yaml.Node {
+	if sp.schema == nil || sp.schema.Value == nil {
+		return nil
+	}
+	if vn := sp.schema.Value.GetValueNode(); vn != nil && utils.IsNodeBoolValue(vn) {
+		return utils.CreateBoolNode(vn.Value)
+	}
+	return nil
+}
@@ -66,3 +66,75 @@ func TestCreateSchemaProxyRef(t *testing.T) {
     assert.True(t, sp.IsReference())
 }
 
+func TestCreateSchemaProxy_RenderSlice(t *testing.T) {
+    sp := CreateSchemaProxy(&Schema{AnyOf: []*SchemaProxy{
+        CreateSchemaProxy(&Schema{Type: []string{"string"}}),
+        CreateSchemaProxyRef("#/components/schemas/MySchema"),
+    }})
+    rend, err := sp.Render()
+    assert.NoError(t, err)
+    assert.Equal(t, "anyOf:\n    - type: string\n    - $ref: '#/components/schemas/MySchema'", strings.TrimSpace(string(rend)))
+}
+
+func TestSchemaProxy_RenderSlice_AfterReference(t *testing.T) {
+    var idxNode yaml.Node
+    _ = yaml.Unmarshal([]byte(`components:
+    schemas:
+        MySchema:
+            type: string`), &idxNode)
+    idx := index.NewSpecIndexWithConfig(&idxNode, index.CreateOpenAPIIndexConfig())
+
+    const ymlSchema = `anyOf:
+    - $ref: '#/components/schemas/MySchema'`
+
+    var node yaml.Node
+    _ = yaml.Unmarshal([]byte(ymlSchema), &node)
+
+    lowProxy := new(lowbase.SchemaProxy)
+    assert.NoError(t, lowProxy.Build(node.Content[0], idx))
+    sp := NewSchemaProxy(&low.NodeReference[*lowbase.SchemaProxy]{
+        Value:     lowProxy,
+        ValueNode: node.Content[0],
+    })
+    schema, err := sp.BuildSchema()
+    assert.NoError(t, err)
+
+    // a new schema after a reference is still rendered.
+    schema.AnyOf = append(schema.AnyOf, CreateSchemaProxy(&Schema{Type: []string{"null"}}))
+    rend, err := sp.Render()
+    assert.NoError(t, err)
+    assert.Equal(t, "anyOf:\n    - $ref: '#/components/schemas/MySchema'\n    - type: \"null\"", strings.TrimSpace(string(rend)))
+}
+
+
+func TestSchemaProxy_BooleanSchemas(t *testing.T) {
+    const ymlSchema = `type: object
+unevaluatedProperties: false
+propertyNames: true
+allOf:
+    - true
+    - type: object`
+
+    var node yaml.Node
+    _ = yaml.Unmarshal([]byte(ymlSchema), &node)
+
+    lowProxy := new(lowbase.SchemaProxy)
+    err := lowProxy.Build(node.Content[0], nil)
+    assert.NoError(t, err)
+
+    sp := NewSchemaProxy(&low.NodeReference[*lowbase.SchemaProxy]{
+        Value:     lowProxy,
+        ValueNode: node.Content[0],
+    })
+    schema, err := sp.BuildSchema()
+    assert.NoError(t, err)
+    assert.NotNil(t, schema.UnevaluatedProperties)
+    assert.NotNil(t, schema.PropertyNames)
+    assert.Len(t, schema.AllOf, 2)
+
+    rend, _ := schema.UnevaluatedProperties.Render()
+    assert.Equal(t, "false", strings.TrimSpace(string(rend)))
+
+    rend, _ = sp.Render()
+    assert.Equal(t, ymlSchema, strings.TrimSpace(string(rend)))
+}
@@ -303,8 +303,8 @@ $anchor: anchor`
     assert.Equal(t, "string", compiled.If.Schema().Type[0])
     assert.Equal(t, "integer", compiled.Else.Schema().Type[0])
     assert.Equal(t, "boolean", compiled.Then.Schema().Type[0])
-    assert.Equal(t, "string", compiled.PatternProperties["patternOne"].Schema().Type[0])
-    assert.Equal(t, "string", compiled.DependentSchemas["schemaOne"].Schema().Type[0])
+    assert.Equal(t, "string", compiled.PatternProperties.GetOrZero("patternOne").Schema().Type[0])
+    assert.Equal(t, "string", compiled.DependentSchemas.GetOrZero("schemaOne").Schema().Type[0])
     assert.Equal(t, "string", compiled.PropertyNames.Schema().Type[0])
     assert.Equal(t, "boolean", compiled.UnevaluatedItems.Schema().Type[0])
     assert.Equal(t, "integer", compiled.UnevaluatedProperties.Schema().Type[0])
@@ -500,9 +500,9 @@ required: [cake, fish]`
     assert.Nil(t, schemaProxy.GetBuildError())
 
     assert.True(t, compiled.ExclusiveMaximum.A)
-    assert.Equal(t, int64(123), compiled.Properties["somethingB"].Schema().ExclusiveMinimum.B)
-    assert.Equal(t, int64(334), compiled.Properties["somethingB"].Schema().ExclusiveMaximum.B)
-    assert.Len(t, compiled.Properties["somethingB"].Schema().Properties["somethingBProp"].Schema().Type, 2)
+    assert.Equal(t, float64(123), compiled.Properties.GetOrZero("somethingB").Schema().ExclusiveMinimum.B)
+    assert.Equal(t, float64(334), compiled.Properties.GetOrZero("somethingB").Schema().ExclusiveMaximum.B)
+    assert.Len(t, compiled.Properties.GetOrZero("somethingB").Schema().Properties.GetOrZero("somethingBProp").Schema().Type, 2)
 
     assert.Equal(t, "nice", compiled.AdditionalProperties.(*SchemaProxy).Schema().Description)
 
@@ -593,7 +593,7 @@ multipleOf: 5
 `
     highSchema := getHighSchema(t, yml)
 
-    value := int64(5)
+    value := float64(5)
     assert.EqualValues(t, &value, highSchema.MultipleOf)
 }
 
@@ -604,7 +604,7 @@ minimum: 5
 `
     highSchema := getHighSchema(t, yml)
 
-    value := int64(5)
+    value := float64(5)
     assert.EqualValues(t, &value, highSchema.Minimum)
 }
 
@@ -615,7 +615,7 @@ minimum: 0
 `
     highSchema := getHighSchema(t, yml)
 
-    value := int64(0)
+    value := float64(0)
     assert.EqualValues(t, &value, highSchema.Minimum)
 }
 
@@ -626,7 +626,7 @@ exclusiveMinimum: 5
 `
     highSchema := getHighSchema(t, yml)
 
-    value := int64(5)
+    value := float64(5)
     assert.EqualValues(t, value, highSchema.ExclusiveMinimum.B)
     assert.True(t, highSchema.ExclusiveMinimum.IsB())
 }
@@ -638,7 +638,7 @@ maximum: 5
 `
     highSchema := getHighSchema(t, yml)
 
-    value := int64(5)
+    value := float64(5)
     assert.EqualValues(t, &value, highSchema.Maximum)
 }
 
@@ -649,7 +649,7 @@ maximum: 0
 `
     highSchema := getHighSchema(t, yml)
 
-    value := int64(0)
+    value := float64(0)
     assert.EqualValues(t, &value, highSchema.Maximum)
 }
 
@@ -660,11 +660,101 @@ exclusiveMaximum: 5
 `
     highSchema := getHighSchema(t, yml)
 
-    value := int64(5)
+    value := float64(5)
     assert.EqualValues(t, value, highSchema.ExclusiveMaximum.B)
     assert.True(t, highSchema.ExclusiveMaximum.IsB())
 }
 
+func TestSchemaNumberDecimals(t *testing.T) {
+    yml := `
+type: number
+multipleOf: 0.01
+minimum: -1.5
+maximum: 99.99
+exclusiveMinimum: 0.5
+exclusiveMaximum: 100.25
+`
+    highSchema := getHighSchema(t, yml)
+
+    assert.Equal(t, 0.01, *highSchema.MultipleOf)
+    assert.Equal(t, -1.5, *highSchema.Minimum)
+    assert.Equal(t, 99.99, *highSchema.Maximum)
+    assert.Equal(t, 0.5, highSchema.ExclusiveMinimum.B)
+    assert.Equal(t, 100.25, highSchema.ExclusiveMaximum.B)
+}
+
+func TestNewSchemaProxy_RenderSchemaDecimals(t *testing.T) {
+    testSpec := `type: number
+multipleOf: 0.50
+minimum: 0
+maximum: 1.0
+exclusiveMinimum: 0.001
+exclusiveMaximum: 1000000
+`
+
+    var compNode yaml.Node
+    _ = yaml.Unmarshal([]byte(testSpec), &compNode)
+
+    sp := new(lowbase.SchemaProxy)
+    err := sp.Build(compNode.Content[0], nil)
+    assert.NoError(t, err)
+
+    lowproxy := low.NodeReference[*lowbase.SchemaProxy]{
+        Value:     sp,
+        ValueNode: compNode.Content[0],
+    }
+
+    schemaProxy := NewSchemaProxy(&lowproxy)
+    compiled := schemaProxy.Schema()
+
+    // now render it out, it should be identical.
+    schemaBytes, _ := compiled.Render()
+    assert.Equal(t, testSpec, string(schemaBytes))
+}
+
+func TestNewSchemaProxy_PropertiesOrdered(t *testing.T) {
+    testSpec := `type: object
+properties:
+    zebra:
+        type: string
+    apple:
+        type: integer
+    mango:
+        type: boolean
+`
+
+    var compNode yaml.Node
+    _ = yaml.Unmarshal([]byte(testSpec), &compNode)
+
+    sp := new(lowbase.SchemaProxy)
+    err := sp.Build(compNode.Content[0], nil)
+    assert.NoError(t, err)
+
+    lowproxy := low.NodeReference[*lowbase.SchemaProxy]{
+        Value:     sp,
+        ValueNode: compNode.Content[0],
+    }
+
+    compiled := NewSchemaProxy(&lowproxy).Schema()
+    assert.Equal(t, []string{"zebra", "apple", "mango"}, compiled.Properties.Keys())
+
+    // move zebra to the end, the render should follow the order of the map.
+    zebra := compiled.Properties.GetOrZero("zebra")
+    compiled.Properties.Delete("zebra")
+    compiled.Properties.Set("zebra", zebra)
+
+    schemaBytes, _ := compiled.Render()
+    assert.Equal(t, `type: object
+properties:
+    apple:
+        type: integer
+    mango:
+        type: boolean
+    zebra:
+        type: string
+`, string(schemaBytes))
+}
+
 func TestSchema_Items_Boolean(t *testing.T) {
     yml := `
 type: number
@@ -712,7 +802,7 @@ properties:
     highSchema := NewSchema(&lowSchema)
 
     // print out the description of 'aProperty'
-    fmt.Print(highSchema.Properties["aProperty"].Schema().Description)
+    fmt.Print(highSchema.Properties.GetOrZero("aProperty").Schema().Description)
     // Output: this is an integer property
 }
 
@@ -743,7 +833,7 @@ properties:
     })
 
     // print out the description of 'aProperty'
-    fmt.Print(highSchema.Schema().Properties["aProperty"].Schema().Description)
+    fmt.Print(highSchema.Schema().Properties.GetOrZero("aProperty").Schema().Description)
     // Output: this is an integer property
 }
 
@@ -1067,6 +1157,58 @@ func TestNewSchemaProxy_RenderAdditionalPropertiesFalse(t *testing.T) {
     assert.Equal(t, testSpec, strings.TrimSpace(string(schemaBytes)))
 }
 
+func TestNewSchemaProxy_31_Keywords(t *testing.T) {
+    testSpec := `$id: https://pb33f.io/schemas/burger
+$comment: burgers are tasty
+$vocabulary:
+    https://json-schema.org/draft/2020-12/vocab/core: true
+$dynamicAnchor: meta
+$dynamicRef: '#meta'
+$defs:
+    bun:
+        type: string
+const: 0
+contentEncoding: base64
+contentMediaType: application/json
+contentSchema:
+    type: object
+dependentRequired:
+    cheese:
+        - bun
+        - patty`
+
+    var compNode yaml.Node
+    _ = yaml.Unmarshal([]byte(testSpec), &compNode)
+
+    sp := new(lowbase.SchemaProxy)
+    err := sp.Build(compNode.Content[0], nil)
+    assert.NoError(t, err)
+
+    lowproxy := low.NodeReference[*lowbase.SchemaProxy]{
+        Value:     sp,
+        ValueNode: compNode.Content[0],
+    }
+
+    schemaProxy := NewSchemaProxy(&lowproxy)
+    compiled := schemaProxy.Schema()
+
+    assert.Equal(t, "https://pb33f.io/schemas/burger", compiled.Id)
+    assert.Equal(t, "burgers are tasty", compiled.Comment)
+    assert.True(t, compiled.Vocabulary.GetOrZero("https://json-schema.org/draft/2020-12/vocab/core"))
+    assert.Equal(t, "meta", compiled.DynamicAnchor)
+    assert.Equal(t, "#meta", compiled.DynamicRef)
+    assert.Equal(t, []string{"string"}, compiled.Defs.GetOrZero("bun").Schema().Type)
+    assert.Equal(t, 0, compiled.Const)
+    assert.Equal(t, "base64", compiled.ContentEncoding)
+    assert.Equal(t, "application/json", compiled.ContentMediaType)
+    assert.Equal(t, []string{"object"}, compiled.ContentSchema.Schema().Type)
+    assert.Equal(t, []string{"bun", "patty"}, compiled.DependentRequired.GetOrZero("cheese"))
+
+    // now render it out, it should be identical.
+    schemaBytes, _ := compiled.Render()
+    assert.Equal(t, testSpec, strings.TrimSpace(string(schemaBytes)))
+}
+
 func TestNewSchemaProxy_RenderMultiplePoly(t *testing.T) {
     idxYaml := `openapi: 3.1.0
 components:
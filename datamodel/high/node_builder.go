@@ -155,6 +155,12 @@ func (n *NodeBuilder) add(key string, i int) {
             }
         }
     case reflect.Ptr:
+        if om, ok := f.(orderedMapEntries); ok {
+            if len(om.nodeEntries()) > 0 {
+                nodeEntry.Value = f
+            }
+            break
+        }
         if !value.IsNil() {
             nodeEntry.Value = f
         }
@@ -211,6 +217,11 @@ func (n *NodeBuilder) add(key string, i int) {
                 }
                 if nb.GetValueNode() != nil {
                     nodeEntry.Line = nb.GetValueNode().Line
+
+                    // keep hold of the original number, so decimals can be rendered back without any loss.
+                    if _, isFloat := f.(*float64); isFloat && nb.GetValueNode().Kind == yaml.ScalarNode {
+                        nodeEntry.StringValue = nb.GetValueNode().Value
+                    }
                 }
             }
         default:
@@ -359,7 +370,10 @@ func (n *NodeBuilder) AddYAMLNode(parent *yaml.Node, entry *NodeEntry) *yaml.Nod
                     if pr, ok := gh.(low.HasValueUnTyped); ok {
                         fg := reflect.ValueOf(pr.GetValueUntyped())
                         found := false
-                        found, orderedCollection = n.extractLowMapKeys(fg, x, found, orderedCollection, m, k)
+                        // the low level value may not be a map, when the value was set on the high level model.
+                        if fg.Kind() == reflect.Map {
+                            found, orderedCollection = n.extractLowMapKeys(fg, x, found, orderedCollection, m, k)
+                        }
                         if found != true {
                             // this is something new, add it.
                             orderedCollection = append(orderedCollection, &NodeEntry{
@@ -417,12 +431,13 @@ func (n *NodeBuilder) AddYAMLNode(parent *yaml.Node, entry *NodeEntry) *yaml.Nod
         sl := utils.CreateEmptySequenceNode()
         skip := false
         for i := 0; i < m.Len(); i++ {
+            skip = false
             sqi := m.Index(i).Interface()
             // check if this is a reference.
             if glu, ok := sqi.(GoesLowUntyped); ok {
                 if glu != nil {
                     ut := glu.GoLowUntyped()
-                    if !reflect.ValueOf(ut).IsNil() {
+                    if ut != nil && !reflect.ValueOf(ut).IsNil() {
                         r := ut.(low.IsReferenced)
                         if ut != nil && r.GetReference() != "" &&
                             ut.(low.IsReferenced).IsReference() {
@@ -495,6 +510,17 @@ func (n *NodeBuilder) AddYAMLNode(parent *yaml.Node, entry *NodeEntry) *yaml.Nod
         return parent
 
     case reflect.Ptr:
+        // ordered maps are rendered in the order of the map, not the order of the low level line numbers.
+        if om, ok := value.(orderedMapEntries); ok {
+            p := utils.CreateEmptyMapNode()
+            for _, cv := range om.nodeEntries() {
+                n.AddYAMLNode(p, cv)
+            }
+            if len(p.Content) > 0 {
+                valueNode = p
+            }
+            break
+        }
         if r, ok := value.(Renderable); ok {
             if gl, lg := value.(GoesLowUntyped); lg {
                 if gl.GoLowUntyped() != nil {
@@ -559,10 +585,8 @@ func (n *NodeBuilder) AddYAMLNode(parent *yaml.Node, entry *NodeEntry) *yaml.Nod
             }
             if b, bok := value.(*float64); bok {
                 encodeSkip = true
-                if *b > 0 {
-                    valueNode = utils.CreateFloatNode(strconv.FormatFloat(*b, 'f', -1, 64))
-                    valueNode.Line = line
-                }
+                valueNode = createNumberNode(*b, entry.StringValue)
+                valueNode.Line = line
             }
             if !encodeSkip {
                 var rawNode yaml.Node
@@ -653,3 +677,19 @@ type Renderable interface {
 type RenderableInline interface {
     MarshalYAMLInline() (interface{}, error)
 }
+
+// createNumberNode will create a scalar node for a float64 value. If the original value (as it was written in the
+// specification) still represents the same number, it is used as is, so values like '0.10' or '1.0' render without
+// any loss. Whole numbers are rendered as integers.
+func createNumberNode(value float64, original string) *yaml.Node {
+    str := strconv.FormatFloat(value, 'f', -1, 64)
+    if original != "" {
+        if o, err := strconv.ParseFloat(original, 64); err == nil && o == value {
+            str = original
+        }
+    }
+    if _, err := strconv.ParseInt(str, 10, 64); err == nil {
+        return utils.CreateIntNode(str)
+    }
+    return utils.CreateFloatNode(str)
+}
@@ -0,0 +1,219 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package high
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// OrderedMap is a map that remembers the order in which keys were added. High-level models use an OrderedMap for
+// every map of named objects (paths, response codes, components, content, headers, properties and so on). Extensions
+// and security requirements remain plain maps.
+//
+// When a high-level model is built, keys are added in the same order they appear in the specification, so iterating
+// an OrderedMap (using Keys, Values or Range) is deterministic, and matches the source document. Setting a key that
+// already exists will replace the value, but will not change the order. New keys are always added to the end.
+//
+// An OrderedMap can be created using NewOrderedMap, the zero value is also an empty map that is ready to use. A nil
+// *OrderedMap behaves like an empty map when it is read from, but it cannot be written to; Set will panic.
+type OrderedMap[K comparable, V any] struct {
+	keys   []K
+	values map[K]V
+}
+
+// orderedMapEntries is used by the NodeBuilder to render an OrderedMap of any type, in order.
+type orderedMapEntries interface {
+	nodeEntries() []*NodeEntry
+}
+
+// NewOrderedMap will create a new, empty OrderedMap, ready to use.
+func NewOrderedMap[K comparable, V any]() *OrderedMap[K, V] {
+	return &OrderedMap[K, V]{values: make(map[K]V)}
+}
+
+// NewOrderedMapFromLow will create a new OrderedMap from a map of high-level values that have been built from a
+// low-level map. The keys are ordered using the position of each key in the specification (line, then column).
+// Any key in the high-level map that cannot be found in the low-level map is added to the end, sorted by name.
+func NewOrderedMapFromLow[L any, V any](lowMap map[low.KeyReference[string]]low.ValueReference[L],
+	built map[string]V) *OrderedMap[string, V] {
+
+	lowKeys := make([]low.KeyReference[string], 0, len(lowMap))
+	for k := range lowMap {
+		lowKeys = append(lowKeys, k)
+	}
+	sort.Slice(lowKeys, func(i, j int) bool {
+		li, lj := lowKeys[i].KeyNode, lowKeys[j].KeyNode
+		if li == nil || lj == nil {
+			if li == lj {
+				return lowKeys[i].Value < lowKeys[j].Value
+			}
+			return lj == nil
+		}
+		if li.Line != lj.Line {
+			return li.Line < lj.Line
+		}
+		return li.Column < lj.Column
+	})
+
+	om := NewOrderedMap[string, V]()
+	for _, k := range lowKeys {
+		if v, ok := built[k.Value]; ok {
+			om.Set(k.Value, v)
+		}
+	}
+	var remaining []string
+	for k := range built {
+		if _, ok := om.values[k]; !ok {
+			remaining = append(remaining, k)
+		}
+	}
+	sort.Strings(remaining)
+	for _, k := range remaining {
+		om.Set(k, built[k])
+	}
+	return om
+}
+
+// Set will add a value to the map. If the key already exists, the value is replaced and the order is retained.
+func (o *OrderedMap[K, V]) Set(key K, value V) {
+	if o.values == nil {
+		o.values = make(map[K]V)
+	}
+	if _, ok := o.values[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = value
+}
+
+// Get will return the value for a key, and true if the key exists.
+func (o *OrderedMap[K, V]) Get(key K) (V, bool) {
+	if o == nil {
+		var zero V
+		return zero, false
+	}
+	v, ok := o.values[key]
+	return v, ok
+}
+
+// GetOrZero will return the value for a key, or the zero value of V if the key does not exist.
+func (o *OrderedMap[K, V]) GetOrZero(key K) V {
+	v, _ := o.Get(key)
+	return v
+}
+
+// Delete will remove a key (and its value) from the map, the order of the remaining keys is retained.
+func (o *OrderedMap[K, V]) Delete(key K) {
+	if o == nil {
+		return
+	}
+	if _, ok := o.values[key]; !ok {
+		return
+	}
+	delete(o.values, key)
+	for i := range o.keys {
+		if o.keys[i] == key {
+			o.keys = append(o.keys[:i], o.keys[i+1:]...)
+			break
+		}
+	}
+}
+
+// Len returns the number of keys in the map. A nil OrderedMap has a length of zero.
+func (o *OrderedMap[K, V]) Len() int {
+	if o == nil {
+		return 0
+	}
+	return len(o.keys)
+}
+
+// Keys returns a copy of all keys in the map, in order.
+func (o *OrderedMap[K, V]) Keys() []K {
+	if o == nil {
+		return nil
+	}
+	keys := make([]K, len(o.keys))
+	copy(keys, o.keys)
+	return keys
+}
+
+// Values returns all values in the map, in the same order as the keys.
+func (o *OrderedMap[K, V]) Values() []V {
+	if o == nil {
+		return nil
+	}
+	values := make([]V, len(o.keys))
+	for i, k := range o.keys {
+		values[i] = o.values[k]
+	}
+	return values
+}
+
+// Range will call fn for each key and value in the map, in order. Iteration stops if fn returns false.
+func (o *OrderedMap[K, V]) Range(fn func(key K, value V) bool) {
+	if o == nil {
+		return
+	}
+	for _, k := range o.Keys() {
+		if !fn(k, o.values[k]) {
+			return
+		}
+	}
+}
+
+// MarshalYAML will render the OrderedMap as a YAML map, with keys in order.
+func (o *OrderedMap[K, V]) MarshalYAML() (interface{}, error) {
+	m := utils.CreateEmptyMapNode()
+	for _, k := range o.keys {
+		var v yaml.Node
+		if err := v.Encode(o.values[k]); err != nil {
+			return nil, err
+		}
+		m.Content = append(m.Content, utils.CreateStringNode(fmt.Sprint(k)), &v)
+	}
+	return m, nil
+}
+
+// MarshalJSON will render the OrderedMap as a JSON object, with keys in order.
+func (o *OrderedMap[K, V]) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, k := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		kb, err := json.Marshal(fmt.Sprint(k))
+		if err != nil {
+			return nil, err
+		}
+		vb, err := json.Marshal(o.values[k])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(kb)
+		buf.WriteByte(':')
+		buf.Write(vb)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// nodeEntries creates a NodeEntry for every key in the map, in order, so the NodeBuilder can render each value.
+func (o *OrderedMap[K, V]) nodeEntries() []*NodeEntry {
+	if o == nil {
+		return nil
+	}
+	entries := make([]*NodeEntry, len(o.keys))
+	for i, k := range o.keys {
+		key := fmt.Sprint(k)
+		entries[i] = &NodeEntry{Tag: key, Key: key, Value: o.values[k], Line: i}
+	}
+	return entries
+}
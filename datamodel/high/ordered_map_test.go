@@ -0,0 +1,129 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package high
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestOrderedMap(t *testing.T) {
+	om := NewOrderedMap[string, int]()
+	om.Set("pizza", 1)
+	om.Set("burger", 2)
+	om.Set("chips", 3)
+	om.Set("pizza", 4)
+
+	assert.Equal(t, 3, om.Len())
+	assert.Equal(t, []string{"pizza", "burger", "chips"}, om.Keys())
+	assert.Equal(t, []int{4, 2, 3}, om.Values())
+
+	v, ok := om.Get("burger")
+	assert.True(t, ok)
+	assert.Equal(t, 2, v)
+	_, ok = om.Get("salad")
+	assert.False(t, ok)
+	assert.Equal(t, 0, om.GetOrZero("salad"))
+
+	om.Delete("burger")
+	om.Delete("salad")
+	assert.Equal(t, []string{"pizza", "chips"}, om.Keys())
+
+	om.Set("burger", 5)
+	var keys []string
+	om.Range(func(k string, v int) bool {
+		keys = append(keys, k)
+		return k != "chips"
+	})
+	assert.Equal(t, []string{"pizza", "chips"}, keys)
+	assert.Equal(t, []string{"pizza", "chips", "burger"}, om.Keys())
+}
+
+func TestOrderedMap_Nil(t *testing.T) {
+	var om *OrderedMap[string, int]
+	assert.Equal(t, 0, om.Len())
+	assert.Nil(t, om.Keys())
+	assert.Nil(t, om.Values())
+	assert.Equal(t, 0, om.GetOrZero("pizza"))
+	om.Delete("pizza")
+	om.Range(func(k string, v int) bool {
+		t.Fail()
+		return true
+	})
+	assert.Panics(t, func() { om.Set("pizza", 1) })
+}
+
+func TestOrderedMap_ZeroValue(t *testing.T) {
+	var om OrderedMap[string, int]
+	assert.Equal(t, 0, om.GetOrZero("pizza"))
+	om.Set("pizza", 1)
+	om.Set("burger", 2)
+	assert.Equal(t, []string{"pizza", "burger"}, om.Keys())
+	assert.Equal(t, 1, om.GetOrZero("pizza"))
+}
+
+func TestOrderedMap_Marshal(t *testing.T) {
+	om := NewOrderedMap[string, string]()
+	om.Set("zebra", "stripes")
+	om.Set("apple", "pie")
+	om.Set("mango", "chutney")
+
+	y, err := yaml.Marshal(om)
+	assert.NoError(t, err)
+	assert.Equal(t, "zebra: stripes\napple: pie\nmango: chutney", strings.TrimSpace(string(y)))
+
+	j, err := json.Marshal(om)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"zebra":"stripes","apple":"pie","mango":"chutney"}`, string(j))
+}
+
+func TestNewOrderedMapFromLow(t *testing.T) {
+	yml := `zebra: stripes
+apple: pie
+mango: chutney`
+
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &root)
+	mapNode := root.Content[0]
+
+	lowMap := make(map[low.KeyReference[string]]low.ValueReference[string])
+	built := make(map[string]string)
+	for i := 0; i < len(mapNode.Content); i += 2 {
+		lowMap[low.KeyReference[string]{
+			Value:   mapNode.Content[i].Value,
+			KeyNode: mapNode.Content[i],
+		}] = low.ValueReference[string]{
+			Value:     mapNode.Content[i+1].Value,
+			ValueNode: mapNode.Content[i+1],
+		}
+		built[mapNode.Content[i].Value] = strings.ToUpper(mapNode.Content[i+1].Value)
+	}
+	built["banana"] = "SPLIT"
+
+	om := NewOrderedMapFromLow(lowMap, built)
+	assert.Equal(t, []string{"zebra", "apple", "mango", "banana"}, om.Keys())
+	assert.Equal(t, "PIE", om.GetOrZero("apple"))
+}
+
+type orderedParent struct {
+	Things *OrderedMap[string, string] `yaml:"things,omitempty"`
+	Empty  *OrderedMap[string, string] `yaml:"empty,omitempty"`
+}
+
+func TestNodeBuilder_OrderedMap(t *testing.T) {
+	om := NewOrderedMap[string, string]()
+	om.Set("zebra", "stripes")
+	om.Set("apple", "pie")
+
+	nb := NewNodeBuilder(&orderedParent{Things: om, Empty: NewOrderedMap[string, string]()}, nil)
+	node := nb.Render()
+
+	data, _ := yaml.Marshal(node)
+	assert.Equal(t, "things:\n    zebra: stripes\n    apple: pie", strings.TrimSpace(string(data)))
+}
@@ -4,6 +4,7 @@
 package v2
 
 import (
+	"github.com/pb33f/libopenapi/datamodel/high"
 	highbase "github.com/pb33f/libopenapi/datamodel/high/base"
 	lowmodel "github.com/pb33f/libopenapi/datamodel/low"
 	lowbase "github.com/pb33f/libopenapi/datamodel/low/base"
@@ -16,7 +17,7 @@ import (
 // arrays or models.
 //  - https://swagger.io/specification/v2/#definitionsObject
 type Definitions struct {
-	Definitions map[string]*highbase.SchemaProxy
+	Definitions *high.OrderedMap[string, *highbase.SchemaProxy]
 	low         *low.Definitions
 }
 
@@ -30,7 +31,7 @@ func NewDefinitions(definitions *low.Definitions) *Definitions {
 			Value: definitions.Schemas[k].Value,
 		})
 	}
-	rd.Definitions = defs
+	rd.Definitions = high.NewOrderedMapFromLow(definitions.Schemas, defs)
 	return rd
 }
 
@@ -3,13 +3,16 @@
 
 package v2
 
-import low "github.com/pb33f/libopenapi/datamodel/low/v2"
+import (
+	"github.com/pb33f/libopenapi/datamodel/high"
+	low "github.com/pb33f/libopenapi/datamodel/low/v2"
+)
 
 // Example represents a high-level Swagger / OpenAPI 2 Example object, backed by a low level one.
 // Allows sharing examples for operation responses
 //  - https://swagger.io/specification/v2/#exampleObject
 type Example struct {
-	Values map[string]any
+	Values *high.OrderedMap[string, any]
 	low    *low.Examples
 }
 
@@ -22,7 +25,7 @@ func NewExample(examples *low.Examples) *Example {
 		for k := range examples.Values {
 			values[k.Value] = examples.Values[k].Value
 		}
-		e.Values = values
+		e.Values = high.NewOrderedMapFromLow(examples.Values, values)
 	}
 	return e
 }
@@ -3,7 +3,10 @@
 
 package v2
 
-import low "github.com/pb33f/libopenapi/datamodel/low/v2"
+import (
+	"github.com/pb33f/libopenapi/datamodel/high"
+	low "github.com/pb33f/libopenapi/datamodel/low/v2"
+)
 
 // ParameterDefinitions is a high-level representation of a Swagger / OpenAPI 2 Parameters Definitions object
 // that is backed by a low-level one.
@@ -12,7 +15,7 @@ import low "github.com/pb33f/libopenapi/datamodel/low/v2"
 // referenced to the ones defined here. It does not define global operation parameters
 //  - https://swagger.io/specification/v2/#parametersDefinitionsObject
 type ParameterDefinitions struct {
-	Definitions map[string]*Parameter
+	Definitions *high.OrderedMap[string, *Parameter]
 	low         *low.ParameterDefinitions
 }
 
@@ -41,7 +44,7 @@ func NewParametersDefinitions(parametersDefinitions *low.ParameterDefinitions) *
 			params[r.key] = r.result
 		}
 	}
-	pd.Definitions = params
+	pd.Definitions = high.NewOrderedMapFromLow(parametersDefinitions.Definitions, params)
 	return pd
 }
 
@@ -10,7 +10,7 @@ import (
 
 // Paths represents a high-level Swagger / OpenAPI Paths object, backed by a low-level one.
 type Paths struct {
-	PathItems  map[string]*PathItem
+	PathItems  *high.OrderedMap[string, *PathItem]
 	Extensions map[string]any
 	low        *low.Paths
 }
@@ -42,7 +42,7 @@ func NewPaths(paths *low.Paths) *Paths {
 				pathItems[res.key] = res.result
 			}
 		}
-		p.PathItems = pathItems
+		p.PathItems = high.NewOrderedMapFromLow(paths.PathItems, pathItems)
 	}
 	return p
 }
@@ -15,7 +15,7 @@ import (
 type Response struct {
 	Description string
 	Schema      *base.SchemaProxy
-	Headers     map[string]*Header
+	Headers     *high.OrderedMap[string, *Header]
 	Examples    *Example
 	Extensions  map[string]any
 	low         *low.Response
@@ -37,7 +37,7 @@ func NewResponse(response *low.Response) *Response {
 		for k := range response.Headers.Value {
 			headers[k.Value] = NewHeader(response.Headers.Value[k].Value)
 		}
-		r.Headers = headers
+		r.Headers = high.NewOrderedMapFromLow(response.Headers.Value, headers)
 	}
 	if !response.Examples.IsEmpty() {
 		r.Examples = NewExample(response.Examples.Value)
@@ -10,7 +10,7 @@ import (
 
 // Responses is a high-level representation of a Swagger / OpenAPI 2 Responses object, backed by a low level one.
 type Responses struct {
-	Codes      map[string]*Response
+	Codes      *high.OrderedMap[string, *Response]
 	Default    *Response
 	Extensions map[string]any
 	low        *low.Responses
@@ -50,7 +50,7 @@ func NewResponses(responses *low.Responses) *Responses {
 				resp[res.key] = res.result
 			}
 		}
-		r.Codes = resp
+		r.Codes = high.NewOrderedMapFromLow(responses.Codes, resp)
 	}
 	return r
 }
@@ -3,7 +3,10 @@
 
 package v2
 
-import low "github.com/pb33f/libopenapi/datamodel/low/v2"
+import (
+	"github.com/pb33f/libopenapi/datamodel/high"
+	low "github.com/pb33f/libopenapi/datamodel/low/v2"
+)
 
 // ResponsesDefinitions is a high-level representation of a Swagger / OpenAPI 2 Responses Definitions object.
 // that is backed by a low-level one.
@@ -12,7 +15,7 @@ import low "github.com/pb33f/libopenapi/datamodel/low/v2"
 // referenced to the ones defined here. It does not define global operation responses
 //  - https://swagger.io/specification/v2/#responsesDefinitionsObject
 type ResponsesDefinitions struct {
-	Definitions map[string]*Response
+	Definitions *high.OrderedMap[string, *Response]
 	low         *low.ResponsesDefinitions
 }
 
@@ -42,7 +45,7 @@ func NewResponsesDefinitions(responsesDefinitions *low.ResponsesDefinitions) *Re
 			responses[r.key] = r.result
 		}
 	}
-	rd.Definitions = responses
+	rd.Definitions = high.NewOrderedMapFromLow(responsesDefinitions.Definitions, responses)
 	return rd
 }
 
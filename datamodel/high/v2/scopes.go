@@ -4,6 +4,7 @@
 package v2
 
 import (
+	"github.com/pb33f/libopenapi/datamodel/high"
 	low "github.com/pb33f/libopenapi/datamodel/low/v2"
 )
 
@@ -12,7 +13,7 @@ import (
 // Scopes lists the available scopes for an OAuth2 security scheme.
 //  - https://swagger.io/specification/v2/#scopesObject
 type Scopes struct {
-	Values map[string]string
+	Values *high.OrderedMap[string, string]
 	low    *low.Scopes
 }
 
@@ -24,7 +25,7 @@ func NewScopes(scopes *low.Scopes) *Scopes {
 	for k := range scopes.Values {
 		scopeValues[k.Value] = scopes.Values[k].Value
 	}
-	s.Values = scopeValues
+	s.Values = high.NewOrderedMapFromLow(scopes.Values, scopeValues)
 	return s
 }
 
@@ -3,7 +3,10 @@
 
 package v2
 
-import low "github.com/pb33f/libopenapi/datamodel/low/v2"
+import (
+	"github.com/pb33f/libopenapi/datamodel/high"
+	low "github.com/pb33f/libopenapi/datamodel/low/v2"
+)
 
 // SecurityDefinitions is a high-level representation of a Swagger / OpenAPI 2 Security Definitions object, that
 // is backed by a low-level one.
@@ -12,7 +15,7 @@ import low "github.com/pb33f/libopenapi/datamodel/low/v2"
 // schemes on the operations and only serves to provide the relevant details for each scheme
 //  - https://swagger.io/specification/v2/#securityDefinitionsObject
 type SecurityDefinitions struct {
-	Definitions map[string]*SecurityScheme
+	Definitions *high.OrderedMap[string, *SecurityScheme]
 	low         *low.SecurityDefinitions
 }
 
@@ -24,7 +27,7 @@ func NewSecurityDefinitions(definitions *low.SecurityDefinitions) *SecurityDefin
 	for k := range definitions.Definitions {
 		schemes[k.Value] = NewSecurityScheme(definitions.Definitions[k].Value)
 	}
-	sd.Definitions = schemes
+	sd.Definitions = high.NewOrderedMapFromLow(definitions.Definitions, schemes)
 	return sd
 }
 
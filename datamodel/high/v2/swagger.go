@@ -15,6 +15,7 @@ import (
 	"github.com/pb33f/libopenapi/datamodel/high"
 	"github.com/pb33f/libopenapi/datamodel/high/base"
 	low "github.com/pb33f/libopenapi/datamodel/low/v2"
+	"github.com/pb33f/libopenapi/index"
 )
 
 // Swagger represents a high-level Swagger / OpenAPI 2 document. An instance of Swagger is the root of the specification.
@@ -174,6 +175,30 @@ func (s *Swagger) GoLow() *low.Swagger {
 	return s.low
 }
 
+// PruneUnusedComponents removes every definition, parameter, response and security definition that is not used
+// by any operation, and returns what was removed. Usage is worked out by the index of the low-level model (see
+// index.SpecIndex.GetUnusedComponents), so it reflects the document as it was read, not any changes made to the
+// model since.
+func (s *Swagger) PruneUnusedComponents() []*index.UnusedComponent {
+	if s.low == nil || s.low.Index == nil {
+		return nil
+	}
+	unused := s.low.Index.GetUnusedComponents()
+	for _, u := range unused {
+		switch {
+		case u.Section == "definitions" && s.Definitions != nil:
+			s.Definitions.Definitions.Delete(u.Name)
+		case u.Section == "parameters" && s.Parameters != nil:
+			s.Parameters.Definitions.Delete(u.Name)
+		case u.Section == "responses" && s.Responses != nil:
+			s.Responses.Definitions.Delete(u.Name)
+		case u.Section == "securityDefinitions" && s.SecurityDefinitions != nil:
+			s.SecurityDefinitions.Definitions.Delete(u.Name)
+		}
+	}
+	return unused
+}
+
 // everything is build async, this little gem holds the results.
 type asyncResult[T any] struct {
 	key    string
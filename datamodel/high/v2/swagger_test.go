@@ -80,17 +80,17 @@ func TestNewSwaggerDocument_Parameters(t *testing.T) {
 	initTest()
 	highDoc := NewSwaggerDocument(doc)
 	params := highDoc.Parameters
-	assert.Len(t, params.Definitions, 1)
-	assert.Equal(t, "query", params.Definitions["simpleParam"].In)
-	assert.Equal(t, "simple", params.Definitions["simpleParam"].Name)
-	assert.Equal(t, "string", params.Definitions["simpleParam"].Type)
-	assert.Equal(t, "nuggets", params.Definitions["simpleParam"].Extensions["x-chicken"])
+	assert.Equal(t, 1, params.Definitions.Len())
+	assert.Equal(t, "query", params.Definitions.GetOrZero("simpleParam").In)
+	assert.Equal(t, "simple", params.Definitions.GetOrZero("simpleParam").Name)
+	assert.Equal(t, "string", params.Definitions.GetOrZero("simpleParam").Type)
+	assert.Equal(t, "nuggets", params.Definitions.GetOrZero("simpleParam").Extensions["x-chicken"])
 
 	wentLow := params.GoLow()
 	assert.Equal(t, 20, wentLow.FindParameter("simpleParam").ValueNode.Line)
 	assert.Equal(t, 5, wentLow.FindParameter("simpleParam").ValueNode.Column)
 
-	wentLower := params.Definitions["simpleParam"].GoLow()
+	wentLower := params.Definitions.GetOrZero("simpleParam").GoLow()
 	assert.Equal(t, 21, wentLower.Name.ValueNode.Line)
 	assert.Equal(t, 11, wentLower.Name.ValueNode.Column)
 
@@ -111,23 +111,23 @@ func TestNewSwaggerDocument_Security(t *testing.T) {
 func TestNewSwaggerDocument_Definitions_Security(t *testing.T) {
 	initTest()
 	highDoc := NewSwaggerDocument(doc)
-	assert.Len(t, highDoc.SecurityDefinitions.Definitions, 3)
-	assert.Equal(t, "oauth2", highDoc.SecurityDefinitions.Definitions["petstore_auth"].Type)
+	assert.Equal(t, 3, highDoc.SecurityDefinitions.Definitions.Len())
+	assert.Equal(t, "oauth2", highDoc.SecurityDefinitions.Definitions.GetOrZero("petstore_auth").Type)
 	assert.Equal(t, "https://petstore.swagger.io/oauth/authorize",
-		highDoc.SecurityDefinitions.Definitions["petstore_auth"].AuthorizationUrl)
-	assert.Equal(t, "implicit", highDoc.SecurityDefinitions.Definitions["petstore_auth"].Flow)
-	assert.Len(t, highDoc.SecurityDefinitions.Definitions["petstore_auth"].Scopes.Values, 2)
+		highDoc.SecurityDefinitions.Definitions.GetOrZero("petstore_auth").AuthorizationUrl)
+	assert.Equal(t, "implicit", highDoc.SecurityDefinitions.Definitions.GetOrZero("petstore_auth").Flow)
+	assert.Equal(t, 2, highDoc.SecurityDefinitions.Definitions.GetOrZero("petstore_auth").Scopes.Values.Len())
 
 	goLow := highDoc.SecurityDefinitions.GoLow()
 
 	assert.Equal(t, 661, goLow.FindSecurityDefinition("petstore_auth").ValueNode.Line)
 	assert.Equal(t, 5, goLow.FindSecurityDefinition("petstore_auth").ValueNode.Column)
 
-	goLower := highDoc.SecurityDefinitions.Definitions["petstore_auth"].GoLow()
+	goLower := highDoc.SecurityDefinitions.Definitions.GetOrZero("petstore_auth").GoLow()
 	assert.Equal(t, 664, goLower.Scopes.KeyNode.Line)
 	assert.Equal(t, 5, goLower.Scopes.KeyNode.Column)
 
-	goLowest := highDoc.SecurityDefinitions.Definitions["petstore_auth"].Scopes.GoLow()
+	goLowest := highDoc.SecurityDefinitions.Definitions.GetOrZero("petstore_auth").Scopes.GoLow()
 	assert.Equal(t, 665, goLowest.FindScope("read:pets").ValueNode.Line)
 	assert.Equal(t, 18, goLowest.FindScope("read:pets").ValueNode.Column)
 }
@@ -135,34 +135,34 @@ func TestNewSwaggerDocument_Definitions_Security(t *testing.T) {
 func TestNewSwaggerDocument_Definitions_Responses(t *testing.T) {
 	initTest()
 	highDoc := NewSwaggerDocument(doc)
-	assert.Len(t, highDoc.Responses.Definitions, 2)
+	assert.Equal(t, 2, highDoc.Responses.Definitions.Len())
 
 	defs := highDoc.Responses.Definitions
-	assert.Equal(t, "morning", defs["200"].Extensions["x-coffee"])
-	assert.Equal(t, "OK", defs["200"].Description)
+	assert.Equal(t, "morning", defs.GetOrZero("200").Extensions["x-coffee"])
+	assert.Equal(t, "OK", defs.GetOrZero("200").Description)
 	assert.Equal(t, "a generic API response object",
-		defs["200"].Schema.Schema().Description)
-	assert.Len(t, defs["200"].Examples.Values, 3)
+		defs.GetOrZero("200").Schema.Schema().Description)
+	assert.Equal(t, 3, defs.GetOrZero("200").Examples.Values.Len())
 
-	exp := defs["200"].Examples.Values["application/json"]
+	exp := defs.GetOrZero("200").Examples.Values.GetOrZero("application/json")
 	assert.Len(t, exp.(map[string]interface{}), 2)
 	assert.Equal(t, "two", exp.(map[string]interface{})["one"])
 
-	exp = defs["200"].Examples.Values["text/xml"]
+	exp = defs.GetOrZero("200").Examples.Values.GetOrZero("text/xml")
 	assert.Len(t, exp.([]interface{}), 3)
 	assert.Equal(t, "two", exp.([]interface{})[1])
 
-	exp = defs["200"].Examples.Values["text/plain"]
+	exp = defs.GetOrZero("200").Examples.Values.GetOrZero("text/plain")
 	assert.Equal(t, "something else.", exp)
 
-	expWentLow := defs["200"].Examples.GoLow()
+	expWentLow := defs.GetOrZero("200").Examples.GoLow()
 	assert.Equal(t, 702, expWentLow.FindExample("application/json").ValueNode.Line)
 	assert.Equal(t, 9, expWentLow.FindExample("application/json").ValueNode.Column)
 
 	wentLow := highDoc.Responses.GoLow()
 	assert.Equal(t, 669, wentLow.FindResponse("200").ValueNode.Line)
 
-	y := defs["500"].Headers["someHeader"]
+	y := defs.GetOrZero("500").Headers.GetOrZero("someHeader")
 	assert.Len(t, y.Enum, 2)
 	x := y.Items
 
@@ -192,7 +192,7 @@ func TestNewSwaggerDocument_Definitions(t *testing.T) {
 	initTest()
 	highDoc := NewSwaggerDocument(doc)
 
-	assert.Len(t, highDoc.Definitions.Definitions, 6)
+	assert.Equal(t, 6, highDoc.Definitions.Definitions.Len())
 
 	wentLow := highDoc.Definitions.GoLow()
 	assert.Equal(t, 848, wentLow.FindSchema("User").ValueNode.Line)
@@ -202,9 +202,9 @@ func TestNewSwaggerDocument_Definitions(t *testing.T) {
 func TestNewSwaggerDocument_Paths(t *testing.T) {
 	initTest()
 	highDoc := NewSwaggerDocument(doc)
-	assert.Len(t, highDoc.Paths.PathItems, 15)
+	assert.Equal(t, 15, highDoc.Paths.PathItems.Len())
 
-	upload := highDoc.Paths.PathItems["/pet/{petId}/uploadImage"]
+	upload := highDoc.Paths.PathItems.GetOrZero("/pet/{petId}/uploadImage")
 	assert.Equal(t, "man", upload.Extensions["x-potato"])
 	assert.Nil(t, upload.Get)
 	assert.Nil(t, upload.Put)
@@ -262,11 +262,11 @@ func TestNewSwaggerDocument_Responses(t *testing.T) {
 
 	initTest()
 	highDoc := NewSwaggerDocument(doc)
-	upload := highDoc.Paths.PathItems["/pet/{petId}/uploadImage"].Post
+	upload := highDoc.Paths.PathItems.GetOrZero("/pet/{petId}/uploadImage").Post
 
-	assert.Len(t, upload.Responses.Codes, 1)
+	assert.Equal(t, 1, upload.Responses.Codes.Len())
 
-	OK := upload.Responses.Codes["200"]
+	OK := upload.Responses.Codes.GetOrZero("200")
 	assert.Equal(t, "successful operation", OK.Description)
 	assert.Equal(t, "a generic API response object", OK.Schema.Schema().Description)
 
@@ -278,3 +278,38 @@ func TestNewSwaggerDocument_Responses(t *testing.T) {
 	assert.Equal(t, 11, wentLower.Schema.KeyNode.Column)
 
 }
+
+func TestSwagger_PruneUnusedComponents(t *testing.T) {
+	yml := `swagger: 2.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          $ref: '#/responses/Pets'
+responses:
+  Pets:
+    description: pets
+    schema:
+      $ref: '#/definitions/Pet'
+  Unused:
+    description: unused
+definitions:
+  Pet:
+    type: object
+  Tag:
+    type: string`
+
+	info, _ := datamodel.ExtractSpecInfo([]byte(yml))
+	lowDoc, err := v2.CreateDocument(info)
+	assert.Empty(t, err)
+	h := NewSwaggerDocument(lowDoc)
+
+	pruned := h.PruneUnusedComponents()
+	assert.Len(t, pruned, 2)
+	assert.Equal(t, "#/responses/Unused", pruned[0].Definition)
+	assert.Equal(t, "#/definitions/Tag", pruned[1].Definition)
+	assert.Equal(t, 1, h.Definitions.Definitions.Len())
+	assert.NotNil(t, h.Definitions.Definitions.GetOrZero("Pet"))
+	assert.Equal(t, 1, h.Responses.Definitions.Len())
+}
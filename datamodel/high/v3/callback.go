@@ -19,8 +19,8 @@ import (
 // that identifies a URL to use for the callback operation.
 //  - https://spec.openapis.org/oas/v3.1.0#callback-object
 type Callback struct {
-	Expression map[string]*PathItem `json:"-" yaml:"-"`
-	Extensions map[string]any       `json:"-" yaml:"-"`
+	Expression *high.OrderedMap[string, *PathItem] `json:"-" yaml:"-"`
+	Extensions map[string]any                      `json:"-" yaml:"-"`
 	low        *low.Callback
 }
 
@@ -28,10 +28,11 @@ type Callback struct {
 func NewCallback(lowCallback *low.Callback) *Callback {
 	n := new(Callback)
 	n.low = lowCallback
-	n.Expression = make(map[string]*PathItem)
+	expressions := make(map[string]*PathItem)
 	for i := range lowCallback.Expression.Value {
-		n.Expression[i.Value] = NewPathItem(lowCallback.Expression.Value[i].Value)
+		expressions[i.Value] = NewPathItem(lowCallback.Expression.Value[i].Value)
 	}
+	n.Expression = high.NewOrderedMapFromLow(lowCallback.Expression.Value, expressions)
 	n.Extensions = make(map[string]any)
 	for k, v := range lowCallback.Extensions {
 		n.Extensions[k.Value] = v.Value
@@ -66,16 +67,18 @@ func (c *Callback) MarshalYAML() (interface{}, error) {
 	}
 	var mapped []*cbItem
 
-	for k, ex := range c.Expression {
-		ln := 999 // default to a high value to weight new content to the bottom.
+	// expressions are rendered in the order of the map, each expression is weighted by the line it was found on
+	// (or the line of the expression before it), so extensions stay where they were.
+	ln := 0
+	for _, k := range c.Expression.Keys() {
 		if c.low != nil {
 			for lKey := range c.low.Expression.Value {
-				if lKey.Value == k {
+				if lKey.Value == k && lKey.KeyNode.Line > ln {
 					ln = lKey.KeyNode.Line
 				}
 			}
 		}
-		mapped = append(mapped, &cbItem{ex, k, ln, nil})
+		mapped = append(mapped, &cbItem{c.Expression.GetOrZero(k), k, ln, nil})
 	}
 
 	// extract extensions
@@ -93,7 +96,7 @@ func (c *Callback) MarshalYAML() (interface{}, error) {
 		}
 	}
 
-	sort.Slice(mapped, func(i, j int) bool {
+	sort.SliceStable(mapped, func(i, j int) bool {
 		return mapped[i].line < mapped[j].line
 	})
 	for j := range mapped {
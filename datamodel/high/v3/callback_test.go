@@ -4,6 +4,7 @@
 package v3
 
 import (
+    "github.com/pb33f/libopenapi/datamodel/high"
     "github.com/pb33f/libopenapi/datamodel/low"
     v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
     "github.com/pb33f/libopenapi/index"
@@ -16,35 +17,39 @@ import (
 func TestCallback_MarshalYAML(t *testing.T) {
 
     cb := &Callback{
-        Expression: map[string]*PathItem{
-            "https://pb33f.io": {
-                Get: &Operation{
-                    OperationId: "oneTwoThree",
-                },
-            },
-            "https://pb33f.io/libopenapi": {
-                Get: &Operation{
-                    OperationId: "openaypeeeye",
-                },
-            },
-        },
+        Expression: high.NewOrderedMap[string, *PathItem](),
         Extensions: map[string]any{
             "x-burgers": "why not?",
         },
     }
+    cb.Expression.Set("https://pb33f.io", &PathItem{Get: &Operation{OperationId: "oneTwoThree"}})
+    cb.Expression.Set("https://pb33f.io/libopenapi", &PathItem{Get: &Operation{OperationId: "openaypeeeye"}})
 
     rend, _ := cb.Render()
 
-    // there is no way to determine order in brand new maps, so we have to check length.
-    assert.Len(t, rend, 152)
+    // expressions are rendered in the order they were added.
+    desired := `https://pb33f.io:
+    get:
+        operationId: oneTwoThree
+https://pb33f.io/libopenapi:
+    get:
+        operationId: openaypeeeye
+x-burgers: why not?`
+    assert.Equal(t, desired, strings.TrimSpace(string(rend)))
 
     // mutate
-    cb.Expression["https://pb33f.io"].Get.OperationId = "blim-blam"
+    cb.Expression.GetOrZero("https://pb33f.io").Get.OperationId = "blim-blam"
     cb.Extensions = map[string]interface{}{"x-burgers": "yes please!"}
 
     rend, _ = cb.Render()
-    // there is no way to determine order in brand new maps, so we have to check length.
-    assert.Len(t, rend, 153)
+    desired = `https://pb33f.io:
+    get:
+        operationId: blim-blam
+https://pb33f.io/libopenapi:
+    get:
+        operationId: openaypeeeye
+x-burgers: yes please!`
+    assert.Equal(t, desired, strings.TrimSpace(string(rend)))
 
     k := `x-break-everything: please
 '{$request.query.queryUrl}':
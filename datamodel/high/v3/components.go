@@ -30,16 +30,16 @@ const (
 // will have no effect on the API unless they are explicitly referenced from properties outside the components object.
 //  - https://spec.openapis.org/oas/v3.1.0#components-object
 type Components struct {
-	Schemas map[string]*highbase.SchemaProxy `json:"schemas,omitempty" yaml:"schemas,omitempty"`
-	Responses map[string]*Response `json:"responses,omitempty" yaml:"responses,omitempty"`
-	Parameters map[string]*Parameter        `json:"parameters,omitempty" yaml:"parameters,omitempty"`
-	Examples   map[string]*highbase.Example `json:"examples,omitempty" yaml:"examples,omitempty"`
-	RequestBodies   map[string]*RequestBody    `json:"requestBodies,omitempty" yaml:"requestBodies,omitempty"`
-	Headers         map[string]*Header         `json:"headers,omitempty" yaml:"headers,omitempty"`
-	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty" yaml:"securitySchemes,omitempty"`
-	Links           map[string]*Link           `json:"links,omitempty" yaml:"links,omitempty"`
-	Callbacks       map[string]*Callback       `json:"callbacks,omitempty" yaml:"callbacks,omitempty"`
-	Extensions      map[string]any             `json:"-" yaml:"-"`
+	Schemas         *high.OrderedMap[string, *highbase.SchemaProxy] `json:"schemas,omitempty" yaml:"schemas,omitempty"`
+	Responses       *high.OrderedMap[string, *Response]             `json:"responses,omitempty" yaml:"responses,omitempty"`
+	Parameters      *high.OrderedMap[string, *Parameter]            `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	Examples        *high.OrderedMap[string, *highbase.Example]     `json:"examples,omitempty" yaml:"examples,omitempty"`
+	RequestBodies   *high.OrderedMap[string, *RequestBody]          `json:"requestBodies,omitempty" yaml:"requestBodies,omitempty"`
+	Headers         *high.OrderedMap[string, *Header]               `json:"headers,omitempty" yaml:"headers,omitempty"`
+	SecuritySchemes *high.OrderedMap[string, *SecurityScheme]       `json:"securitySchemes,omitempty" yaml:"securitySchemes,omitempty"`
+	Links           *high.OrderedMap[string, *Link]                 `json:"links,omitempty" yaml:"links,omitempty"`
+	Callbacks       *high.OrderedMap[string, *Callback]             `json:"callbacks,omitempty" yaml:"callbacks,omitempty"`
+	Extensions      map[string]any                                  `json:"-" yaml:"-"`
 	low             *low.Components
 }
 
@@ -138,15 +138,15 @@ func NewComponents(comp *low.Components) *Components {
 			securitySchemeMap[ssRes.key] = ssRes.res
 		}
 	}
-	c.Schemas = schemas
-	c.Callbacks = cbMap
-	c.Links = linkMap
-	c.Parameters = parameterMap
-	c.Headers = headerMap
-	c.Responses = responseMap
-	c.RequestBodies = requestBodyMap
-	c.Examples = exampleMap
-	c.SecuritySchemes = securitySchemeMap
+	c.Schemas = high.NewOrderedMapFromLow(comp.Schemas.Value, schemas)
+	c.Callbacks = high.NewOrderedMapFromLow(comp.Callbacks.Value, cbMap)
+	c.Links = high.NewOrderedMapFromLow(comp.Links.Value, linkMap)
+	c.Parameters = high.NewOrderedMapFromLow(comp.Parameters.Value, parameterMap)
+	c.Headers = high.NewOrderedMapFromLow(comp.Headers.Value, headerMap)
+	c.Responses = high.NewOrderedMapFromLow(comp.Responses.Value, responseMap)
+	c.RequestBodies = high.NewOrderedMapFromLow(comp.RequestBodies.Value, requestBodyMap)
+	c.Examples = high.NewOrderedMapFromLow(comp.Examples.Value, exampleMap)
+	c.SecuritySchemes = high.NewOrderedMapFromLow(comp.SecuritySchemes.Value, securitySchemeMap)
 	return c
 }
 
@@ -4,6 +4,7 @@
 package v3
 
 import (
+    "github.com/pb33f/libopenapi/datamodel/high"
     "github.com/pb33f/libopenapi/datamodel/low"
     v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
     "github.com/pb33f/libopenapi/index"
@@ -15,28 +16,17 @@ import (
 
 func TestComponents_MarshalYAML(t *testing.T) {
 
+    content := high.NewOrderedMap[string, *MediaType]()
+    content.Set("application/json", &MediaType{Example: "why?"})
+
     comp := &Components{
-        Responses: map[string]*Response{
-            "200": {
-                Description: "OK",
-            },
-        },
-        Parameters: map[string]*Parameter{
-            "id": {
-                Name: "id",
-                In:   "path",
-            },
-        },
-        RequestBodies: map[string]*RequestBody{
-            "body": {
-                Content: map[string]*MediaType{
-                    "application/json": {
-                        Example: "why?",
-                    },
-                },
-            },
-        },
+        Responses:     high.NewOrderedMap[string, *Response](),
+        Parameters:    high.NewOrderedMap[string, *Parameter](),
+        RequestBodies: high.NewOrderedMap[string, *RequestBody](),
     }
+    comp.Responses.Set("200", &Response{Description: "OK"})
+    comp.Parameters.Set("id", &Parameter{Name: "id", In: "path"})
+    comp.RequestBodies.Set("body", &RequestBody{Content: content})
 
     dat, _ := comp.Render()
 
@@ -66,3 +56,39 @@ requestBodies:
     dat, _ = r.Render()
     assert.Equal(t, desired, strings.TrimSpace(string(dat)))
 }
+
+func TestNewComponents_Ordered(t *testing.T) {
+    yml := `responses:
+    Zebra:
+        description: stripes
+        content:
+            text/plain:
+                example: z
+            application/json:
+                example: z
+    Apple:
+        description: crunchy
+parameters:
+    offset:
+        name: offset
+        in: query
+    limit:
+        name: limit
+        in: query`
+
+    var idxNode yaml.Node
+    _ = yaml.Unmarshal([]byte(yml), &idxNode)
+    idx := index.NewSpecIndexWithConfig(&idxNode, index.CreateOpenAPIIndexConfig())
+
+    var n v3.Components
+    _ = low.BuildModel(idxNode.Content[0], &n)
+    _ = n.Build(idxNode.Content[0], idx)
+
+    r := NewComponents(&n)
+    assert.Equal(t, []string{"Zebra", "Apple"}, r.Responses.Keys())
+    assert.Equal(t, []string{"text/plain", "application/json"}, r.Responses.GetOrZero("Zebra").Content.Keys())
+    assert.Equal(t, []string{"offset", "limit"}, r.Parameters.Keys())
+
+    dat, _ := r.Render()
+    assert.Equal(t, yml, strings.TrimSpace(string(dat)))
+}
@@ -79,7 +79,7 @@ type Document struct {
 	// for example by an out-of-band registration. The key name is a unique string to refer to each webhook,
 	// while the (optionally referenced) Path Item Object describes a request that may be initiated by the API provider
 	// and the expected responses. An example is available.
-	Webhooks map[string]*PathItem `json:"webhooks,omitempty" yaml:"webhooks,omitempty"`
+	Webhooks *high.OrderedMap[string, *PathItem] `json:"webhooks,omitempty" yaml:"webhooks,omitempty"`
 
 	// Index is a reference to the *index.SpecIndex that was created for the document and used
 	// as a guide when building out the Document. Ideal if further processing is required on the model and
@@ -131,7 +131,7 @@ func NewDocument(document *low.Document) *Document {
 		for h := range document.Webhooks.Value {
 			hooks[h.Value] = NewPathItem(document.Webhooks.Value[h].Value)
 		}
-		d.Webhooks = hooks
+		d.Webhooks = high.NewOrderedMapFromLow(document.Webhooks.Value, hooks)
 	}
 	if !document.Security.IsEmpty() {
 		var security []*base.SecurityRequirement
@@ -148,6 +148,62 @@ func (d *Document) GoLow() *low.Document {
 	return d.low
 }
 
+// PruneUnusedComponents removes every component that is not used by any operation or webhook, and returns what
+// was removed. Usage is worked out by the Index (see index.SpecIndex.GetUnusedComponents), so it reflects the
+// document as it was read, not any changes made to the model since. Components in a section the model does not
+// hold (like 'pathItems') are not removed, or returned.
+func (d *Document) PruneUnusedComponents() []*index.UnusedComponent {
+	if d.Index == nil || d.Components == nil {
+		return nil
+	}
+	return d.RemoveComponents(d.Index.GetUnusedComponents())
+}
+
+// RemoveComponents removes components from the document, by section and name, and returns the components that
+// were found and removed.
+func (d *Document) RemoveComponents(components []*index.UnusedComponent) []*index.UnusedComponent {
+	if d.Components == nil {
+		return nil
+	}
+	var removed []*index.UnusedComponent
+	for _, c := range components {
+		var found bool
+		switch c.Section {
+		case "schemas":
+			found = removeComponent(d.Components.Schemas, c.Name)
+		case "responses":
+			found = removeComponent(d.Components.Responses, c.Name)
+		case "parameters":
+			found = removeComponent(d.Components.Parameters, c.Name)
+		case "examples":
+			found = removeComponent(d.Components.Examples, c.Name)
+		case "requestBodies":
+			found = removeComponent(d.Components.RequestBodies, c.Name)
+		case "headers":
+			found = removeComponent(d.Components.Headers, c.Name)
+		case "securitySchemes":
+			found = removeComponent(d.Components.SecuritySchemes, c.Name)
+		case "links":
+			found = removeComponent(d.Components.Links, c.Name)
+		case "callbacks":
+			found = removeComponent(d.Components.Callbacks, c.Name)
+		}
+		if found {
+			removed = append(removed, c)
+		}
+	}
+	return removed
+}
+
+// removeComponent deletes a component from a section, and returns true if it was there.
+func removeComponent[V any](section *high.OrderedMap[string, V], name string) bool {
+	if _, ok := section.Get(name); !ok {
+		return false
+	}
+	section.Delete(name)
+	return true
+}
+
 // Render will return a YAML representation of the Document object as a byte slice.
 func (d *Document) Render() ([]byte, error) {
 	return yaml.Marshal(d)
@@ -13,11 +13,11 @@ import (
 // Encoding represents an OpenAPI 3+ Encoding object
 //  - https://spec.openapis.org/oas/v3.1.0#encoding-object
 type Encoding struct {
-	ContentType   string             `json:"contentType,omitempty" yaml:"contentType,omitempty"`
-	Headers       map[string]*Header `json:"headers,omitempty" yaml:"headers,omitempty"`
-	Style         string             `json:"style,omitempty" yaml:"style,omitempty"`
-	Explode       *bool              `json:"explode,omitempty" yaml:"explode,omitempty"`
-	AllowReserved bool               `json:"allowReserved,omitempty" yaml:"allowReserved,omitempty"`
+	ContentType   string                            `json:"contentType,omitempty" yaml:"contentType,omitempty"`
+	Headers       *high.OrderedMap[string, *Header] `json:"headers,omitempty" yaml:"headers,omitempty"`
+	Style         string                            `json:"style,omitempty" yaml:"style,omitempty"`
+	Explode       *bool                             `json:"explode,omitempty" yaml:"explode,omitempty"`
+	AllowReserved bool                              `json:"allowReserved,omitempty" yaml:"allowReserved,omitempty"`
 	low           *low.Encoding
 }
 
@@ -54,11 +54,12 @@ func (e *Encoding) MarshalYAML() (interface{}, error) {
 	return nb.Render(), nil
 }
 
-// ExtractEncoding converts hard to navigate low-level plumbing Encoding definitions, into a high-level simple map
-func ExtractEncoding(elements map[lowmodel.KeyReference[string]]lowmodel.ValueReference[*low.Encoding]) map[string]*Encoding {
+// ExtractEncoding converts hard to navigate low-level plumbing Encoding definitions, into a high-level simple map,
+// in the same order as the specification.
+func ExtractEncoding(elements map[lowmodel.KeyReference[string]]lowmodel.ValueReference[*low.Encoding]) *high.OrderedMap[string, *Encoding] {
 	extracted := make(map[string]*Encoding)
 	for k, v := range elements {
 		extracted[k.Value] = NewEncoding(v.Value)
 	}
-	return extracted
+	return high.NewOrderedMapFromLow(elements, extracted)
 }
@@ -4,6 +4,7 @@
 package v3
 
 import (
+    "github.com/pb33f/libopenapi/datamodel/high"
     "github.com/stretchr/testify/assert"
     "strings"
     "testing"
@@ -12,9 +13,11 @@ import (
 func TestEncoding_MarshalYAML(t *testing.T) {
 
     explode := true
+    headers := high.NewOrderedMap[string, *Header]()
+    headers.Set("x-pizza-time", &Header{Description: "oh yes please"})
     encoding := &Encoding{
         ContentType: "application/json",
-        Headers:     map[string]*Header{"x-pizza-time": {Description: "oh yes please"}},
+        Headers:     headers,
         Style:       "simple",
         Explode:     &explode,
     }
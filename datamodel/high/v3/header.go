@@ -15,18 +15,18 @@ import (
 // Header represents a high-level OpenAPI 3+ Header object that is backed by a low-level one.
 //  - https://spec.openapis.org/oas/v3.1.0#header-object
 type Header struct {
-	Description     string                       `json:"description,omitempty" yaml:"description,omitempty"`
-	Required        bool                         `json:"required,omitempty" yaml:"required,omitempty"`
-	Deprecated      bool                         `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
-	AllowEmptyValue bool                         `json:"allowEmptyValue,omitempty" yaml:"allowEmptyValue,omitempty"`
-	Style           string                       `json:"style,omitempty" yaml:"style,omitempty"`
-	Explode         bool                         `json:"explode,omitempty" yaml:"explode,omitempty"`
-	AllowReserved   bool                         `json:"allowReserved,omitempty" yaml:"allowReserved,omitempty"`
-	Schema          *highbase.SchemaProxy        `json:"schema,omitempty" yaml:"schema,omitempty"`
-	Example         any                          `json:"example,omitempty" yaml:"example,omitempty"`
-	Examples        map[string]*highbase.Example `json:"examples,omitempty" yaml:"examples,omitempty"`
-	Content         map[string]*MediaType        `json:"content,omitempty" yaml:"content,omitempty"`
-	Extensions      map[string]any               `json:"-" yaml:"-"`
+	Description     string                                      `json:"description,omitempty" yaml:"description,omitempty"`
+	Required        bool                                        `json:"required,omitempty" yaml:"required,omitempty"`
+	Deprecated      bool                                        `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+	AllowEmptyValue bool                                        `json:"allowEmptyValue,omitempty" yaml:"allowEmptyValue,omitempty"`
+	Style           string                                      `json:"style,omitempty" yaml:"style,omitempty"`
+	Explode         bool                                        `json:"explode,omitempty" yaml:"explode,omitempty"`
+	AllowReserved   bool                                        `json:"allowReserved,omitempty" yaml:"allowReserved,omitempty"`
+	Schema          *highbase.SchemaProxy                       `json:"schema,omitempty" yaml:"schema,omitempty"`
+	Example         any                                         `json:"example,omitempty" yaml:"example,omitempty"`
+	Examples        *high.OrderedMap[string, *highbase.Example] `json:"examples,omitempty" yaml:"examples,omitempty"`
+	Content         *high.OrderedMap[string, *MediaType]        `json:"content,omitempty" yaml:"content,omitempty"`
+	Extensions      map[string]any                              `json:"-" yaml:"-"`
 	low             *low.Header
 }
 
@@ -64,13 +64,14 @@ func (h *Header) GoLowUntyped() any {
 	return h.low
 }
 
-// ExtractHeaders will extract a hard to navigate low-level Header map, into simple high-level one.
-func ExtractHeaders(elements map[lowmodel.KeyReference[string]]lowmodel.ValueReference[*low.Header]) map[string]*Header {
+// ExtractHeaders will extract a hard to navigate low-level Header map, into simple high-level one. The headers are
+// in the same order as the specification.
+func ExtractHeaders(elements map[lowmodel.KeyReference[string]]lowmodel.ValueReference[*low.Header]) *high.OrderedMap[string, *Header] {
 	extracted := make(map[string]*Header)
 	for k, v := range elements {
 		extracted[k.Value] = NewHeader(v.Value)
 	}
-	return extracted
+	return high.NewOrderedMapFromLow(elements, extracted)
 }
 
 // Render will return a YAML representation of the Header object as a byte slice.
@@ -4,6 +4,7 @@
 package v3
 
 import (
+    "github.com/pb33f/libopenapi/datamodel/high"
     "github.com/pb33f/libopenapi/datamodel/high/base"
     "github.com/stretchr/testify/assert"
     "strings"
@@ -12,6 +13,9 @@ import (
 
 func TestHeader_MarshalYAML(t *testing.T) {
 
+    examples := high.NewOrderedMap[string, *base.Example]()
+    examples.Set("example", &base.Example{Value: "example"})
+
     header := &Header{
         Description:     "A header",
         Required:        true,
@@ -21,7 +25,7 @@ func TestHeader_MarshalYAML(t *testing.T) {
         Explode:         true,
         AllowReserved:   true,
         Example:         "example",
-        Examples:        map[string]*base.Example{"example": {Value: "example"}},
+        Examples:        examples,
         Extensions:      map[string]interface{}{"x-burgers": "why not?"},
     }
 
@@ -22,13 +22,13 @@ import (
 // in an operation and using them as parameters while invoking the linked operation.
 //   - https://spec.openapis.org/oas/v3.1.0#link-object
 type Link struct {
-	OperationRef string            `json:"operationRef,omitempty" yaml:"operationRef,omitempty"`
-	OperationId  string            `json:"operationId,omitempty" yaml:"operationId,omitempty"`
-	Parameters   map[string]string `json:"parameters,omitempty" yaml:"parameters,omitempty"`
-	RequestBody  string            `json:"requestBody,omitempty" yaml:"requestBody,omitempty"`
-	Description  string            `json:"description,omitempty" yaml:"description,omitempty"`
-	Server       *Server           `json:"server,omitempty" yaml:"server,omitempty"`
-	Extensions   map[string]any    `json:"-" yaml:"-"`
+	OperationRef string                           `json:"operationRef,omitempty" yaml:"operationRef,omitempty"`
+	OperationId  string                           `json:"operationId,omitempty" yaml:"operationId,omitempty"`
+	Parameters   *high.OrderedMap[string, string] `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	RequestBody  string                           `json:"requestBody,omitempty" yaml:"requestBody,omitempty"`
+	Description  string                           `json:"description,omitempty" yaml:"description,omitempty"`
+	Server       *Server                          `json:"server,omitempty" yaml:"server,omitempty"`
+	Extensions   map[string]any                   `json:"-" yaml:"-"`
 	low          *low.Link
 }
 
@@ -42,7 +42,7 @@ func NewLink(link *low.Link) *Link {
 	for k, v := range link.Parameters.Value {
 		params[k.Value] = v.Value
 	}
-	l.Parameters = params
+	l.Parameters = high.NewOrderedMapFromLow(link.Parameters.Value, params)
 	l.RequestBody = link.RequestBody.Value
 	l.Description = link.Description.Value
 	if link.Server.Value != nil {
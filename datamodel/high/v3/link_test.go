@@ -4,18 +4,20 @@
 package v3
 
 import (
+    "github.com/pb33f/libopenapi/datamodel/high"
     "github.com/stretchr/testify/assert"
     "strings"
     "testing"
 )
 
 func TestLink_MarshalYAML(t *testing.T) {
+    params := high.NewOrderedMap[string, string]()
+    params.Set("over", "theRainbow")
+
     link := Link{
         OperationRef: "somewhere",
         OperationId:  "somewhereOutThere",
-        Parameters: map[string]string{
-            "over": "theRainbow",
-        },
+        Parameters:   params,
         RequestBody: "hello?",
         Description: "are you there?",
         Server: &Server{
@@ -18,11 +18,11 @@ import (
 // Each Media Type Object provides schema and examples for the media type identified by its key.
 //   - https://spec.openapis.org/oas/v3.1.0#media-type-object
 type MediaType struct {
-	Schema     *base.SchemaProxy        `json:"schema,omitempty" yaml:"schema,omitempty"`
-	Example    any                      `json:"example,omitempty" yaml:"example,omitempty"`
-	Examples   map[string]*base.Example `json:"examples,omitempty" yaml:"examples,omitempty"`
-	Encoding   map[string]*Encoding     `json:"encoding,omitempty" yaml:"encoding,omitempty"`
-	Extensions map[string]any           `json:"-" yaml:"-"`
+	Schema     *base.SchemaProxy                       `json:"schema,omitempty" yaml:"schema,omitempty"`
+	Example    any                                     `json:"example,omitempty" yaml:"example,omitempty"`
+	Examples   *high.OrderedMap[string, *base.Example] `json:"examples,omitempty" yaml:"examples,omitempty"`
+	Encoding   *high.OrderedMap[string, *Encoding]     `json:"encoding,omitempty" yaml:"encoding,omitempty"`
+	Extensions map[string]any                          `json:"-" yaml:"-"`
 	low        *low.MediaType
 }
 
@@ -73,8 +73,8 @@ func (m *MediaType) MarshalYAMLInline() (interface{}, error) {
 }
 
 // ExtractContent takes in a complex and hard to navigate low-level content map, and converts it in to a much simpler
-// and easier to navigate high-level one.
-func ExtractContent(elements map[lowmodel.KeyReference[string]]lowmodel.ValueReference[*low.MediaType]) map[string]*MediaType {
+// and easier to navigate high-level one. The media types are in the same order as the specification.
+func ExtractContent(elements map[lowmodel.KeyReference[string]]lowmodel.ValueReference[*low.MediaType]) *high.OrderedMap[string, *MediaType] {
 	// extract everything async
 	doneChan := make(chan bool)
 
@@ -98,5 +98,5 @@ func ExtractContent(elements map[lowmodel.KeyReference[string]]lowmodel.ValueRef
 			n++
 		}
 	}
-	return extracted
+	return high.NewOrderedMapFromLow(elements, extracted)
 }
@@ -12,11 +12,11 @@ import (
 // OAuthFlow represents a high-level OpenAPI 3+ OAuthFlow object that is backed by a low-level one.
 //  - https://spec.openapis.org/oas/v3.1.0#oauth-flow-object
 type OAuthFlow struct {
-	AuthorizationUrl string            `json:"authorizationUrl,omitempty" yaml:"authorizationUrl,omitempty"`
-	TokenUrl         string            `json:"tokenUrl,omitempty" yaml:"tokenUrl,omitempty"`
-	RefreshUrl       string            `json:"refreshUrl,omitempty" yaml:"refreshUrl,omitempty"`
-	Scopes           map[string]string `json:"scopes,omitempty" yaml:"scopes,omitempty"`
-	Extensions       map[string]any    `json:"-" yaml:"-"`
+	AuthorizationUrl string                           `json:"authorizationUrl,omitempty" yaml:"authorizationUrl,omitempty"`
+	TokenUrl         string                           `json:"tokenUrl,omitempty" yaml:"tokenUrl,omitempty"`
+	RefreshUrl       string                           `json:"refreshUrl,omitempty" yaml:"refreshUrl,omitempty"`
+	Scopes           *high.OrderedMap[string, string] `json:"scopes,omitempty" yaml:"scopes,omitempty"`
+	Extensions       map[string]any                   `json:"-" yaml:"-"`
 	low              *low.OAuthFlow
 }
 
@@ -31,7 +31,7 @@ func NewOAuthFlow(flow *low.OAuthFlow) *OAuthFlow {
 	for k, v := range flow.Scopes.Value {
 		scopes[k.Value] = v.Value
 	}
-	o.Scopes = scopes
+	o.Scopes = high.NewOrderedMapFromLow(flow.Scopes.Value, scopes)
 	o.Extensions = high.ExtractExtensions(flow.Extensions)
 	return o
 }
@@ -15,20 +15,20 @@ import (
 // A unique parameter is defined by a combination of a name and location.
 //   - https://spec.openapis.org/oas/v3.1.0#parameter-object
 type Parameter struct {
-	Name            string                   `json:"name,omitempty" yaml:"name,omitempty"`
-	In              string                   `json:"in,omitempty" yaml:"in,omitempty"`
-	Description     string                   `json:"description,omitempty" yaml:"description,omitempty"`
-	Required        bool                     `json:"required,omitempty" yaml:"required,omitempty"`
-	Deprecated      bool                     `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
-	AllowEmptyValue bool                     `json:"allowEmptyValue,omitempty" yaml:"allowEmptyValue,omitempty"`
-	Style           string                   `json:"style,omitempty" yaml:"style,omitempty"`
-	Explode         *bool                    `json:"explode,omitempty" yaml:"explode,omitempty"`
-	AllowReserved   bool                     `json:"allowReserved,omitempty" yaml:"allowReserved,omitempty"`
-	Schema          *base.SchemaProxy        `json:"schema,omitempty" yaml:"schema,omitempty"`
-	Example         any                      `json:"example,omitempty" yaml:"example,omitempty"`
-	Examples        map[string]*base.Example `json:"examples,omitempty" yaml:"examples,omitempty"`
-	Content         map[string]*MediaType    `json:"content,omitempty" yaml:"content,omitempty"`
-	Extensions      map[string]any           `json:"-" yaml:"-"`
+	Name            string                                  `json:"name,omitempty" yaml:"name,omitempty"`
+	In              string                                  `json:"in,omitempty" yaml:"in,omitempty"`
+	Description     string                                  `json:"description,omitempty" yaml:"description,omitempty"`
+	Required        bool                                    `json:"required,omitempty" yaml:"required,omitempty"`
+	Deprecated      bool                                    `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+	AllowEmptyValue bool                                    `json:"allowEmptyValue,omitempty" yaml:"allowEmptyValue,omitempty"`
+	Style           string                                  `json:"style,omitempty" yaml:"style,omitempty"`
+	Explode         *bool                                   `json:"explode,omitempty" yaml:"explode,renderZero,omitempty"`
+	AllowReserved   bool                                    `json:"allowReserved,omitempty" yaml:"allowReserved,omitempty"`
+	Schema          *base.SchemaProxy                       `json:"schema,omitempty" yaml:"schema,omitempty"`
+	Example         any                                     `json:"example,omitempty" yaml:"example,omitempty"`
+	Examples        *high.OrderedMap[string, *base.Example] `json:"examples,omitempty" yaml:"examples,omitempty"`
+	Content         *high.OrderedMap[string, *MediaType]    `json:"content,omitempty" yaml:"content,omitempty"`
+	Extensions      map[string]any                          `json:"-" yaml:"-"`
 	low             *low.Parameter
 }
 
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/pb33f/libopenapi/datamodel/high"
 	"github.com/pb33f/libopenapi/datamodel/high/base"
 	"github.com/stretchr/testify/assert"
 )
@@ -14,6 +15,8 @@ import (
 func TestParameter_MarshalYAML(t *testing.T) {
 
 	explode := true
+	examples := high.NewOrderedMap[string, *base.Example]()
+	examples.Set("example", &base.Example{Value: "example"})
 	param := Parameter{
 		Name:          "chicken",
 		In:            "nuggets",
@@ -23,7 +26,7 @@ func TestParameter_MarshalYAML(t *testing.T) {
 		Explode:       &explode,
 		AllowReserved: true,
 		Example:       "example",
-		Examples:      map[string]*base.Example{"example": {Value: "example"}},
+		Examples:      examples,
 		Extensions:    map[string]interface{}{"x-burgers": "why not?"},
 	}
 
@@ -45,9 +48,31 @@ x-burgers: why not?`
 	assert.Equal(t, desired, strings.TrimSpace(string(rend)))
 }
 
+func TestParameter_MarshalYAML_NoExplode(t *testing.T) {
+
+	explode := false
+	param := Parameter{
+		Name:    "tags",
+		In:      "query",
+		Style:   "form",
+		Explode: &explode,
+	}
+
+	rend, _ := param.Render()
+
+	desired := `name: tags
+in: query
+style: form
+explode: false`
+
+	assert.Equal(t, desired, strings.TrimSpace(string(rend)))
+}
+
 func TestParameter_MarshalYAMLInline(t *testing.T) {
 
 	explode := true
+	examples := high.NewOrderedMap[string, *base.Example]()
+	examples.Set("example", &base.Example{Value: "example"})
 	param := Parameter{
 		Name:          "chicken",
 		In:            "nuggets",
@@ -57,7 +82,7 @@ func TestParameter_MarshalYAMLInline(t *testing.T) {
 		Explode:       &explode,
 		AllowReserved: true,
 		Example:       "example",
-		Examples:      map[string]*base.Example{"example": {Value: "example"}},
+		Examples:      examples,
 		Extensions:    map[string]interface{}{"x-burgers": "why not?"},
 	}
 
@@ -82,6 +107,8 @@ x-burgers: why not?`
 func TestParameter_IsExploded(t *testing.T) {
 
 	explode := true
+	examples := high.NewOrderedMap[string, *base.Example]()
+	examples.Set("example", &base.Example{Value: "example"})
 	param := Parameter{
 		Explode: &explode,
 	}
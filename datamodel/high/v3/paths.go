@@ -19,8 +19,8 @@ import (
 // constraints.
 //   - https://spec.openapis.org/oas/v3.1.0#paths-object
 type Paths struct {
-	PathItems  map[string]*PathItem `json:"-" yaml:"-"`
-	Extensions map[string]any       `json:"-" yaml:"-"`
+	PathItems  *high.OrderedMap[string, *PathItem] `json:"-" yaml:"-"`
+	Extensions map[string]any                      `json:"-" yaml:"-"`
 	low        *low.Paths
 }
 
@@ -51,7 +51,7 @@ func NewPaths(paths *low.Paths) *Paths {
 			items[r.k] = r.v
 		}
 	}
-	p.PathItems = items
+	p.PathItems = high.NewOrderedMapFromLow(paths.PathItems, items)
 	return p
 }
 
@@ -87,15 +87,17 @@ func (p *Paths) MarshalYAML() (interface{}, error) {
 	}
 	var mapped []*pathItem
 
-	for k, pi := range p.PathItems {
-		ln := 9999 // default to a high value to weight new content to the bottom.
+	// paths are rendered in the order of the map, each path is weighted by the line it was found on (or the line
+	// of the path before it), so extensions stay where they were.
+	ln := 0
+	for _, k := range p.PathItems.Keys() {
 		if p.low != nil {
 			lpi := p.low.FindPath(k)
-			if lpi != nil {
+			if lpi != nil && lpi.ValueNode.Line > ln {
 				ln = lpi.ValueNode.Line
 			}
 		}
-		mapped = append(mapped, &pathItem{pi, k, ln, nil})
+		mapped = append(mapped, &pathItem{p.PathItems.GetOrZero(k), k, ln, nil})
 	}
 
 	nb := high.NewNodeBuilder(p, p.low)
@@ -112,7 +114,7 @@ func (p *Paths) MarshalYAML() (interface{}, error) {
 		}
 	}
 
-	sort.Slice(mapped, func(i, j int) bool {
+	sort.SliceStable(mapped, func(i, j int) bool {
 		return mapped[i].line < mapped[j].line
 	})
 	for j := range mapped {
@@ -141,15 +143,17 @@ func (p *Paths) MarshalYAMLInline() (interface{}, error) {
 	}
 	var mapped []*pathItem
 
-	for k, pi := range p.PathItems {
-		ln := 9999 // default to a high value to weight new content to the bottom.
+	// paths are rendered in the order of the map, each path is weighted by the line it was found on (or the line
+	// of the path before it), so extensions stay where they were.
+	ln := 0
+	for _, k := range p.PathItems.Keys() {
 		if p.low != nil {
 			lpi := p.low.FindPath(k)
-			if lpi != nil {
+			if lpi != nil && lpi.ValueNode.Line > ln {
 				ln = lpi.ValueNode.Line
 			}
 		}
-		mapped = append(mapped, &pathItem{pi, k, ln, nil})
+		mapped = append(mapped, &pathItem{p.PathItems.GetOrZero(k), k, ln, nil})
 	}
 
 	nb := high.NewNodeBuilder(p, p.low)
@@ -167,7 +171,7 @@ func (p *Paths) MarshalYAMLInline() (interface{}, error) {
 		}
 	}
 
-	sort.Slice(mapped, func(i, j int) bool {
+	sort.SliceStable(mapped, func(i, j int) bool {
 		return mapped[i].line < mapped[j].line
 	})
 	for j := range mapped {
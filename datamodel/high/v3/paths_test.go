@@ -48,7 +48,7 @@ func TestPaths_MarshalYAML(t *testing.T) {
 
 	// mutate
 	deprecated := true
-	high.PathItems["/beer"].Get.Deprecated = &deprecated
+	high.PathItems.GetOrZero("/beer").Get.Deprecated = &deprecated
 
 	yml = `/foo/bar/bizzle:
     get:
@@ -100,7 +100,7 @@ func TestPaths_MarshalYAMLInline(t *testing.T) {
 
 	// mutate
 	deprecated := true
-	high.PathItems["/beer"].Get.Deprecated = &deprecated
+	high.PathItems.GetOrZero("/beer").Get.Deprecated = &deprecated
 
 	yml = `/foo/bar/bizzle:
     get:
@@ -117,3 +117,50 @@ func TestPaths_MarshalYAMLInline(t *testing.T) {
 	assert.Equal(t, yml, strings.TrimSpace(string(rend)))
 
 }
+
+func TestPaths_Ordered(t *testing.T) {
+
+	yml := `/zebra:
+    get:
+        description: get a zebra
+/apple:
+    post:
+        description: post an apple
+x-pizza: time
+/mango:
+    get:
+        description: get a mango`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndexWithConfig(&idxNode, index.CreateOpenAPIIndexConfig())
+
+	var n v3low.Paths
+	err := low.BuildModel(&idxNode, &n)
+	assert.NoError(t, err)
+
+	err = n.Build(idxNode.Content[0], idx)
+	assert.NoError(t, err)
+
+	high := NewPaths(&n)
+	assert.Equal(t, []string{"/zebra", "/apple", "/mango"}, high.PathItems.Keys())
+
+	// move zebra to the end, the render should follow the order of the map.
+	zebra := high.PathItems.GetOrZero("/zebra")
+	high.PathItems.Delete("/zebra")
+	high.PathItems.Set("/zebra", zebra)
+
+	yml = `/apple:
+    post:
+        description: post an apple
+x-pizza: time
+/mango:
+    get:
+        description: get a mango
+/zebra:
+    get:
+        description: get a zebra`
+
+	rend, _ := high.Render()
+	assert.Equal(t, yml, strings.TrimSpace(string(rend)))
+}
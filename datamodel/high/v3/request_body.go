@@ -12,10 +12,10 @@ import (
 // RequestBody represents a high-level OpenAPI 3+ RequestBody object, backed by a low-level one.
 //   - https://spec.openapis.org/oas/v3.1.0#request-body-object
 type RequestBody struct {
-	Description string                `json:"description,omitempty" yaml:"description,omitempty"`
-	Content     map[string]*MediaType `json:"content,omitempty" yaml:"content,omitempty"`
-	Required    *bool                 `json:"required,omitempty" yaml:"required,renderZero,omitempty"`
-	Extensions  map[string]any        `json:"-" yaml:"-"`
+	Description string                               `json:"description,omitempty" yaml:"description,omitempty"`
+	Content     *high.OrderedMap[string, *MediaType] `json:"content,omitempty" yaml:"content,omitempty"`
+	Required    *bool                                `json:"required,omitempty" yaml:"required,renderZero,omitempty"`
+	Extensions  map[string]any                       `json:"-" yaml:"-"`
 	low         *low.RequestBody
 }
 
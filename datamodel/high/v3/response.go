@@ -15,11 +15,11 @@ import (
 // operations based on the response.
 //   - https://spec.openapis.org/oas/v3.1.0#response-object
 type Response struct {
-	Description string                `json:"description,omitempty" yaml:"description,omitempty"`
-	Headers     map[string]*Header    `json:"headers,omitempty" yaml:"headers,omitempty"`
-	Content     map[string]*MediaType `json:"content,omitempty" yaml:"content,omitempty"`
-	Links       map[string]*Link      `json:"links,omitempty" yaml:"links,omitempty"`
-	Extensions  map[string]any        `json:"-" yaml:"-"`
+	Description string                               `json:"description,omitempty" yaml:"description,omitempty"`
+	Headers     *high.OrderedMap[string, *Header]    `json:"headers,omitempty" yaml:"headers,omitempty"`
+	Content     *high.OrderedMap[string, *MediaType] `json:"content,omitempty" yaml:"content,omitempty"`
+	Links       *high.OrderedMap[string, *Link]      `json:"links,omitempty" yaml:"links,omitempty"`
+	Extensions  map[string]any                       `json:"-" yaml:"-"`
 	low         *low.Response
 }
 
@@ -40,7 +40,7 @@ func NewResponse(response *low.Response) *Response {
 		for k, v := range response.Links.Value {
 			responseLinks[k.Value] = NewLink(v.Value)
 		}
-		r.Links = responseLinks
+		r.Links = high.NewOrderedMapFromLow(response.Links.Value, responseLinks)
 	}
 	return r
 }
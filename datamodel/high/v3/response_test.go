@@ -42,10 +42,10 @@ links:
 
 	r := NewResponse(&n)
 
-	assert.Len(t, r.Headers, 1)
-	assert.Len(t, r.Content, 1)
+	assert.Equal(t, 1, r.Headers.Len())
+	assert.Equal(t, 1, r.Content.Len())
 	assert.Equal(t, "pizza!", r.Extensions["x-pizza-man"])
-	assert.Len(t, r.Links, 1)
+	assert.Equal(t, 1, r.Links.Len())
 	assert.Equal(t, 1, r.GoLow().Description.KeyNode.Line)
 
 }
@@ -28,9 +28,9 @@ import (
 // be the response for a successful operation call.
 //   - https://spec.openapis.org/oas/v3.1.0#responses-object
 type Responses struct {
-	Codes      map[string]*Response `json:"-" yaml:"-"`
-	Default    *Response            `json:"default,omitempty" yaml:"default,omitempty"`
-	Extensions map[string]any       `json:"-" yaml:"-"`
+	Codes      *high.OrderedMap[string, *Response] `json:"-" yaml:"-"`
+	Default    *Response                           `json:"default,omitempty" yaml:"default,omitempty"`
+	Extensions map[string]any                      `json:"-" yaml:"-"`
 	low        *low.Responses
 }
 
@@ -68,13 +68,13 @@ func NewResponses(responses *low.Responses) *Responses {
 			codes[re.code] = re.resp
 		}
 	}
-	r.Codes = codes
+	r.Codes = high.NewOrderedMapFromLow(responses.Codes, codes)
 	return r
 }
 
 // FindResponseByCode is a shortcut for looking up code by an integer vs. a string
 func (r *Responses) FindResponseByCode(code int) *Response {
-	return r.Codes[fmt.Sprintf("%d", code)]
+	return r.Codes.GetOrZero(fmt.Sprintf("%d", code))
 }
 
 // GoLow returns the low-level Response object used to create the high-level one.
@@ -109,16 +109,18 @@ func (r *Responses) MarshalYAML() (interface{}, error) {
 	}
 	var mapped []*responseItem
 
-	for k, re := range r.Codes {
-		ln := 9999 // default to a high value to weight new content to the bottom.
+	// codes are rendered in the order of the map, each code is weighted by the line it was found on (or the line
+	// of the code before it), so extensions stay where they were.
+	ln := 0
+	for _, k := range r.Codes.Keys() {
 		if r.low != nil {
 			for lKey := range r.low.Codes {
-				if lKey.Value == k {
+				if lKey.Value == k && lKey.KeyNode.Line > ln {
 					ln = lKey.KeyNode.Line
 				}
 			}
 		}
-		mapped = append(mapped, &responseItem{re, k, ln, nil})
+		mapped = append(mapped, &responseItem{r.Codes.GetOrZero(k), k, ln, nil})
 	}
 
 	// extract extensions
@@ -136,7 +138,7 @@ func (r *Responses) MarshalYAML() (interface{}, error) {
 		}
 	}
 
-	sort.Slice(mapped, func(i, j int) bool {
+	sort.SliceStable(mapped, func(i, j int) bool {
 		return mapped[i].line < mapped[j].line
 	})
 	for j := range mapped {
@@ -165,16 +167,18 @@ func (r *Responses) MarshalYAMLInline() (interface{}, error) {
 	}
 	var mapped []*responseItem
 
-	for k, re := range r.Codes {
-		ln := 9999 // default to a high value to weight new content to the bottom.
+	// codes are rendered in the order of the map, each code is weighted by the line it was found on (or the line
+	// of the code before it), so extensions stay where they were.
+	ln := 0
+	for _, k := range r.Codes.Keys() {
 		if r.low != nil {
 			for lKey := range r.low.Codes {
-				if lKey.Value == k {
+				if lKey.Value == k && lKey.KeyNode.Line > ln {
 					ln = lKey.KeyNode.Line
 				}
 			}
 		}
-		mapped = append(mapped, &responseItem{re, k, ln, nil})
+		mapped = append(mapped, &responseItem{r.Codes.GetOrZero(k), k, ln, nil})
 	}
 
 	// extract extensions
@@ -193,7 +197,7 @@ func (r *Responses) MarshalYAMLInline() (interface{}, error) {
 		}
 	}
 
-	sort.Slice(mapped, func(i, j int) bool {
+	sort.SliceStable(mapped, func(i, j int) bool {
 		return mapped[i].line < mapped[j].line
 	})
 	for j := range mapped {
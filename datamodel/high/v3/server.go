@@ -12,10 +12,10 @@ import (
 // Server represents a high-level OpenAPI 3+ Server object, that is backed by a low level one.
 //   - https://spec.openapis.org/oas/v3.1.0#server-object
 type Server struct {
-	URL         string                     `json:"url,omitempty" yaml:"url,omitempty"`
-	Description string                     `json:"description,omitempty" yaml:"description,omitempty"`
-	Variables   map[string]*ServerVariable `json:"variables,omitempty" yaml:"variables,omitempty"`
-	Extensions  map[string]any             `json:"-" yaml:"-"`
+	URL         string                                    `json:"url,omitempty" yaml:"url,omitempty"`
+	Description string                                    `json:"description,omitempty" yaml:"description,omitempty"`
+	Variables   *high.OrderedMap[string, *ServerVariable] `json:"variables,omitempty" yaml:"variables,omitempty"`
+	Extensions  map[string]any                            `json:"-" yaml:"-"`
 	low         *low.Server
 }
 
@@ -29,7 +29,7 @@ func NewServer(server *low.Server) *Server {
 	for k, val := range server.Variables.Value {
 		vars[k.Value] = NewServerVariable(val.Value)
 	}
-	s.Variables = vars
+	s.Variables = high.NewOrderedMapFromLow(server.Variables.Value, vars)
 	s.Extensions = high.ExtractExtensions(server.Extensions)
 	return s
 }
@@ -4,6 +4,7 @@
 package v3
 
 import (
+    "github.com/pb33f/libopenapi/datamodel/high"
     "github.com/stretchr/testify/assert"
     "strings"
     "testing"
@@ -23,11 +24,8 @@ description: the b33f`
     assert.Equal(t, desired, strings.TrimSpace(string(rend)))
 
     // mutate
-    server.Variables = map[string]*ServerVariable{
-        "rainbow": {
-            Enum: []string{"one", "two", "three"},
-        },
-    }
+    server.Variables = high.NewOrderedMap[string, *ServerVariable]()
+    server.Variables.Set("rainbow", &ServerVariable{Enum: []string{"one", "two", "three"}})
 
     desired = `url: https://pb33f.io
 description: the b33f
@@ -45,6 +45,15 @@ const (
 	SchemaLabel                = "schema"
 	SchemaTypeLabel            = "$schema"
 	AnchorLabel                = "$anchor"
+	IdLabel                    = "$id"
+	DefsLabel                  = "$defs"
+	DynamicRefLabel            = "$dynamicRef"
+	DynamicAnchorLabel         = "$dynamicAnchor"
+	VocabularyLabel            = "$vocabulary"
+	CommentLabel               = "$comment"
+	ConstLabel                 = "const"
+	ContentSchemaLabel         = "contentSchema"
+	DependentRequiredLabel     = "dependentRequired"
 )
 
 /*
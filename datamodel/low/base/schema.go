@@ -52,10 +52,12 @@ type Schema struct {
     SchemaTypeRef low.NodeReference[string]
 
     // In versions 2 and 3.0, this ExclusiveMaximum can only be a boolean.
-    ExclusiveMaximum low.NodeReference[*SchemaDynamicValue[bool, int64]]
+    // In version 3.1, ExclusiveMaximum is a number (which may be a decimal).
+    ExclusiveMaximum low.NodeReference[*SchemaDynamicValue[bool, float64]]
 
     // In versions 2 and 3.0, this ExclusiveMinimum can only be a boolean.
-    ExclusiveMinimum low.NodeReference[*SchemaDynamicValue[bool, int64]]
+    // In version 3.1, ExclusiveMinimum is a number (which may be a decimal).
+    ExclusiveMinimum low.NodeReference[*SchemaDynamicValue[bool, float64]]
 
     // In versions 2 and 3.0, this Type is a single value, so array will only ever have one value
     // in version 3.1, Type can be multiple values
@@ -91,12 +93,21 @@ type Schema struct {
     UnevaluatedItems      low.NodeReference[*SchemaProxy]
     UnevaluatedProperties low.NodeReference[*SchemaProxy]
     Anchor                low.NodeReference[string]
+    Id                    low.NodeReference[string]
+    Defs                  low.NodeReference[map[low.KeyReference[string]]low.ValueReference[*SchemaProxy]]
+    DynamicRef            low.NodeReference[string]
+    DynamicAnchor         low.NodeReference[string]
+    Vocabulary            low.NodeReference[map[low.KeyReference[string]]low.ValueReference[bool]]
+    Comment               low.NodeReference[string]
+    Const                 low.NodeReference[any]
+    ContentSchema         low.NodeReference[*SchemaProxy]
+    DependentRequired     low.NodeReference[map[low.KeyReference[string]]low.ValueReference[[]string]]
 
     // Compatible with all versions
     Title                low.NodeReference[string]
-    MultipleOf           low.NodeReference[int64]
-    Maximum              low.NodeReference[int64]
-    Minimum              low.NodeReference[int64]
+    MultipleOf           low.NodeReference[float64]
+    Maximum              low.NodeReference[float64]
+    Minimum              low.NodeReference[float64]
     MaxLength            low.NodeReference[int64]
     MinLength            low.NodeReference[int64]
     Pattern              low.NodeReference[string]
@@ -398,6 +409,49 @@ func (s *Schema) Hash() [32]byte {
     if !s.Anchor.IsEmpty() {
         d = append(d, fmt.Sprint(s.Anchor.Value))
     }
+    if !s.Id.IsEmpty() {
+        d = append(d, fmt.Sprint(s.Id.Value))
+    }
+    if !s.DynamicRef.IsEmpty() {
+        d = append(d, fmt.Sprint(s.DynamicRef.Value))
+    }
+    if !s.DynamicAnchor.IsEmpty() {
+        d = append(d, fmt.Sprint(s.DynamicAnchor.Value))
+    }
+    if !s.Comment.IsEmpty() {
+        d = append(d, fmt.Sprint(s.Comment.Value))
+    }
+    if !s.Const.IsEmpty() {
+        d = append(d, low.GenerateHashString(s.Const.Value))
+    }
+    if !s.ContentSchema.IsEmpty() {
+        d = append(d, low.GenerateHashString(s.ContentSchema.Value))
+    }
+
+    defsKeys := make([]string, len(s.Defs.Value))
+    z = 0
+    for i := range s.Defs.Value {
+        defsKeys[z] = i.Value
+        z++
+    }
+    sort.Strings(defsKeys)
+    for k := range defsKeys {
+        d = append(d, low.GenerateHashString(s.FindDef(defsKeys[k]).Value))
+    }
+
+    keys = make([]string, 0, len(s.Vocabulary.Value))
+    for k, v := range s.Vocabulary.Value {
+        keys = append(keys, fmt.Sprintf("%s:%v", k.Value, v.Value))
+    }
+    sort.Strings(keys)
+    d = append(d, keys...)
+
+    keys = make([]string, 0, len(s.DependentRequired.Value))
+    for k, v := range s.DependentRequired.Value {
+        keys = append(keys, fmt.Sprintf("%s:%s", k.Value, strings.Join(v.Value, ",")))
+    }
+    sort.Strings(keys)
+    d = append(d, keys...)
 
     depSchemasKeys := make([]string, len(s.DependentSchemas.Value))
     z = 0
@@ -490,6 +544,18 @@ func (s *Schema) FindPatternProperty(name string) *low.ValueReference[*SchemaPro
     return low.FindItemInMap[*SchemaProxy](name, s.PatternProperties.Value)
 }
 
+// FindDef will return a ValueReference pointer containing a SchemaProxy pointer
+// from a $defs key name. if found (3.1+ only)
+func (s *Schema) FindDef(name string) *low.ValueReference[*SchemaProxy] {
+    return low.FindItemInMap[*SchemaProxy](name, s.Defs.Value)
+}
+
+// FindDependentRequired will return a ValueReference pointer containing the required property names
+// from a dependent required key name. if found (3.1+ only)
+func (s *Schema) FindDependentRequired(name string) *low.ValueReference[[]string] {
+    return low.FindItemInMap[[]string](name, s.DependentRequired.Value)
+}
+
 // GetExtensions returns all extensions for Schema
 func (s *Schema) GetExtensions() map[low.KeyReference[string]]low.ValueReference[any] {
     return s.Extensions
@@ -519,6 +585,11 @@ func (s *Schema) GetExtensions() map[low.KeyReference[string]]low.ValueReference
 //   - UnevaluatedItems
 //   - UnevaluatedProperties
 //   - Anchor
+//   - Id, DynamicRef, DynamicAnchor and Comment
+//   - Defs
+//   - Vocabulary
+//   - ContentSchema
+//   - DependentRequired
 func (s *Schema) Build(root *yaml.Node, idx *index.SpecIndex) error {
     s.Reference = new(low.Reference)
     if h, _, _ := utils.IsNodeRefValue(root); h {
@@ -570,44 +641,44 @@ func (s *Schema) Build(root *yaml.Node, idx *index.SpecIndex) error {
         }
     }
 
-    // determine exclusive minimum type, bool (3.0) or int (3.1)
+    // determine exclusive minimum type, bool (3.0) or number (3.1)
     _, exMinLabel, exMinValue := utils.FindKeyNodeFullTop(ExclusiveMinimumLabel, root.Content)
     if exMinValue != nil {
         if utils.IsNodeBoolValue(exMinValue) {
             val, _ := strconv.ParseBool(exMinValue.Value)
-            s.ExclusiveMinimum = low.NodeReference[*SchemaDynamicValue[bool, int64]]{
+            s.ExclusiveMinimum = low.NodeReference[*SchemaDynamicValue[bool, float64]]{
                 KeyNode:   exMinLabel,
                 ValueNode: exMinValue,
-                Value:     &SchemaDynamicValue[bool, int64]{N: 0, A: val},
+                Value:     &SchemaDynamicValue[bool, float64]{N: 0, A: val},
             }
         }
-        if utils.IsNodeIntValue(exMinValue) {
-            val, _ := strconv.ParseInt(exMinValue.Value, 10, 64)
-            s.ExclusiveMinimum = low.NodeReference[*SchemaDynamicValue[bool, int64]]{
+        if utils.IsNodeIntValue(exMinValue) || utils.IsNodeFloatValue(exMinValue) {
+            val, _ := strconv.ParseFloat(exMinValue.Value, 64)
+            s.ExclusiveMinimum = low.NodeReference[*SchemaDynamicValue[bool, float64]]{
                 KeyNode:   exMinLabel,
                 ValueNode: exMinValue,
-                Value:     &SchemaDynamicValue[bool, int64]{N: 1, B: val},
+                Value:     &SchemaDynamicValue[bool, float64]{N: 1, B: val},
             }
         }
     }
 
-    // determine exclusive maximum type, bool (3.0) or int (3.1)
+    // determine exclusive maximum type, bool (3.0) or number (3.1)
     _, exMaxLabel, exMaxValue := utils.FindKeyNodeFullTop(ExclusiveMaximumLabel, root.Content)
     if exMaxValue != nil {
         if utils.IsNodeBoolValue(exMaxValue) {
             val, _ := strconv.ParseBool(exMaxValue.Value)
-            s.ExclusiveMaximum = low.NodeReference[*SchemaDynamicValue[bool, int64]]{
+            s.ExclusiveMaximum = low.NodeReference[*SchemaDynamicValue[bool, float64]]{
                 KeyNode:   exMaxLabel,
                 ValueNode: exMaxValue,
-                Value:     &SchemaDynamicValue[bool, int64]{N: 0, A: val},
+                Value:     &SchemaDynamicValue[bool, float64]{N: 0, A: val},
             }
         }
-        if utils.IsNodeIntValue(exMaxValue) {
-            val, _ := strconv.ParseInt(exMaxValue.Value, 10, 64)
-            s.ExclusiveMaximum = low.NodeReference[*SchemaDynamicValue[bool, int64]]{
+        if utils.IsNodeIntValue(exMaxValue) || utils.IsNodeFloatValue(exMaxValue) {
+            val, _ := strconv.ParseFloat(exMaxValue.Value, 64)
+            s.ExclusiveMaximum = low.NodeReference[*SchemaDynamicValue[bool, float64]]{
                 KeyNode:   exMaxLabel,
                 ValueNode: exMaxValue,
-                Value:     &SchemaDynamicValue[bool, int64]{N: 1, B: val},
+                Value:     &SchemaDynamicValue[bool, float64]{N: 1, B: val},
             }
         }
     }
@@ -628,6 +699,44 @@ func (s *Schema) Build(root *yaml.Node, idx *index.SpecIndex) error {
         }
     }
 
+    // handle remaining '$' keywords if set. (3.1) BuildModel matches field names without the '$' prefix,
+    // so these are always reset from the real keyword, or cleared if it does not exist.
+    s.Id = extractStringKeyword(IdLabel, root)
+    s.DynamicRef = extractStringKeyword(DynamicRefLabel, root)
+    s.DynamicAnchor = extractStringKeyword(DynamicAnchorLabel, root)
+    s.Comment = extractStringKeyword(CommentLabel, root)
+
+    // handle vocabulary if set. (3.1)
+    _, vocabLabel, vocabNode := utils.FindKeyNodeFullTop(VocabularyLabel, root.Content)
+    if vocabNode != nil && utils.IsNodeMap(vocabNode) {
+        vocab := make(map[low.KeyReference[string]]low.ValueReference[bool])
+        for i := 0; i < len(vocabNode.Content)-1; i += 2 {
+            b, _ := strconv.ParseBool(vocabNode.Content[i+1].Value)
+            vocab[low.KeyReference[string]{Value: vocabNode.Content[i].Value, KeyNode: vocabNode.Content[i]}] =
+                low.ValueReference[bool]{Value: b, ValueNode: vocabNode.Content[i+1]}
+        }
+        s.Vocabulary = low.NodeReference[map[low.KeyReference[string]]low.ValueReference[bool]]{
+            Value: vocab, KeyNode: vocabLabel, ValueNode: vocabNode,
+        }
+    }
+
+    // handle dependent required if set. (3.1)
+    _, depReqLabel, depReqNode := utils.FindKeyNodeFullTop(DependentRequiredLabel, root.Content)
+    if depReqNode != nil && utils.IsNodeMap(depReqNode) {
+        depReq := make(map[low.KeyReference[string]]low.ValueReference[[]string])
+        for i := 0; i < len(depReqNode.Content)-1; i += 2 {
+            var required []string
+            for _, r := range depReqNode.Content[i+1].Content {
+                required = append(required, r.Value)
+            }
+            depReq[low.KeyReference[string]{Value: depReqNode.Content[i].Value, KeyNode: depReqNode.Content[i]}] =
+                low.ValueReference[[]string]{Value: required, ValueNode: depReqNode.Content[i+1]}
+        }
+        s.DependentRequired = low.NodeReference[map[low.KeyReference[string]]low.ValueReference[[]string]]{
+            Value: depReq, KeyNode: depReqLabel, ValueNode: depReqNode,
+        }
+    }
+
     // handle example if set. (3.0)
     _, expLabel, expNode := utils.FindKeyNodeFull(ExampleLabel, root.Content)
     if expNode != nil {
@@ -780,6 +889,15 @@ func (s *Schema) Build(root *yaml.Node, idx *index.SpecIndex) error {
         s.PatternProperties = *props
     }
 
+    // handle $defs
+    props, err = buildPropertyMap(root, idx, DefsLabel)
+    if err != nil {
+        return err
+    }
+    if props != nil {
+        s.Defs = *props
+    }
+
     // check items type for schema or bool (3.1 only)
     itemsIsBool := false
     itemsBoolValue := false
@@ -802,7 +920,7 @@ func (s *Schema) Build(root *yaml.Node, idx *index.SpecIndex) error {
     }
 
     var allOf, anyOf, oneOf, prefixItems []low.ValueReference[*SchemaProxy]
-    var items, not, contains, sif, selse, sthen, propertyNames, unevalItems, unevalProperties low.ValueReference[*SchemaProxy]
+    var items, not, contains, sif, selse, sthen, propertyNames, unevalItems, unevalProperties, contentSchema low.ValueReference[*SchemaProxy]
 
     _, allOfLabel, allOfValue := utils.FindKeyNodeFullTop(AllOfLabel, root.Content)
     _, anyOfLabel, anyOfValue := utils.FindKeyNodeFullTop(AnyOfLabel, root.Content)
@@ -817,6 +935,7 @@ func (s *Schema) Build(root *yaml.Node, idx *index.SpecIndex) error {
 
     _, unevalItemsLabel, unevalItemsValue := utils.FindKeyNodeFullTop(UnevaluatedItemsLabel, root.Content)
     _, unevalPropsLabel, unevalPropsValue := utils.FindKeyNodeFullTop(UnevaluatedPropertiesLabel, root.Content)
+    _, contentSchemaLabel, contentSchemaValue := utils.FindKeyNodeFullTop(ContentSchemaLabel, root.Content)
 
     errorChan := make(chan error)
     allOfChan := make(chan schemaProxyBuildResult)
@@ -832,6 +951,7 @@ func (s *Schema) Build(root *yaml.Node, idx *index.SpecIndex) error {
     propNamesChan := make(chan schemaProxyBuildResult)
     unevalItemsChan := make(chan schemaProxyBuildResult)
     unevalPropsChan := make(chan schemaProxyBuildResult)
+    contentSchemaChan := make(chan schemaProxyBuildResult)
 
     totalBuilds := countSubSchemaItems(allOfValue) +
         countSubSchemaItems(anyOfValue) +
@@ -886,6 +1006,10 @@ func (s *Schema) Build(root *yaml.Node, idx *index.SpecIndex) error {
         totalBuilds++
         go buildSchema(unevalPropsChan, unevalPropsLabel, unevalPropsValue, errorChan, idx)
     }
+    if contentSchemaValue != nil {
+        totalBuilds++
+        go buildSchema(contentSchemaChan, contentSchemaLabel, contentSchemaValue, errorChan, idx)
+    }
 
     completeCount := 0
     for completeCount < totalBuilds {
@@ -931,6 +1055,9 @@ func (s *Schema) Build(root *yaml.Node, idx *index.SpecIndex) error {
         case r := <-unevalPropsChan:
             completeCount++
             unevalProperties = r.v
+        case r := <-contentSchemaChan:
+            completeCount++
+            contentSchema = r.v
         }
     }
 
@@ -1027,9 +1154,26 @@ func (s *Schema) Build(root *yaml.Node, idx *index.SpecIndex) error {
             ValueNode: unevalPropsValue,
         }
     }
+    if !contentSchema.IsEmpty() {
+        s.ContentSchema = low.NodeReference[*SchemaProxy]{
+            Value:     contentSchema.Value,
+            KeyNode:   contentSchemaLabel,
+            ValueNode: contentSchemaValue,
+        }
+    }
     return nil
 }
 
+// extractStringKeyword will look up a top level keyword in the root node and return a reference to its
+// string value. An empty reference is returned if the keyword does not exist.
+func extractStringKeyword(label string, root *yaml.Node) low.NodeReference[string] {
+    _, keyNode, valueNode := utils.FindKeyNodeFullTop(label, root.Content)
+    if valueNode == nil {
+        return low.NodeReference[string]{}
+    }
+    return low.NodeReference[string]{Value: valueNode.Value, KeyNode: keyNode, ValueNode: valueNode}
+}
+
 func buildPropertyMap(root *yaml.Node, idx *index.SpecIndex, label string) (*low.NodeReference[map[low.KeyReference[string]]low.ValueReference[*SchemaProxy]], error) {
     // for property, build in a new thread!
     bChan := make(chan schemaProxyBuildResult)
@@ -1156,7 +1300,10 @@ func buildSchema(schemas chan schemaProxyBuildResult, labelNode, valueNode *yaml
 
         isRef := false
         refLocation := ""
-        if utils.IsNodeMap(valueNode) {
+
+        // anything that is not an array is a single schema, this includes boolean schemas (3.1+), which
+        // must still produce a result, or the build will wait forever.
+        if !utils.IsNodeArray(valueNode) {
             h := false
             if h, _, refLocation = utils.IsNodeRefValue(valueNode); h {
                 isRef = true
@@ -424,8 +424,8 @@ examples:
     assert.True(t, sch.ExclusiveMinimum.Value.IsB())
     assert.False(t, sch.ExclusiveMinimum.Value.IsA())
     assert.True(t, sch.ExclusiveMaximum.Value.IsB())
-    assert.Equal(t, int64(12), sch.ExclusiveMinimum.Value.B)
-    assert.Equal(t, int64(13), sch.ExclusiveMaximum.Value.B)
+    assert.Equal(t, float64(12), sch.ExclusiveMinimum.Value.B)
+    assert.Equal(t, float64(13), sch.ExclusiveMaximum.Value.B)
     assert.Len(t, sch.Examples.Value, 1)
     assert.Equal(t, "testing", sch.Examples.Value[0].Value)
     assert.Equal(t, "fish64", sch.ContentEncoding.Value)
@@ -434,6 +434,132 @@ examples:
     assert.True(t, sch.Items.Value.B)
 }
 
+func Test_Schema_Decimals(t *testing.T) {
+    testSpec := `type: number
+multipleOf: 0.01
+minimum: -0.5
+maximum: 10
+exclusiveMinimum: 0.25
+exclusiveMaximum: 9.75`
+
+    var rootNode yaml.Node
+    mErr := yaml.Unmarshal([]byte(testSpec), &rootNode)
+    assert.NoError(t, mErr)
+
+    sch := Schema{}
+    mbErr := low.BuildModel(rootNode.Content[0], &sch)
+    assert.NoError(t, mbErr)
+
+    schErr := sch.Build(rootNode.Content[0], nil)
+    assert.NoError(t, schErr)
+    assert.Equal(t, 0.01, sch.MultipleOf.Value)
+    assert.Equal(t, -0.5, sch.Minimum.Value)
+    assert.Equal(t, float64(10), sch.Maximum.Value)
+    assert.Equal(t, "10", sch.Maximum.ValueNode.Value)
+    assert.True(t, sch.ExclusiveMinimum.Value.IsB())
+    assert.Equal(t, 0.25, sch.ExclusiveMinimum.Value.B)
+    assert.Equal(t, 9.75, sch.ExclusiveMaximum.Value.B)
+}
+
+func Test_Schema_31_Keywords(t *testing.T) {
+    testSpec := `$id: https://pb33f.io/schemas/burger
+$dynamicAnchor: meta
+$dynamicRef: '#meta'
+$comment: burgers are tasty
+$vocabulary:
+  https://json-schema.org/draft/2020-12/vocab/core: true
+  https://json-schema.org/draft/2020-12/vocab/format-annotation: false
+$defs:
+  bun:
+    type: string
+  patty:
+    type: integer
+const: burger
+contentEncoding: base64
+contentMediaType: application/json
+contentSchema:
+  type: object
+dependentRequired:
+  cheese:
+    - bun
+    - patty
+id: not a keyword
+comment: also not a keyword`
+
+    var rootNode yaml.Node
+    mErr := yaml.Unmarshal([]byte(testSpec), &rootNode)
+    assert.NoError(t, mErr)
+
+    sch := Schema{}
+    mbErr := low.BuildModel(rootNode.Content[0], &sch)
+    assert.NoError(t, mbErr)
+
+    schErr := sch.Build(rootNode.Content[0], nil)
+    assert.NoError(t, schErr)
+    assert.Equal(t, "https://pb33f.io/schemas/burger", sch.Id.Value)
+    assert.Equal(t, 1, sch.Id.ValueNode.Line)
+    assert.Equal(t, "meta", sch.DynamicAnchor.Value)
+    assert.Equal(t, "#meta", sch.DynamicRef.Value)
+    assert.Equal(t, "burgers are tasty", sch.Comment.Value)
+    assert.Len(t, sch.Vocabulary.Value, 2)
+    assert.True(t, low.FindItemInMap[bool]("https://json-schema.org/draft/2020-12/vocab/core", sch.Vocabulary.Value).Value)
+    assert.False(t, low.FindItemInMap[bool]("https://json-schema.org/draft/2020-12/vocab/format-annotation", sch.Vocabulary.Value).Value)
+    assert.Len(t, sch.Defs.Value, 2)
+    assert.Equal(t, "string", sch.FindDef("bun").Value.Schema().Type.Value.A)
+    assert.Equal(t, "integer", sch.FindDef("patty").Value.Schema().Type.Value.A)
+    assert.Nil(t, sch.FindDef("lettuce"))
+    assert.Equal(t, "burger", sch.Const.Value)
+    assert.Equal(t, "base64", sch.ContentEncoding.Value)
+    assert.Equal(t, "application/json", sch.ContentMediaType.Value)
+    assert.Equal(t, "object", sch.ContentSchema.Value.Schema().Type.Value.A)
+    assert.Equal(t, []string{"bun", "patty"}, sch.FindDependentRequired("cheese").Value)
+    assert.Nil(t, sch.FindDependentRequired("pickles"))
+}
+
+func TestSchema_Hash_31_Keywords(t *testing.T) {
+    left := `$id: https://pb33f.io/schemas/burger
+$comment: burgers are tasty
+$defs:
+  bun:
+    type: string
+const:
+  - bun
+contentSchema:
+  type: object
+dependentRequired:
+  cheese:
+    - bun
+$vocabulary:
+  https://json-schema.org/draft/2020-12/vocab/core: true`
+
+    right := `$id: https://pb33f.io/schemas/burger
+$comment: burgers are tasty
+$defs:
+  bun:
+    type: string
+const:
+  - bun
+contentSchema:
+  type: object
+dependentRequired:
+  cheese:
+    - patty
+$vocabulary:
+  https://json-schema.org/draft/2020-12/vocab/core: true`
+
+    build := func(spec string) *Schema {
+        var node yaml.Node
+        _ = yaml.Unmarshal([]byte(spec), &node)
+        sch := Schema{}
+        _ = low.BuildModel(node.Content[0], &sch)
+        _ = sch.Build(node.Content[0], nil)
+        return &sch
+    }
+
+    assert.Equal(t, build(left).Hash(), build(left).Hash())
+    assert.NotEqual(t, build(left).Hash(), build(right).Hash())
+}
+
 func TestSchema_Build_PropsLookup(t *testing.T) {
     yml := `components:
   schemas:
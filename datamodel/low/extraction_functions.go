@@ -51,6 +51,12 @@ func generateIndexCollection(idx *index.SpecIndex) []func() map[string]*index.Re
 func LocateRefNode(root *yaml.Node, idx *index.SpecIndex) (*yaml.Node, error) {
 	if rf, _, rv := utils.IsNodeRefValue(root); rf {
 
+		// without an index, there is nowhere to look.
+		if idx == nil {
+			return nil, fmt.Errorf("reference '%s' at line %d, column %d cannot be resolved without an index",
+				rv, root.Line, root.Column)
+		}
+
 		// run through everything and return as soon as we find a match.
 		// this operates as fast as possible as ever
 		collections := generateIndexCollection(idx)
@@ -66,27 +72,36 @@ func LocateRefNode(root *yaml.Node, idx *index.SpecIndex) (*yaml.Node, error) {
 			}
 		}
 
-		var found map[string]*index.Reference
+		// a reference made inside an '$id' scope (3.1+) is mapped by the URI it resolves to.
+		var located *index.Reference
+		if key := idx.GetReferenceKey(root, rv); key != rv {
+			located = idx.GetMappedReferences()[key]
+		}
 		for _, collection := range collections {
-			found = collection()
-			if found != nil && found[rv] != nil {
-
-				// if this is a ref node, we need to keep diving
-				// until we hit something that isn't a ref.
-				if jh, _, _ := utils.IsNodeRefValue(found[rv].Node); jh {
-					// if this node is circular, stop drop and roll.
-					if !IsCircular(found[rv].Node, idx) {
-						return LocateRefNode(found[rv].Node, idx)
-					} else {
-						return found[rv].Node, fmt.Errorf("circular reference '%s' found during lookup at line "+
-							"%d, column %d, It cannot be resolved",
-							GetCircularReferenceResult(found[rv].Node, idx).GenerateJourneyPath(),
-							found[rv].Node.Line,
-							found[rv].Node.Column)
-					}
+			if located != nil {
+				break
+			}
+			if found := collection(); found != nil {
+				located = found[rv]
+			}
+		}
+		if located != nil {
+
+			// if this is a ref node, we need to keep diving
+			// until we hit something that isn't a ref.
+			if jh, _, _ := utils.IsNodeRefValue(located.Node); jh {
+				// if this node is circular, stop drop and roll.
+				if !IsCircular(located.Node, idx) {
+					return LocateRefNode(located.Node, idx)
+				} else {
+					return located.Node, fmt.Errorf("circular reference '%s' found during lookup at line "+
+						"%d, column %d, It cannot be resolved",
+						GetCircularReferenceResult(located.Node, idx).GenerateJourneyPath(),
+						located.Node.Line,
+						located.Node.Column)
 				}
-				return found[rv].Node, nil
 			}
+			return located.Node, nil
 		}
 
 		// perform a search for the reference in the index
@@ -68,6 +68,59 @@ func TestLocateRefNode(t *testing.T) {
 
 }
 
+func TestLocateRefNode_SchemaIdScopes(t *testing.T) {
+
+	yml := `openapi: 3.1.0
+components:
+  schemas:
+    Pet:
+      $id: https://example.com/schemas/pet
+      properties:
+        name:
+          $anchor: name
+          type: string
+        nickname:
+          $ref: '#name'
+    Car:
+      $id: https://example.com/schemas/car
+      properties:
+        name:
+          $anchor: name
+          type: integer
+        model:
+          $ref: '#name'`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndexWithConfig(&idxNode, index.CreateClosedAPIIndexConfig())
+
+	// each '#name' is resolved in the scope of the schema that makes it.
+	schemas := idxNode.Content[0].Content[3].Content[1]
+	nickname := schemas.Content[1].Content[3].Content[3]
+	model := schemas.Content[3].Content[3].Content[3]
+
+	located, err := LocateRefNode(nickname, idx)
+	assert.NoError(t, err)
+	assert.Equal(t, 8, located.Line)
+
+	located, err = LocateRefNode(model, idx)
+	assert.NoError(t, err)
+	assert.Equal(t, 16, located.Line)
+}
+
+func TestLocateRefNode_NoIndex(t *testing.T) {
+
+	yml := `$ref: '#/components/schemas/cake'`
+
+	var cNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &cNode)
+
+	located, err := LocateRefNode(cNode.Content[0], nil)
+	assert.Nil(t, located)
+	assert.Equal(t, "reference '#/components/schemas/cake' at line 1, column 1 cannot be resolved without an index",
+		err.Error())
+}
+
 func TestLocateRefNode_BadNode(t *testing.T) {
 
 	yml := `components:
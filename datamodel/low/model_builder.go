@@ -227,7 +227,7 @@ func SetField(field *reflect.Value, valueNode *yaml.Node, keyNode *yaml.Node) er
 
     case reflect.TypeOf(NodeReference[float64]{}):
 
-        if utils.IsNodeFloatValue(valueNode) {
+        if utils.IsNodeFloatValue(valueNode) || utils.IsNodeIntValue(valueNode) {
             if field.CanSet() {
                 fv, _ := strconv.ParseFloat(valueNode.Value, 64)
                 nr := NodeReference[float64]{
@@ -329,7 +329,7 @@ func IsCircular(node *yaml.Node, idx *index.SpecIndex) bool {
 	// check mapped references in case we didn't find it.
 	_, nv := utils.FindKeyNode("$ref", node.Content)
 	if nv != nil {
-		ref := idx.GetMappedReferences()[nv.Value]
+		ref := idx.GetMappedReferences()[idx.GetReferenceKey(node, nv.Value)]
 		if ref != nil {
 			return ref.Circular
 		}
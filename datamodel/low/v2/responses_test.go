@@ -6,6 +6,7 @@ package v2
 import (
 	"github.com/pb33f/libopenapi/datamodel/low"
 	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v3"
 	"testing"
@@ -48,6 +49,28 @@ func TestResponses_Build_Response_Default(t *testing.T) {
 
 }
 
+func TestResponses_Build_Response_DefaultReference(t *testing.T) {
+
+	yml := `responses:
+  error:
+    description: an error
+default:
+  $ref: '#/responses/error'`
+
+	var idxNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(yml), &idxNode)
+	assert.NoError(t, mErr)
+	idx := index.NewSpecIndex(&idxNode)
+
+	var n Responses
+	_, ln, vn := utils.FindKeyNodeFull("default", idxNode.Content[0].Content)
+	root := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Content: []*yaml.Node{ln, vn}}
+	err := n.Build(root, idx)
+	assert.NoError(t, err)
+	assert.Equal(t, "#/responses/error", n.Default.Reference)
+	assert.Equal(t, "an error", n.Default.Value.Description.Value)
+}
+
 func TestResponses_Build_WrongType(t *testing.T) {
 
 	yml := `- $ref: break`
@@ -18,6 +18,7 @@ import (
     "github.com/pb33f/libopenapi/index"
     "github.com/pb33f/libopenapi/resolver"
     "gopkg.in/yaml.v3"
+    "path/filepath"
 )
 
 // processes a property of a Swagger document asynchronously using bool and error channels for signals.
@@ -141,11 +142,27 @@ func createDocument(info *datamodel.SpecInfo, config *datamodel.DocumentConfigur
     doc := Swagger{Swagger: low.ValueReference[string]{Value: info.Version, ValueNode: info.RootNode}}
     doc.Extensions = low.ExtractExtensions(info.RootNode.Content[0])
 
+    // if a file system has been provided, then paths are relative to the root of that file system.
+    var basePath string
+    fileFetcher := config.FileFetcher
+    if config.FileSystem != nil {
+        basePath = filepath.Dir(config.RootFileName)
+        if fileFetcher == nil {
+            fileFetcher = index.NewFSFetcher(config.FileSystem)
+        }
+    }
+    if config.BasePath != "" {
+        basePath = config.BasePath
+    }
+
     // build an index
     idx := index.NewSpecIndexWithConfig(info.RootNode, &index.SpecIndexConfig{
         BaseURL:           config.BaseURL,
+        BasePath:          basePath,
         AllowRemoteLookup: config.AllowRemoteReferences,
         AllowFileLookup:   config.AllowFileReferences,
+        RemoteFetcher:     config.RemoteFetcher,
+        FileFetcher:       fileFetcher,
     })
     doc.Index = idx
     doc.SpecInfo = info
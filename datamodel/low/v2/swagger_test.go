@@ -9,6 +9,7 @@ import (
     "github.com/stretchr/testify/assert"
     "io/ioutil"
     "testing"
+    "testing/fstest"
 )
 
 var doc *Swagger
@@ -351,3 +352,27 @@ func TestCircularReferenceError(t *testing.T) {
     assert.Len(t, err, 3)
 
 }
+
+func TestCreateDocument_FileSystem(t *testing.T) {
+    yml := `swagger: 2.0
+definitions:
+  Pet:
+    $ref: 'definitions/pet.yaml'`
+
+    pet := `type: object
+description: a pet from a file system`
+
+    fileSystem := fstest.MapFS{
+        "api/definitions/pet.yaml": &fstest.MapFile{Data: []byte(pet)},
+    }
+
+    info, _ := datamodel.ExtractSpecInfo([]byte(yml))
+    d, err := CreateDocumentFromConfig(info, &datamodel.DocumentConfiguration{
+        AllowFileReferences: true,
+        FileSystem:          fileSystem,
+        RootFileName:        "api/swagger.yaml",
+    })
+    assert.Len(t, err, 0)
+    assert.Equal(t, "a pet from a file system",
+        d.Definitions.Value.FindSchema("Pet").Value.Schema().Description.Value)
+}
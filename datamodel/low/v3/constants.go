@@ -137,4 +137,13 @@ const (
 	DependentSchemasLabel      = "dependentSchemas"
 	PatternPropertiesLabel     = "patternProperties"
 	AnchorLabel                = "$anchor"
+	IdLabel                    = "$id"
+	DefsLabel                  = "$defs"
+	DynamicRefLabel            = "$dynamicRef"
+	DynamicAnchorLabel         = "$dynamicAnchor"
+	VocabularyLabel            = "$vocabulary"
+	CommentLabel               = "$comment"
+	ConstLabel                 = "const"
+	ContentSchemaLabel         = "contentSchema"
+	DependentRequiredLabel     = "dependentRequired"
 )
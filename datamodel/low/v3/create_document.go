@@ -3,6 +3,7 @@ package v3
 import (
 	"errors"
 	"os"
+	"path/filepath"
 	"sync"
 
 	"github.com/pb33f/libopenapi/datamodel"
@@ -42,6 +43,15 @@ func createDocument(info *datamodel.SpecInfo, config *datamodel.DocumentConfigur
 	// get current working directory as a basePath
 	cwd, _ := os.Getwd()
 
+	// if a file system has been provided, then paths are relative to the root of that file system.
+	fileFetcher := config.FileFetcher
+	if config.FileSystem != nil {
+		cwd = filepath.Dir(config.RootFileName)
+		if fileFetcher == nil {
+			fileFetcher = index.NewFSFetcher(config.FileSystem)
+		}
+	}
+
 	// If basePath is provided override it
 	if config.BasePath != "" {
 		cwd = config.BasePath
@@ -52,6 +62,8 @@ func createDocument(info *datamodel.SpecInfo, config *datamodel.DocumentConfigur
 		BasePath:          cwd,
 		AllowFileLookup:   config.AllowFileReferences,
 		AllowRemoteLookup: config.AllowRemoteReferences,
+		RemoteFetcher:     config.RemoteFetcher,
+		FileFetcher:       fileFetcher,
 	})
 	doc.Index = idx
 
@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"io/ioutil"
 	"testing"
+	"testing/fstest"
 
 	"github.com/pb33f/libopenapi/datamodel"
 	"github.com/pb33f/libopenapi/datamodel/low/base"
+	"github.com/pb33f/libopenapi/index"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -167,6 +169,79 @@ func TestCreateDocument_WebHooks_Error(t *testing.T) {
 	assert.Len(t, err, 1)
 }
 
+func TestCreateDocument_RemoteFetcher(t *testing.T) {
+	yml := `openapi: 3.1.0
+components:
+  schemas:
+    Burger:
+      $ref: 'https://pb33f.io/burgers.yaml#/components/schemas/Burger'`
+
+	remote := `components:
+  schemas:
+    Burger:
+      type: object
+      description: a remote burger`
+
+	info, _ := datamodel.ExtractSpecInfo([]byte(yml))
+	d, err := CreateDocumentFromConfig(info, &datamodel.DocumentConfiguration{
+		AllowRemoteReferences: true,
+		RemoteFetcher:         index.MapFetcher{"https://pb33f.io/burgers.yaml": []byte(remote)},
+	})
+	assert.Len(t, err, 0)
+	burger := d.Components.Value.FindSchema("Burger").Value.Schema()
+	assert.Equal(t, "a remote burger", burger.Description.Value)
+}
+
+func TestCreateDocument_FileSystem(t *testing.T) {
+	yml := `openapi: 3.1.0
+components:
+  schemas:
+    Burger:
+      $ref: 'schemas/burger.yaml'`
+
+	burger := `type: object
+description: a burger from a file system
+properties:
+  fries:
+    $ref: 'fries.yaml'`
+
+	fries := `type: string
+description: crispy`
+
+	fileSystem := fstest.MapFS{
+		"api/openapi.yaml":          &fstest.MapFile{Data: []byte(yml)},
+		"api/schemas/burger.yaml":   &fstest.MapFile{Data: []byte(burger)},
+		"api/schemas/fries.yaml":    &fstest.MapFile{Data: []byte(fries)},
+		"api/schemas/not-used.yaml": &fstest.MapFile{Data: []byte("type: string")},
+	}
+
+	info, _ := datamodel.ExtractSpecInfo([]byte(yml))
+	d, err := CreateDocumentFromConfig(info, &datamodel.DocumentConfiguration{
+		AllowFileReferences: true,
+		FileSystem:          fileSystem,
+		RootFileName:        "api/openapi.yaml",
+	})
+	assert.Len(t, err, 0)
+	b := d.Components.Value.FindSchema("Burger").Value.Schema()
+	assert.Equal(t, "a burger from a file system", b.Description.Value)
+	assert.Equal(t, "crispy", b.FindProperty("fries").Value.Schema().Description.Value)
+}
+
+func TestCreateDocument_FileSystem_NotFound(t *testing.T) {
+	yml := `openapi: 3.1.0
+components:
+  schemas:
+    Burger:
+      $ref: 'schemas/burger.yaml'`
+
+	info, _ := datamodel.ExtractSpecInfo([]byte(yml))
+	_, err := CreateDocumentFromConfig(info, &datamodel.DocumentConfiguration{
+		AllowFileReferences: true,
+		FileSystem:          fstest.MapFS{},
+	})
+	assert.NotEmpty(t, err)
+}
+
 func TestCreateDocument_Servers(t *testing.T) {
 	initTest()
 	assert.Len(t, doc.Servers.Value, 2)
@@ -0,0 +1,246 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package datamodel
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"gopkg.in/yaml.v3"
+)
+
+// SpecValidationError represents a single violation found when validating a specification against the
+// OpenAPI (or Swagger) schema that is embedded in the library.
+type SpecValidationError struct {
+	// Message describes what is wrong.
+	Message string `json:"message"`
+
+	// Path is a JSON pointer to the value in the specification that failed, for example '/paths/~1pets/get'.
+	// An empty Path means the root of the document.
+	Path string `json:"path"`
+
+	// SchemaPath is a JSON pointer to the keyword in the OpenAPI schema that failed.
+	SchemaPath string `json:"schemaPath"`
+
+	// Line and Column are the position of the failing value in the specification, taken from SpecInfo.RootNode.
+	Line   int `json:"line"`
+	Column int `json:"column"`
+
+	// Node is the *yaml.Node of the failing value, if it could be located.
+	Node *yaml.Node `json:"-"`
+}
+
+// Error returns a readable version of the violation, including the position in the specification.
+func (e *SpecValidationError) Error() string {
+	path := e.Path
+	if path == "" {
+		path = "/"
+	}
+	return fmt.Sprintf("%s: %s (line %d, column %d)", path, e.Message, e.Line, e.Column)
+}
+
+// compiled OpenAPI schemas are expensive to build, so they are only built once, keyed by the schema data.
+var (
+	specSchemaLock  sync.Mutex
+	specSchemaCache = make(map[string]*jsonschema.Schema)
+)
+
+// ValidateSpec will validate a specification against the OpenAPI 2, 3.0 or 3.1 schema held in SpecInfo.APISchema
+// (which is selected by ExtractSpecInfo). Every violation found is returned as a *SpecValidationError, with a JSON
+// pointer and the line and column of the value in SpecInfo.RootNode. An empty slice means the specification is valid.
+//
+// An error is returned if the specification cannot be validated at all, for example if there is no schema
+// available for the type of specification.
+func ValidateSpec(info *SpecInfo) ([]*SpecValidationError, error) {
+	if info == nil || info.RootNode == nil {
+		return nil, errors.New("unable to validate specification: there is no specification to validate")
+	}
+	if info.APISchema == "" {
+		return nil, fmt.Errorf("unable to validate specification: no schema is available for spec type '%s'",
+			info.SpecType)
+	}
+
+	schema, err := compileSpecSchema(info.APISchema)
+	if err != nil {
+		return nil, fmt.Errorf("unable to validate specification: %s", err.Error())
+	}
+
+	root := info.RootNode
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	var results []*SpecValidationError
+	vErr := schema.Validate(yamlNodeToJSON(root))
+	if vErr == nil {
+		return results, nil
+	}
+	var ve *jsonschema.ValidationError
+	if !errors.As(vErr, &ve) {
+		return nil, fmt.Errorf("unable to validate specification: %s", vErr.Error())
+	}
+
+	// only the leaves of the error tree are useful, everything else is a summary of the leaves.
+	seen := make(map[string]bool)
+	var collect func(e *jsonschema.ValidationError)
+	collect = func(e *jsonschema.ValidationError) {
+		if len(e.Causes) > 0 {
+			for _, c := range e.Causes {
+				collect(c)
+			}
+			return
+		}
+		key := fmt.Sprintf("%s:%s", e.InstanceLocation, e.Message)
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		sErr := &SpecValidationError{
+			Message:    e.Message,
+			Path:       e.InstanceLocation,
+			SchemaPath: e.KeywordLocation,
+		}
+		if n := locateNodeByPointer(root, e.InstanceLocation); n != nil {
+			sErr.Node = n
+			sErr.Line = n.Line
+			sErr.Column = n.Column
+		}
+		results = append(results, sErr)
+	}
+	collect(ve)
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Line != results[j].Line {
+			return results[i].Line < results[j].Line
+		}
+		return results[i].Column < results[j].Column
+	})
+	return results, nil
+}
+
+const jsonSchemaDraft4URL = "http://json-schema.org/draft-04/schema"
+
+// compileSpecSchema compiles (and caches) an OpenAPI schema. Schemas are never loaded remotely, anything referenced
+// by the OpenAPI schemas is embedded in the library.
+func compileSpecSchema(schemaData string) (*jsonschema.Schema, error) {
+	specSchemaLock.Lock()
+	defer specSchemaLock.Unlock()
+	if s, ok := specSchemaCache[schemaData]; ok {
+		return s, nil
+	}
+	const schemaURL = "https://pb33f.io/libopenapi/openapi-schema.json"
+	compiler := jsonschema.NewCompiler()
+	compiler.LoadURL = func(s string) (io.ReadCloser, error) {
+		if strings.TrimSuffix(s, "#") == jsonSchemaDraft4URL {
+			return io.NopCloser(strings.NewReader(JSONSchemaDraft4Data)), nil
+		}
+		return nil, fmt.Errorf("cannot load '%s', remote schemas are not supported", s)
+	}
+	if err := compiler.AddResource(schemaURL, strings.NewReader(schemaData)); err != nil {
+		return nil, err
+	}
+	s, err := compiler.Compile(schemaURL)
+	if err != nil {
+		return nil, err
+	}
+	specSchemaCache[schemaData] = s
+	return s, nil
+}
+
+// yamlNodeToJSON converts a *yaml.Node into a plain JSON value (maps, slices, strings, json.Number and bools).
+// YAML allows non-string keys (like response codes: 200), so every key is converted into a string.
+func yamlNodeToJSON(node *yaml.Node) any {
+	if node == nil {
+		return nil
+	}
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) > 0 {
+			return yamlNodeToJSON(node.Content[0])
+		}
+		return nil
+	case yaml.AliasNode:
+		return yamlNodeToJSON(node.Alias)
+	case yaml.MappingNode:
+		m := make(map[string]any)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			m[node.Content[i].Value] = yamlNodeToJSON(node.Content[i+1])
+		}
+		return m
+	case yaml.SequenceNode:
+		s := make([]any, len(node.Content))
+		for i := range node.Content {
+			s[i] = yamlNodeToJSON(node.Content[i])
+		}
+		return s
+	case yaml.ScalarNode:
+		switch node.Tag {
+		case "!!null":
+			return nil
+		case "!!bool":
+			b, err := strconv.ParseBool(node.Value)
+			if err != nil {
+				var v bool
+				if node.Decode(&v) == nil {
+					return v
+				}
+				return node.Value
+			}
+			return b
+		case "!!int", "!!float":
+			if _, err := strconv.ParseFloat(node.Value, 64); err == nil {
+				return json.Number(node.Value)
+			}
+			var f float64
+			if node.Decode(&f) == nil {
+				return json.Number(strconv.FormatFloat(f, 'f', -1, 64))
+			}
+			return node.Value
+		}
+		return node.Value
+	}
+	return nil
+}
+
+// locateNodeByPointer will walk a *yaml.Node tree using a JSON pointer, and return the node found, or the closest
+// parent that could be found.
+func locateNodeByPointer(root *yaml.Node, pointer string) *yaml.Node {
+	node := root
+	if pointer == "" || pointer == "/" {
+		return node
+	}
+	for _, seg := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		seg = utils.UnescapePointerSegment(seg)
+		for node.Kind == yaml.AliasNode && node.Alias != nil {
+			node = node.Alias
+		}
+		var next *yaml.Node
+		switch node.Kind {
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == seg {
+					next = node.Content[i+1]
+					break
+				}
+			}
+		case yaml.SequenceNode:
+			if idx, err := strconv.Atoi(seg); err == nil && idx >= 0 && idx < len(node.Content) {
+				next = node.Content[idx]
+			}
+		}
+		if next == nil {
+			return node
+		}
+		node = next
+	}
+	return node
+}
@@ -0,0 +1,115 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package datamodel
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSpec_ValidSpecs(t *testing.T) {
+	for _, f := range []string{"../test_specs/petstorev3.json", "../test_specs/petstorev2.json"} {
+		spec, _ := ioutil.ReadFile(f)
+		info, err := ExtractSpecInfo(spec)
+		assert.NoError(t, err)
+
+		errs, err := ValidateSpec(info)
+		assert.NoError(t, err, f)
+		assert.Empty(t, errs, f)
+	}
+}
+
+func TestValidateSpec_OpenAPI3(t *testing.T) {
+	spec := `openapi: 3.0.3
+info:
+  title: pizza
+paths:
+  /pizza:
+    get:
+      responses:
+        200:
+          description: nice`
+
+	info, _ := ExtractSpecInfo([]byte(spec))
+	errs, err := ValidateSpec(info)
+	assert.NoError(t, err)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "/info", errs[0].Path)
+	assert.Equal(t, "missing properties: 'version'", errs[0].Message)
+	assert.Equal(t, 3, errs[0].Line)
+	assert.Equal(t, 3, errs[0].Column)
+	assert.Equal(t, "/info: missing properties: 'version' (line 3, column 3)", errs[0].Error())
+}
+
+func TestValidateSpec_OpenAPI31(t *testing.T) {
+	spec := `openapi: 3.1.0
+info:
+  title: pizza
+  version: 1.0.0
+paths:
+  /pizza:
+    get:
+      operationId: 1234
+      responses:
+        '200':
+          description: nice`
+
+	info, _ := ExtractSpecInfo([]byte(spec))
+	errs, err := ValidateSpec(info)
+	assert.NoError(t, err)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "/paths/~1pizza/get/operationId", errs[0].Path)
+	assert.Equal(t, 8, errs[0].Line)
+	assert.Equal(t, 20, errs[0].Column)
+	assert.NotEmpty(t, errs[0].SchemaPath)
+	assert.Equal(t, "1234", errs[0].Node.Value)
+}
+
+func TestValidateSpec_Swagger(t *testing.T) {
+	spec := `swagger: "2.0"
+info:
+  title: pizza
+  version: 1.0.0
+paths:
+  /pizza:
+    get:
+      responses:
+        200:
+          description: nice
+      pizza: party`
+
+	info, _ := ExtractSpecInfo([]byte(spec))
+	errs, err := ValidateSpec(info)
+	assert.NoError(t, err)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "/paths/~1pizza/get", errs[0].Path)
+	assert.Equal(t, 8, errs[0].Line)
+}
+
+func TestValidateSpec_NoSchema(t *testing.T) {
+	_, err := ValidateSpec(nil)
+	assert.Error(t, err)
+
+	info, _ := ExtractSpecInfo([]byte(`asyncapi: 2.0.0`))
+	_, err = ValidateSpec(info)
+	assert.Error(t, err)
+}
+
+func TestLocateNodeByPointer(t *testing.T) {
+	spec := `openapi: 3.1.0
+tags:
+  - name: a/b~c
+    description: pizza`
+
+	info, _ := ExtractSpecInfo([]byte(spec))
+	root := info.RootNode.Content[0]
+	assert.Equal(t, "pizza", locateNodeByPointer(root, "/tags/0/description").Value)
+	assert.Equal(t, root, locateNodeByPointer(root, ""))
+
+	// missing nodes return the closest parent.
+	assert.Equal(t, 3, locateNodeByPointer(root, "/tags/0/nope").Line)
+	assert.Equal(t, 3, locateNodeByPointer(root, "/tags/9").Line)
+}
@@ -18,6 +18,7 @@ import (
 	"fmt"
 
 	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/patch"
 
 	"github.com/pb33f/libopenapi/datamodel"
 	v2high "github.com/pb33f/libopenapi/datamodel/high/v2"
@@ -44,6 +45,9 @@ type Document interface {
 	// allowing remote or local references, as well as a BaseURL to allow for relative file references.
 	SetConfiguration(configuration *datamodel.DocumentConfiguration)
 
+	// GetConfiguration will return the configuration set for the document, or nil if none has been set.
+	GetConfiguration() *datamodel.DocumentConfiguration
+
 	// BuildV2Model will build out a Swagger (version 2) model from the specification used to create the document
 	// If there are any issues, then no model will be returned, instead a slice of errors will explain all the
 	// problems that occurred. This method will only support version 2 specifications and will throw an error for
@@ -72,6 +76,29 @@ type Document interface {
 	// it's too old, so it should be motivation to upgrade to OpenAPI 3.
 	RenderAndReload() ([]byte, Document, *DocumentModel[v3high.Document], []error)
 
+	// ApplyJSONPatch applies a JSON Patch (RFC 6902) document to the specification (the RootNode of the SpecInfo).
+	// Nodes are changed in place, so comments and line numbers of anything the patch does not touch are kept. If
+	// an operation fails, nothing is changed, and a *patch.PatchError is returned with the JSON pointer that failed.
+	//
+	// Any model that has already been built is rebuilt from the patched specification, and any errors building it
+	// are returned. References to the old model should not be used after patching.
+	ApplyJSONPatch(patch []byte) []error
+
+	// ApplyMergePatch applies a JSON Merge Patch (RFC 7396) document to the specification, in the same way as
+	// ApplyJSONPatch.
+	ApplyMergePatch(patch []byte) []error
+
+	// Rebuild refreshes the specification information from the RootNode of the SpecInfo, after it has been changed
+	// in place (by an overlay for example), and rebuilds any model that has already been built from it. Any errors
+	// building the model are returned. References to the old model should not be used after rebuilding.
+	Rebuild() []error
+
+	// Validate will validate the specification against the OpenAPI (or Swagger) schema that matches the version of
+	// the document. Every violation is returned with a JSON pointer to the failing value, and the line and column it
+	// can be found on. An empty slice means the document is valid. An error is returned if the document could not be
+	// validated at all.
+	Validate() ([]*datamodel.SpecValidationError, error)
+
 	// Serialize will re-render a Document back into a []byte slice. If any modifications have been made to the
 	// underlying data model using low level APIs, then those changes will be reflected in the serialized output.
 	//
@@ -144,6 +171,14 @@ func (d *document) SetConfiguration(configuration *datamodel.DocumentConfigurati
 	d.config = configuration
 }
 
+func (d *document) GetConfiguration() *datamodel.DocumentConfiguration {
+	return d.config
+}
+
+func (d *document) Validate() ([]*datamodel.SpecValidationError, error) {
+	return datamodel.ValidateSpec(d.info)
+}
+
 func (d *document) Serialize() ([]byte, error) {
 	if d.info == nil {
 		return nil, fmt.Errorf("unable to serialize, document has not yet been initialized")
@@ -177,6 +212,59 @@ func (d *document) RenderAndReload() ([]byte, Document, *DocumentModel[v3high.Do
 	return newBytes, newDoc, model, nil
 }
 
+func (d *document) ApplyJSONPatch(p []byte) []error {
+	if d.info == nil || d.info.RootNode == nil {
+		return []error{errors.New("unable to patch, document has not yet been initialized")}
+	}
+	if err := patch.ApplyJSONPatch(d.info.RootNode, p); err != nil {
+		return []error{err}
+	}
+	return d.Rebuild()
+}
+
+func (d *document) ApplyMergePatch(p []byte) []error {
+	if d.info == nil || d.info.RootNode == nil {
+		return []error{errors.New("unable to patch, document has not yet been initialized")}
+	}
+	if err := patch.ApplyMergePatch(d.info.RootNode, p); err != nil {
+		return []error{err}
+	}
+	return d.Rebuild()
+}
+
+func (d *document) Rebuild() []error {
+	if d.info == nil || d.info.RootNode == nil {
+		return []error{errors.New("unable to rebuild, document has not yet been initialized")}
+	}
+	rendered, err := yaml.Marshal(d.info.RootNode)
+	if err != nil {
+		return []error{err}
+	}
+	info, err := datamodel.ExtractSpecInfo(rendered)
+	if info == nil {
+		return []error{err}
+	}
+	d.info.SpecType = info.SpecType
+	d.info.Version = info.Version
+	d.info.SpecFormat = info.SpecFormat
+	d.info.APISchema = info.APISchema
+	d.info.SpecJSON = info.SpecJSON
+	d.info.SpecJSONBytes = info.SpecJSONBytes
+	d.info.Error = info.Error
+	d.version = info.Version
+
+	var errs []error
+	if d.highOpenAPI3Model != nil {
+		d.highOpenAPI3Model = nil
+		_, errs = d.BuildV3Model()
+	}
+	if d.highSwaggerModel != nil {
+		d.highSwaggerModel = nil
+		_, errs = d.BuildV2Model()
+	}
+	return errs
+}
+
 func (d *document) BuildV2Model() (*DocumentModel[v2high.Swagger], []error) {
 	if d.highSwaggerModel != nil {
 		return d.highSwaggerModel, nil
@@ -269,7 +357,9 @@ func (d *document) BuildV3Model() (*DocumentModel[v3high.Document], []error) {
 // If there are any errors when building the models, those errors are returned with a nil pointer for the
 // model.DocumentChanges. If there are any changes found however between either Document, then a pointer to
 // model.DocumentChanges is returned containing every single change, broken down, model by model.
-func CompareDocuments(original, updated Document) (*model.DocumentChanges, []error) {
+//
+// Any model.BreakingRules supplied override which changes are breaking.
+func CompareDocuments(original, updated Document, rules ...model.BreakingRules) (*model.DocumentChanges, []error) {
 	var errors []error
 	if original.GetSpecInfo().SpecType == utils.OpenApi3 && updated.GetSpecInfo().SpecType == utils.OpenApi3 {
 		v3ModelLeft, errs := original.BuildV3Model()
@@ -281,7 +371,7 @@ func CompareDocuments(original, updated Document) (*model.DocumentChanges, []err
 			errors = append(errors, errs...)
 		}
 		if v3ModelLeft != nil && v3ModelRight != nil {
-			return what_changed.CompareOpenAPIDocuments(v3ModelLeft.Model.GoLow(), v3ModelRight.Model.GoLow()), errors
+			return what_changed.CompareOpenAPIDocuments(v3ModelLeft.Model.GoLow(), v3ModelRight.Model.GoLow(), rules...), errors
 		} else {
 			return nil, errors
 		}
@@ -296,7 +386,7 @@ func CompareDocuments(original, updated Document) (*model.DocumentChanges, []err
 			errors = append(errors, errs...)
 		}
 		if v2ModelLeft != nil && v2ModelRight != nil {
-			return what_changed.CompareSwaggerDocuments(v2ModelLeft.Model.GoLow(), v2ModelRight.Model.GoLow()), errors
+			return what_changed.CompareSwaggerDocuments(v2ModelLeft.Model.GoLow(), v2ModelRight.Model.GoLow(), rules...), errors
 		} else {
 			return nil, errors
 		}
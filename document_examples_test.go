@@ -48,8 +48,8 @@ func ExampleNewDocument_fromOpenAPI3Document() {
     }
 
     // get a count of the number of paths and schemas.
-    paths := len(v3Model.Model.Paths.PathItems)
-    schemas := len(v3Model.Model.Components.Schemas)
+    paths := v3Model.Model.Paths.PathItems.Len()
+    schemas := v3Model.Model.Components.Schemas.Len()
 
     // print the number of paths and schemas in the document
     fmt.Printf("There are %d paths and %d schemas in the document", paths, schemas)
@@ -154,8 +154,8 @@ func ExampleNewDocument_fromSwaggerDocument() {
     }
 
     // get a count of the number of paths and schemas.
-    paths := len(v2Model.Model.Paths.PathItems)
-    schemas := len(v2Model.Model.Definitions.Definitions)
+    paths := v2Model.Model.Paths.PathItems.Len()
+    schemas := v2Model.Model.Definitions.Definitions.Len()
 
     // print the number of paths and schemas in the document
     fmt.Printf("There are %d paths and %d schemas in the document", paths, schemas)
@@ -185,8 +185,8 @@ func ExampleNewDocument_fromUnknownVersion() {
             errors = errs
         }
         if len(errors) <= 0 {
-            paths = len(v3Model.Model.Paths.PathItems)
-            schemas = len(v3Model.Model.Components.Schemas)
+            paths = v3Model.Model.Paths.PathItems.Len()
+            schemas = v3Model.Model.Components.Schemas.Len()
         }
     }
     if document.GetSpecInfo().SpecType == utils.OpenApi2 {
@@ -195,8 +195,8 @@ func ExampleNewDocument_fromUnknownVersion() {
             errors = errs
         }
         if len(errors) <= 0 {
-            paths = len(v2Model.Model.Paths.PathItems)
-            schemas = len(v2Model.Model.Definitions.Definitions)
+            paths = v2Model.Model.Paths.PathItems.Len()
+            schemas = v2Model.Model.Definitions.Definitions.Len()
         }
     }
 
@@ -561,8 +561,8 @@ components:
     }
 
     // get a reference to SchemaOne and ParameterOne
-    schemaOne := docModel.Model.Components.Schemas["SchemaOne"].Schema()
-    parameterOne := docModel.Model.Components.Parameters["ParameterOne"]
+    schemaOne := docModel.Model.Components.Schemas.GetOrZero("SchemaOne").Schema()
+    parameterOne := docModel.Model.Components.Parameters.GetOrZero("ParameterOne")
 
     // unpack schemaOne extensions into complex `cakes` type
     schemaOneExtensions, schemaUnpackErrors := high.UnpackExtensions[cakes, *low.Schema](schemaOne)
@@ -642,10 +642,10 @@ func ExampleNewDocument_modifyAndReRender() {
     }
 
     // capture original number of paths
-    originalPaths := len(v3Model.Model.Paths.PathItems)
+    originalPaths := v3Model.Model.Paths.PathItems.Len()
 
     // add the path to the document
-    v3Model.Model.Paths.PathItems["/new/path"] = newPath
+    v3Model.Model.Paths.PathItems.Set("/new/path", newPath)
 
     // render out the new path item to YAML
     // renderedPathItem, _ := yaml.Marshal(newPath)
@@ -659,7 +659,7 @@ func ExampleNewDocument_modifyAndReRender() {
     }
 
     // capture new number of paths after re-rendering
-    newPaths := len(newModel.Model.Paths.PathItems)
+    newPaths := newModel.Model.Paths.PathItems.Len()
 
     // print the number of paths and schemas in the document
     fmt.Printf("There were %d original paths. There are now %d paths in the document\n", originalPaths, newPaths)
@@ -245,37 +245,37 @@ func TestDocument_RenderAndReload(t *testing.T) {
 
 	// mutate the model
 	h := m.Model
-	h.Paths.PathItems["/pet/findByStatus"].Get.OperationId = "findACakeInABakery"
-	h.Paths.PathItems["/pet/findByStatus"].Get.Responses.Codes["400"].Description = "a nice bucket of mice"
-	h.Paths.PathItems["/pet/findByTags"].Get.Tags =
-		append(h.Paths.PathItems["/pet/findByTags"].Get.Tags, "gurgle", "giggle")
+	h.Paths.PathItems.GetOrZero("/pet/findByStatus").Get.OperationId = "findACakeInABakery"
+	h.Paths.PathItems.GetOrZero("/pet/findByStatus").Get.Responses.Codes.GetOrZero("400").Description = "a nice bucket of mice"
+	h.Paths.PathItems.GetOrZero("/pet/findByTags").Get.Tags =
+		append(h.Paths.PathItems.GetOrZero("/pet/findByTags").Get.Tags, "gurgle", "giggle")
 
-	h.Paths.PathItems["/pet/{petId}"].Delete.Security = append(h.Paths.PathItems["/pet/{petId}"].Delete.Security,
+	h.Paths.PathItems.GetOrZero("/pet/{petId}").Delete.Security = append(h.Paths.PathItems.GetOrZero("/pet/{petId}").Delete.Security,
 		&base.SecurityRequirement{Requirements: map[string][]string{
 			"pizza-and-cake": {"read:abook", "write:asong"},
 		}})
 
-	h.Components.Schemas["Order"].Schema().Properties["status"].Schema().Example = "I am a teapot, filled with love."
-	h.Components.SecuritySchemes["petstore_auth"].Flows.Implicit.AuthorizationUrl = "https://pb33f.io"
+	h.Components.Schemas.GetOrZero("Order").Schema().Properties.GetOrZero("status").Schema().Example = "I am a teapot, filled with love."
+	h.Components.SecuritySchemes.GetOrZero("petstore_auth").Flows.Implicit.AuthorizationUrl = "https://pb33f.io"
 
 	bytes, _, newDocModel, e := doc.RenderAndReload()
 	assert.Nil(t, e)
 	assert.NotNil(t, bytes)
 
 	h = newDocModel.Model
-	assert.Equal(t, "findACakeInABakery", h.Paths.PathItems["/pet/findByStatus"].Get.OperationId)
+	assert.Equal(t, "findACakeInABakery", h.Paths.PathItems.GetOrZero("/pet/findByStatus").Get.OperationId)
 	assert.Equal(t, "a nice bucket of mice",
-		h.Paths.PathItems["/pet/findByStatus"].Get.Responses.Codes["400"].Description)
-	assert.Len(t, h.Paths.PathItems["/pet/findByTags"].Get.Tags, 3)
+		h.Paths.PathItems.GetOrZero("/pet/findByStatus").Get.Responses.Codes.GetOrZero("400").Description)
+	assert.Len(t, h.Paths.PathItems.GetOrZero("/pet/findByTags").Get.Tags, 3)
 
-	assert.Len(t, h.Paths.PathItems["/pet/findByTags"].Get.Tags, 3)
-	yu := h.Paths.PathItems["/pet/{petId}"].Delete.Security
+	assert.Len(t, h.Paths.PathItems.GetOrZero("/pet/findByTags").Get.Tags, 3)
+	yu := h.Paths.PathItems.GetOrZero("/pet/{petId}").Delete.Security
 	assert.Equal(t, "read:abook", yu[len(yu)-1].Requirements["pizza-and-cake"][0])
 	assert.Equal(t, "I am a teapot, filled with love.",
-		h.Components.Schemas["Order"].Schema().Properties["status"].Schema().Example)
+		h.Components.Schemas.GetOrZero("Order").Schema().Properties.GetOrZero("status").Schema().Example)
 
 	assert.Equal(t, "https://pb33f.io",
-		h.Components.SecuritySchemes["petstore_auth"].Flows.Implicit.AuthorizationUrl)
+		h.Components.SecuritySchemes.GetOrZero("petstore_auth").Flows.Implicit.AuthorizationUrl)
 
 }
 func TestDocument_RenderAndReload_Swagger(t *testing.T) {
@@ -363,7 +363,7 @@ paths:
 	}
 
 	// extract operation.
-	operation := result.Model.Paths.PathItems["/something"].Get
+	operation := result.Model.Paths.PathItems.GetOrZero("/something").Get
 
 	// print it out.
 	fmt.Printf("param1: %s, is reference? %t, original reference %s",
@@ -441,16 +441,16 @@ func TestSchemaRefIsFollowed(t *testing.T) {
 
 	// get a count of the number of paths and schemas.
 	schemas := v3Model.Model.Components.Schemas
-	assert.Equal(t, 4, len(schemas))
+	assert.Equal(t, 4, schemas.Len())
 
-	fp := schemas["FP"]
-	fbsref := schemas["FBSRef"]
+	fp := schemas.GetOrZero("FP")
+	fbsref := schemas.GetOrZero("FBSRef")
 
 	assert.Equal(t, fp.Schema().Pattern, fbsref.Schema().Pattern)
 	assert.Equal(t, fp.Schema().Example, fbsref.Schema().Example)
 
-	byte := schemas["Byte"]
-	uint64 := schemas["UInt64"]
+	byte := schemas.GetOrZero("Byte")
+	uint64 := schemas.GetOrZero("UInt64")
 
 	assert.Equal(t, uint64.Schema().Format, byte.Schema().Format)
 	assert.Equal(t, uint64.Schema().Type, byte.Schema().Type)
@@ -530,7 +530,7 @@ paths:
 		panic(errs)
 	}
 
-	assert.Equal(t, "crs", result.Model.Paths.PathItems["/test"].Get.Parameters[0].Name)
+	assert.Equal(t, "crs", result.Model.Paths.PathItems.GetOrZero("/test").Get.Parameters[0].Name)
 }
 
 func TestDocument_ExampleMap(t *testing.T) {
@@ -595,3 +595,122 @@ paths:
 
 	assert.Equal(t, d, strings.TrimSpace(string(rend)))
 }
+
+func TestDocument_GetConfiguration(t *testing.T) {
+	config := datamodel.NewOpenDocumentConfiguration()
+	doc, err := NewDocumentWithConfiguration([]byte(`openapi: 3.1.0`), config)
+	assert.NoError(t, err)
+	assert.Same(t, config, doc.GetConfiguration())
+
+	doc, _ = NewDocument([]byte(`openapi: 3.1.0`))
+	assert.Nil(t, doc.GetConfiguration())
+}
+
+func TestDocument_Validate(t *testing.T) {
+	petstore, _ := ioutil.ReadFile("test_specs/petstorev3.json")
+	doc, err := NewDocument(petstore)
+	assert.NoError(t, err)
+
+	errs, err := doc.Validate()
+	assert.NoError(t, err)
+	assert.Empty(t, errs)
+
+	spec := `openapi: 3.1.0
+info:
+  title: pizza
+paths:
+  /pizza:
+    get:
+      responses:
+        '200':
+          description: nice`
+
+	doc, err = NewDocument([]byte(spec))
+	assert.NoError(t, err)
+
+	errs, err = doc.Validate()
+	assert.NoError(t, err)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "/info", errs[0].Path)
+	assert.Equal(t, 3, errs[0].Line)
+}
+
+func TestDocument_ApplyJSONPatch(t *testing.T) {
+	spec := `openapi: 3.0.3
+info:
+  title: pizza # the best
+  version: 1.0.0
+paths:
+  /pizza:
+    get:
+      responses:
+        '200':
+          description: nice`
+
+	doc, err := NewDocument([]byte(spec))
+	assert.NoError(t, err)
+	model, errs := doc.BuildV3Model()
+	assert.Empty(t, errs)
+	assert.Equal(t, "pizza", model.Model.Info.Title)
+
+	errs = doc.ApplyJSONPatch([]byte(`[
+  {"op": "replace", "path": "/openapi", "value": "3.1.0"},
+  {"op": "replace", "path": "/info/title", "value": "pasta"},
+  {"op": "add", "path": "/paths/~1pizza/get/summary", "value": "get pizza"}
+]`))
+	assert.Empty(t, errs)
+	assert.Equal(t, "3.1.0", doc.GetVersion())
+	assert.Equal(t, "3.1.0", doc.GetSpecInfo().Version)
+
+	model, errs = doc.BuildV3Model()
+	assert.Empty(t, errs)
+	assert.Equal(t, "pasta", model.Model.Info.Title)
+	assert.Equal(t, "get pizza", model.Model.Paths.PathItems.GetOrZero("/pizza").Get.Summary)
+
+	// untouched nodes keep their position and comments.
+	assert.Equal(t, 10, model.Model.Paths.PathItems.GetOrZero("/pizza").Get.Responses.Codes.GetOrZero("200").
+		GoLow().Description.ValueNode.Line)
+	out, _ := doc.Serialize()
+	assert.Contains(t, string(out), "title: pasta # the best")
+
+	errs = doc.ApplyJSONPatch([]byte(`[{"op": "remove", "path": "/info/contact"}]`))
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "unable to apply patch operation 0 (remove) at '/info/contact': 'contact' does not exist",
+		errs[0].Error())
+}
+
+func TestDocument_ApplyMergePatch(t *testing.T) {
+	spec := `swagger: 2.0
+info:
+  title: pizza
+  version: 1.0.0
+paths:
+  /pizza:
+    get:
+      responses:
+        '200':
+          description: nice`
+
+	doc, err := NewDocument([]byte(spec))
+	assert.NoError(t, err)
+	_, errs := doc.BuildV2Model()
+	assert.Empty(t, errs)
+
+	errs = doc.ApplyMergePatch([]byte(`{"info": {"title": "pasta", "version": null}, "basePath": "/v2"}`))
+	assert.Empty(t, errs)
+
+	model, errs := doc.BuildV2Model()
+	assert.Empty(t, errs)
+	assert.Equal(t, "pasta", model.Model.Info.Title)
+	assert.Empty(t, model.Model.Info.Version)
+	assert.Equal(t, "/v2", model.Model.BasePath)
+
+	errs = doc.ApplyMergePatch([]byte(``))
+	assert.Len(t, errs, 1)
+}
+
+func TestDocument_ApplyPatch_NotInitialized(t *testing.T) {
+	doc := new(document)
+	assert.Len(t, doc.ApplyJSONPatch([]byte(`[]`)), 1)
+	assert.Len(t, doc.ApplyMergePatch([]byte(`{}`)), 1)
+}
@@ -0,0 +1,224 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+// Package filter cuts an OpenAPI 3 document down to a subset of its operations.
+//
+// An OperationFilter decides which operations are kept. Everything the remaining operations do not use is shaken
+// out of the document: components that can no longer be reached, security schemes that are no longer required,
+// and tags that are no longer used by any operation.
+package filter
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/index"
+	"gopkg.in/yaml.v3"
+)
+
+// OperationFilter decides if an operation is kept. The path is the path the operation belongs to (or the name
+// of the webhook), and the method is lowercase, like 'get'.
+type OperationFilter func(path, method string, operation *v3high.Operation) bool
+
+// ByTag keeps operations that have any of the tags.
+func ByTag(tags ...string) OperationFilter {
+	return func(_, _ string, operation *v3high.Operation) bool {
+		for _, t := range operation.Tags {
+			for _, tag := range tags {
+				if t == tag {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+// ByPathPrefix keeps operations with a path that starts with any of the prefixes.
+func ByPathPrefix(prefixes ...string) OperationFilter {
+	return func(path, _ string, _ *v3high.Operation) bool {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(path, prefix) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// ByOperationId keeps operations with any of the operationIds.
+func ByOperationId(ids ...string) OperationFilter {
+	return func(_, _ string, operation *v3high.Operation) bool {
+		for _, id := range ids {
+			if operation.OperationId == id {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// ByExtension keeps operations with an extension (like 'x-audience') set to a value. If the extension holds a
+// list, the operation is kept if the value is in the list.
+func ByExtension(name string, value any) OperationFilter {
+	return func(_, _ string, operation *v3high.Operation) bool {
+		ext, ok := operation.Extensions[name]
+		if !ok {
+			return false
+		}
+		if list, isList := ext.([]any); isList {
+			for _, v := range list {
+				if reflect.DeepEqual(v, value) {
+					return true
+				}
+			}
+		}
+		return reflect.DeepEqual(ext, value)
+	}
+}
+
+// Any keeps operations that are kept by any of the filters.
+func Any(filters ...OperationFilter) OperationFilter {
+	return func(path, method string, operation *v3high.Operation) bool {
+		for _, f := range filters {
+			if f(path, method, operation) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// All keeps operations that are kept by all the filters.
+func All(filters ...OperationFilter) OperationFilter {
+	return func(path, method string, operation *v3high.Operation) bool {
+		for _, f := range filters {
+			if !f(path, method, operation) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// FilterDocument removes every operation (and webhook operation) that is not kept by the filter from a document
+// model, in place. Path items and webhooks that have no operations left are removed, along with every component,
+// security scheme and tag that is no longer used.
+//
+// The components that are still used are worked out from the rendered document, using the configuration of the
+// document's Index (if it has one), so references into other files are followed.
+func FilterDocument(doc *v3high.Document, keep OperationFilter) error {
+	if doc == nil {
+		return errors.New("unable to filter, no document was supplied")
+	}
+	if keep == nil {
+		return errors.New("unable to filter, no filter was supplied")
+	}
+
+	tags := make(map[string]bool)
+	if doc.Paths != nil && doc.Paths.PathItems != nil {
+		for _, path := range doc.Paths.PathItems.Keys() {
+			if !filterPathItem(path, doc.Paths.PathItems.GetOrZero(path), keep, tags) {
+				doc.Paths.PathItems.Delete(path)
+			}
+		}
+	}
+	for _, name := range doc.Webhooks.Keys() {
+		if !filterPathItem(name, doc.Webhooks.GetOrZero(name), keep, tags) {
+			doc.Webhooks.Delete(name)
+		}
+	}
+	if doc.Webhooks.Len() == 0 {
+		doc.Webhooks = nil
+	}
+
+	var keptTags []*base.Tag
+	for _, tag := range doc.Tags {
+		if tags[tag.Name] {
+			keptTags = append(keptTags, tag)
+		}
+	}
+	doc.Tags = keptTags
+
+	rendered, err := doc.MarshalYAML()
+	if err != nil {
+		return err
+	}
+	root, ok := rendered.(*yaml.Node)
+	if !ok {
+		return errors.New("unable to filter, the document did not render")
+	}
+	config := index.CreateClosedAPIIndexConfig()
+	if doc.Index != nil && doc.Index.GetConfig() != nil {
+		original := doc.Index.GetConfig()
+		config.BaseURL = original.BaseURL
+		config.BasePath = original.BasePath
+		config.AllowRemoteLookup = original.AllowRemoteLookup
+		config.AllowFileLookup = original.AllowFileLookup
+		config.RemoteFetcher = original.RemoteFetcher
+		config.FileFetcher = original.FileFetcher
+	}
+	idx := index.NewSpecIndexWithConfig(&yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{root}}, config)
+	doc.RemoveComponents(idx.GetUnusedComponents())
+	return nil
+}
+
+// Filter builds the OpenAPI 3 model of a document, filters it with FilterDocument, then renders and reloads it
+// (see libopenapi.Document.RenderAndReload). The filtered specification, document and model are returned.
+//
+// The document is not changed. The model is built from a new document, with the same specification and
+// configuration, so any changes made to a model the document already built (that have not been rendered) are
+// not part of the result.
+func Filter(document libopenapi.Document, keep OperationFilter) ([]byte, libopenapi.Document,
+	*libopenapi.DocumentModel[v3high.Document], []error) {
+	if document == nil || document.GetSpecInfo() == nil || document.GetSpecInfo().SpecBytes == nil {
+		return nil, nil, nil, []error{errors.New("unable to filter, no document was supplied")}
+	}
+	fresh, err := libopenapi.NewDocumentWithConfiguration(*document.GetSpecInfo().SpecBytes,
+		document.GetConfiguration())
+	if err != nil {
+		return nil, nil, nil, []error{err}
+	}
+	model, errs := fresh.BuildV3Model()
+	if model == nil {
+		return nil, nil, nil, errs
+	}
+	if err := FilterDocument(&model.Model, keep); err != nil {
+		return nil, nil, nil, []error{err}
+	}
+	return fresh.RenderAndReload()
+}
+
+// filterPathItem removes the operations of a path item that are not kept, and records the tags of the
+// operations that are. It returns false if no operations are left.
+func filterPathItem(path string, item *v3high.PathItem, keep OperationFilter, tags map[string]bool) bool {
+	if item == nil {
+		return false
+	}
+	operations := []struct {
+		method    string
+		operation **v3high.Operation
+	}{
+		{"get", &item.Get}, {"put", &item.Put}, {"post", &item.Post}, {"delete", &item.Delete},
+		{"options", &item.Options}, {"head", &item.Head}, {"patch", &item.Patch}, {"trace", &item.Trace},
+	}
+	kept := false
+	for _, op := range operations {
+		if *op.operation == nil {
+			continue
+		}
+		if !keep(path, op.method, *op.operation) {
+			*op.operation = nil
+			continue
+		}
+		kept = true
+		for _, tag := range (*op.operation).Tags {
+			tags[tag] = true
+		}
+	}
+	return kept
+}
@@ -0,0 +1,213 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package filter
+
+import (
+	"os"
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+const partnerSpec = `openapi: 3.1.0
+info:
+  title: pets
+  version: 1.0.0
+tags:
+  - name: pets
+  - name: admin
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      tags:
+        - pets
+      x-audience: partner
+      security:
+        - apiKey: []
+      responses:
+        "200":
+          description: pets
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+    delete:
+      operationId: deletePets
+      tags:
+        - admin
+      security:
+        - oauth: [admin]
+      responses:
+        "204":
+          $ref: '#/components/responses/Deleted'
+  /admin/audit:
+    get:
+      operationId: audit
+      tags:
+        - admin
+      x-audience:
+        - internal
+        - partner
+      parameters:
+        - $ref: '#/components/parameters/Since'
+      responses:
+        "200":
+          description: audit
+webhooks:
+  petAdded:
+    post:
+      operationId: petAdded
+      x-audience: partner
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+components:
+  parameters:
+    Since:
+      name: since
+      in: query
+  responses:
+    Deleted:
+      description: deleted
+      content:
+        application/json:
+          schema:
+            $ref: '#/components/schemas/Receipt'
+  schemas:
+    Pet:
+      properties:
+        tag:
+          $ref: '#/components/schemas/Tag'
+    Tag:
+      type: string
+    Receipt:
+      type: object
+  securitySchemes:
+    apiKey:
+      type: apiKey
+      name: key
+      in: header
+    oauth:
+      type: oauth2
+      flows:
+        implicit:
+          authorizationUrl: https://pb33f.io/auth
+          scopes:
+            admin: admin`
+
+func filterModel(t *testing.T, spec string, keep OperationFilter) *v3high.Document {
+	doc, err := libopenapi.NewDocument([]byte(spec))
+	assert.NoError(t, err)
+	model, errs := doc.BuildV3Model()
+	assert.Empty(t, errs)
+	assert.NoError(t, FilterDocument(&model.Model, keep))
+	return &model.Model
+}
+
+func operationIds(doc *v3high.Document) []string {
+	var ids []string
+	if doc.Paths != nil {
+		for _, path := range doc.Paths.PathItems.Keys() {
+			for _, op := range doc.Paths.PathItems.GetOrZero(path).GetOperations() {
+				ids = append(ids, op.OperationId)
+			}
+		}
+	}
+	return ids
+}
+
+func TestFilterDocument_ByTag(t *testing.T) {
+	doc := filterModel(t, partnerSpec, ByTag("pets"))
+
+	assert.Equal(t, []string{"listPets"}, operationIds(doc))
+	assert.Nil(t, doc.Webhooks)
+	assert.Len(t, doc.Tags, 1)
+	assert.Equal(t, "pets", doc.Tags[0].Name)
+	assert.Equal(t, []string{"Pet", "Tag"}, doc.Components.Schemas.Keys())
+	assert.Empty(t, doc.Components.Parameters.Keys())
+	assert.Empty(t, doc.Components.Responses.Keys())
+	assert.Equal(t, 1, doc.Components.SecuritySchemes.Len())
+	assert.NotNil(t, doc.Components.SecuritySchemes.GetOrZero("apiKey"))
+}
+
+func TestFilterDocument_ByOperationId(t *testing.T) {
+	doc := filterModel(t, partnerSpec, ByOperationId("deletePets"))
+
+	assert.Equal(t, []string{"deletePets"}, operationIds(doc))
+	assert.Nil(t, doc.Paths.PathItems.GetOrZero("/pets").Get)
+	assert.Equal(t, []string{"Receipt"}, doc.Components.Schemas.Keys())
+	assert.Equal(t, 1, doc.Components.Responses.Len())
+	assert.NotNil(t, doc.Components.SecuritySchemes.GetOrZero("oauth"))
+	assert.Len(t, doc.Tags, 1)
+	assert.Equal(t, "admin", doc.Tags[0].Name)
+}
+
+func TestFilterDocument_ByPathPrefix(t *testing.T) {
+	doc := filterModel(t, partnerSpec, ByPathPrefix("/admin"))
+
+	assert.Equal(t, []string{"audit"}, operationIds(doc))
+	assert.Equal(t, 1, doc.Paths.PathItems.Len())
+	assert.Empty(t, doc.Components.Schemas.Keys())
+	assert.Equal(t, 1, doc.Components.Parameters.Len())
+	assert.Empty(t, doc.Components.SecuritySchemes.Keys())
+}
+
+func TestFilterDocument_ByExtension(t *testing.T) {
+	doc := filterModel(t, partnerSpec, ByExtension("x-audience", "partner"))
+
+	assert.ElementsMatch(t, []string{"listPets", "audit"}, operationIds(doc))
+	assert.Equal(t, 1, doc.Webhooks.Len())
+	assert.NotNil(t, doc.Webhooks.GetOrZero("petAdded"))
+	assert.Equal(t, []string{"Pet", "Tag"}, doc.Components.Schemas.Keys())
+	assert.Equal(t, 1, doc.Components.Parameters.Len())
+	assert.Empty(t, doc.Components.Responses.Keys())
+}
+
+func TestFilterDocument_AnyAll(t *testing.T) {
+	doc := filterModel(t, partnerSpec, All(ByTag("admin"), Any(ByOperationId("audit"), ByPathPrefix("/nope"))))
+	assert.Equal(t, []string{"audit"}, operationIds(doc))
+
+	doc = filterModel(t, partnerSpec, Any(ByTag("nope"), ByOperationId("listPets")))
+	assert.Equal(t, []string{"listPets"}, operationIds(doc))
+}
+
+func TestFilterDocument_Errors(t *testing.T) {
+	assert.Error(t, FilterDocument(nil, ByTag("pets")))
+	assert.Error(t, FilterDocument(&v3high.Document{}, nil))
+}
+
+func TestFilter(t *testing.T) {
+	spec, _ := os.ReadFile("../test_specs/burgershop.openapi.yaml")
+	doc, err := libopenapi.NewDocument(spec)
+	assert.NoError(t, err)
+
+	filtered, newDoc, model, errs := Filter(doc, ByOperationId("getDressing"))
+	assert.Empty(t, errs)
+	assert.NotNil(t, newDoc)
+	assert.NotEmpty(t, filtered)
+
+	assert.Equal(t, []string{"getDressing"}, operationIds(&model.Model))
+	assert.Equal(t, []string{"Error", "Dressing"}, model.Model.Components.Schemas.Keys())
+	assert.Len(t, model.Model.Tags, 1)
+	assert.Equal(t, "Dressing", model.Model.Tags[0].Name)
+	assert.Empty(t, model.Model.Index.GetUnusedComponents())
+
+	// the original document and its model are left alone.
+	original, errs := doc.BuildV3Model()
+	assert.Empty(t, errs)
+	assert.Greater(t, len(operationIds(&original.Model)), 1)
+	assert.Greater(t, original.Model.Components.Schemas.Len(), 2)
+}
+
+func TestFilter_NoDocument(t *testing.T) {
+	_, _, _, errs := Filter(nil, ByTag("pets"))
+	assert.Len(t, errs, 1)
+}
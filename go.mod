@@ -3,6 +3,7 @@ module github.com/pb33f/libopenapi
 go 1.18
 
 require (
+	github.com/santhosh-tekuri/jsonschema/v5 v5.3.1
 	github.com/stretchr/testify v1.8.0
 	github.com/vmware-labs/yaml-jsonpath v0.3.2
 	golang.org/x/sync v0.1.0
@@ -18,6 +18,12 @@ func (index *SpecIndex) ExtractRefs(node, parent *yaml.Node, seenPath []string,
 		return nil
 	}
 	var found []*Reference
+
+	// register any '$id' or '$anchor' values, an '$id' creates a new base URI scope for everything below it.
+	if index.registerSchemaIdentifiers(node, seenPath) {
+		defer index.popSchemaIdScope()
+	}
+
 	if len(node.Content) > 0 {
 		var prev, polyName string
 		for i, n := range node.Content {
@@ -119,7 +125,12 @@ func (index *SpecIndex) ExtractRefs(node, parent *yaml.Node, seenPath []string,
 					Name:       name,
 					Node:       node,
 					Path:       p,
+					BaseURI:    index.currentSchemaIdScope(),
 				}
+				if len(index.schemaIdScope) > 0 {
+					index.schemaIdRefScopes[node] = ref.BaseURI
+				}
+				key := index.GetReferenceKey(node, value)
 
 				// add to raw sequenced refs
 				index.rawSequencedRefs = append(index.rawSequencedRefs, ref)
@@ -144,6 +155,7 @@ func (index *SpecIndex) ExtractRefs(node, parent *yaml.Node, seenPath []string,
 						Name:       ref.Name,
 						Node:       &copiedNode,
 						Path:       p,
+						BaseURI:    ref.BaseURI,
 					}
 					// protect this data using a copy, prevent the resolver from destroying things.
 					index.refsWithSiblings[value] = copied
@@ -169,7 +181,7 @@ func (index *SpecIndex) ExtractRefs(node, parent *yaml.Node, seenPath []string,
 				}
 
 				// check if this is a dupe, if so, skip it, we don't care now.
-				if index.allRefs[value] != nil { // seen before, skip.
+				if index.allRefs[key] != nil { // seen before, skip.
 					continue
 				}
 
@@ -188,7 +200,7 @@ func (index *SpecIndex) ExtractRefs(node, parent *yaml.Node, seenPath []string,
 					continue
 				}
 
-				index.allRefs[value] = ref
+				index.allRefs[key] = ref
 				found = append(found, ref)
 			}
 
@@ -364,15 +376,27 @@ func (index *SpecIndex) ExtractComponentsFromRefs(refs []*Reference) []*Referenc
 	c := make(chan bool)
 
 	locate := func(ref *Reference, refIndex int, sequence []*ReferenceMapped) {
-		located := index.FindComponent(ref.Definition, ref.Node)
+		// check '$id' and '$anchor' identifiers first (3.1+), before a regular lookup.
+		located := index.findSchemaIdReference(ref)
+		if located == nil {
+			located = index.FindComponent(ref.Definition, ref.Node)
+		}
 		if located != nil {
+			// references made inside an '$id' scope are mapped by the URI they resolve to, the same relative
+			// reference can point to a different schema in another scope.
+			key := index.GetReferenceKey(ref.Node, ref.Definition)
+			if key != ref.Definition && located.Definition != key {
+				scoped := *located
+				scoped.Definition = key
+				located = &scoped
+			}
 			index.refLock.Lock()
-			if index.allMappedRefs[ref.Definition] == nil {
+			if index.allMappedRefs[key] == nil {
 				found = append(found, located)
-				index.allMappedRefs[ref.Definition] = located
+				index.allMappedRefs[key] = located
 				sequence[refIndex] = &ReferenceMapped{
 					Reference:  located,
-					Definition: ref.Definition,
+					Definition: key,
 				}
 			}
 			index.refLock.Unlock()
@@ -0,0 +1,126 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Fetcher is used by the index to retrieve the raw bytes of any document that is referenced from the specification,
+// but is not a part of it. Remote references are fetched using the RemoteFetcher set in the SpecIndexConfig,
+// file references are read using the FileFetcher.
+//
+// Implementing a Fetcher allows a custom HTTP client (with auth headers, proxies and retries) to be used for
+// remote lookups, or in-memory fixtures to be used for tests.
+type Fetcher interface {
+	// Fetch will return the raw bytes of the document found at the supplied location. For remote lookups, the
+	// location is a fully qualified URL, for file lookups it's a file path (joined with the configured BasePath).
+	Fetch(location string) ([]byte, error)
+}
+
+// FetcherFunc is an adapter that allows an ordinary function to be used as a Fetcher.
+type FetcherFunc func(location string) ([]byte, error)
+
+// Fetch calls f(location)
+func (f FetcherFunc) Fetch(location string) ([]byte, error) {
+	return f(location)
+}
+
+// HTTPFetcher is a Fetcher that uses an *http.Client to retrieve remote documents.
+type HTTPFetcher struct {
+	Client *http.Client
+}
+
+// NewHTTPFetcher will create a new HTTPFetcher using the supplied *http.Client. If the client is nil, then a default
+// client with a 60-second timeout will be used.
+func NewHTTPFetcher(client *http.Client) *HTTPFetcher {
+	if client == nil {
+		client = &http.Client{Timeout: time.Duration(60) * time.Second}
+	}
+	return &HTTPFetcher{Client: client}
+}
+
+// Fetch will perform a GET request against the location and return the body of the response.
+func (h *HTTPFetcher) Fetch(location string) ([]byte, error) {
+	resp, err := h.Client.Get(location)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return io.ReadAll(resp.Body)
+}
+
+// OSFetcher is a Fetcher that reads files from the local file system.
+type OSFetcher struct{}
+
+// Fetch will read the file at the location from the local file system.
+func (o *OSFetcher) Fetch(location string) ([]byte, error) {
+	return os.ReadFile(location)
+}
+
+// FSFetcher is a Fetcher that reads files from an fs.FS, such as an embed.FS or a *zip.Reader. This allows
+// exploded multi-file specifications to be resolved without access to the host file system.
+type FSFetcher struct {
+	FS fs.FS
+}
+
+// NewFSFetcher will create a new FSFetcher that reads files from the supplied fs.FS.
+func NewFSFetcher(fileSystem fs.FS) *FSFetcher {
+	return &FSFetcher{FS: fileSystem}
+}
+
+// Fetch will read the file at the location from the fs.FS. The location is cleaned and converted into
+// an unrooted, slash separated path, as required by fs.FS.
+func (f *FSFetcher) Fetch(location string) ([]byte, error) {
+	return fs.ReadFile(f.FS, CleanFSPath(location))
+}
+
+// CleanFSPath will convert a file path into a valid fs.FS path (slash separated, unrooted and cleaned).
+func CleanFSPath(location string) string {
+	p := path.Clean(filepath.ToSlash(location))
+	p = strings.TrimPrefix(p, "/")
+	if p == "" {
+		return "."
+	}
+	return p
+}
+
+// MapFetcher is a Fetcher that returns documents from an in-memory map, keyed by location.
+// It's useful for tests, or when all the documents are already available.
+type MapFetcher map[string][]byte
+
+// Fetch will return the bytes stored against the location, or an error if there is nothing stored.
+func (m MapFetcher) Fetch(location string) ([]byte, error) {
+	if b, ok := m[location]; ok {
+		return b, nil
+	}
+	return nil, fmt.Errorf("unable to fetch '%s', location does not exist", location)
+}
+
+var defaultRemoteFetcher Fetcher = NewHTTPFetcher(nil)
+var defaultFileFetcher Fetcher = &OSFetcher{}
+
+// getRemoteFetcher returns the RemoteFetcher set in the index configuration, or the default if one is not set.
+func (index *SpecIndex) getRemoteFetcher() Fetcher {
+	if index.config == nil || index.config.RemoteFetcher == nil {
+		return defaultRemoteFetcher
+	}
+	return index.config.RemoteFetcher
+}
+
+// getFileFetcher returns the FileFetcher set in the index configuration, or the default if one is not set.
+func (index *SpecIndex) getFileFetcher() Fetcher {
+	if index.config == nil || index.config.FileFetcher == nil {
+		return defaultFileFetcher
+	}
+	return index.config.FileFetcher
+}
@@ -0,0 +1,227 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestSpecIndex_RemoteFetcher_MapFetcher(t *testing.T) {
+	yml := `openapi: 3.1.0
+components:
+  schemas:
+    thing:
+      properties:
+        thong:
+          $ref: 'https://pb33f.io/remote.yaml#/components/schemas/thong'`
+
+	remote := `components:
+  schemas:
+    thong:
+      type: string
+      description: a remote thong`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &rootNode)
+
+	c := CreateClosedAPIIndexConfig()
+	c.AllowRemoteLookup = true
+	c.RemoteFetcher = MapFetcher{"https://pb33f.io/remote.yaml": []byte(remote)}
+
+	index := NewSpecIndexWithConfig(&rootNode, c)
+	assert.Len(t, index.GetReferenceIndexErrors(), 0)
+
+	ref := index.GetMappedReferences()["https://pb33f.io/remote.yaml#/components/schemas/thong"]
+	assert.NotNil(t, ref)
+	assert.True(t, ref.IsRemote)
+	assert.Equal(t, "thong", ref.Name)
+}
+
+func TestSpecIndex_RemoteFetcher_Error(t *testing.T) {
+	yml := `openapi: 3.1.0
+components:
+  schemas:
+    thing:
+      properties:
+        thong:
+          $ref: 'https://pb33f.io/remote.yaml#/components/schemas/thong'`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &rootNode)
+
+	c := CreateClosedAPIIndexConfig()
+	c.AllowRemoteLookup = true
+	c.RemoteFetcher = FetcherFunc(func(location string) ([]byte, error) {
+		return nil, errors.New("no more thongs")
+	})
+
+	index := NewSpecIndexWithConfig(&rootNode, c)
+	assert.Len(t, index.GetReferenceIndexErrors(), 2)
+	assert.Equal(t, "no more thongs", index.GetReferenceIndexErrors()[0].Error())
+}
+
+func TestSpecIndex_FileFetcher_MapFetcher(t *testing.T) {
+	yml := `openapi: 3.1.0
+components:
+  schemas:
+    thing:
+      properties:
+        thong:
+          $ref: 'thongs.yaml#/components/schemas/thong'`
+
+	file := `components:
+  schemas:
+    thong:
+      type: string`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &rootNode)
+
+	c := CreateClosedAPIIndexConfig()
+	c.AllowFileLookup = true
+	c.BasePath = "/specs"
+	c.FileFetcher = MapFetcher{"/specs/thongs.yaml": []byte(file)}
+
+	index := NewSpecIndexWithConfig(&rootNode, c)
+	assert.Len(t, index.GetReferenceIndexErrors(), 0)
+	assert.NotNil(t, index.GetMappedReferences()["thongs.yaml#/components/schemas/thong"])
+	assert.Len(t, index.GetChildren(), 1)
+	assert.Equal(t, c.FileFetcher, index.GetChildren()[0].config.FileFetcher)
+}
+
+func TestSpecIndex_FileFetcher_Missing(t *testing.T) {
+	yml := `openapi: 3.1.0
+components:
+  schemas:
+    thing:
+      $ref: 'thongs.yaml#/components/schemas/thong'`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &rootNode)
+
+	c := CreateClosedAPIIndexConfig()
+	c.AllowFileLookup = true
+	c.BasePath = "/specs"
+	c.FileFetcher = MapFetcher{}
+
+	index := NewSpecIndexWithConfig(&rootNode, c)
+	assert.Len(t, index.GetReferenceIndexErrors(), 2)
+	assert.Equal(t, "unable to fetch '/specs/thongs.yaml', location does not exist",
+		index.GetReferenceIndexErrors()[0].Error())
+}
+
+func TestSpecIndex_DefaultFetchers(t *testing.T) {
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte("openapi: 3.1.0"), &rootNode)
+	index := NewSpecIndexWithConfig(&rootNode, &SpecIndexConfig{})
+	assert.Equal(t, defaultRemoteFetcher, index.getRemoteFetcher())
+	assert.Equal(t, defaultFileFetcher, index.getFileFetcher())
+
+	index = new(SpecIndex)
+	assert.Equal(t, defaultRemoteFetcher, index.getRemoteFetcher())
+	assert.Equal(t, defaultFileFetcher, index.getFileFetcher())
+}
+
+func TestHTTPFetcher_Fetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer pizza", r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte("type: string"))
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &authTransport{token: "pizza"}}
+	fetcher := NewHTTPFetcher(client)
+	b, err := fetcher.Fetch(server.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, "type: string", string(b))
+}
+
+func TestHTTPFetcher_Fetch_Error(t *testing.T) {
+	fetcher := NewHTTPFetcher(nil)
+	_, err := fetcher.Fetch("htttttp://not-a-thing")
+	assert.Error(t, err)
+}
+
+type authTransport struct {
+	token string
+}
+
+func (a *authTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r.Header.Set("Authorization", "Bearer "+a.token)
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func TestFSFetcher_Fetch(t *testing.T) {
+	fileSystem := fstest.MapFS{
+		"specs/thongs.yaml": &fstest.MapFile{Data: []byte("type: string")},
+	}
+	fetcher := NewFSFetcher(fileSystem)
+
+	b, err := fetcher.Fetch("specs/thongs.yaml")
+	assert.NoError(t, err)
+	assert.Equal(t, "type: string", string(b))
+
+	b, err = fetcher.Fetch("/specs/../specs/./thongs.yaml")
+	assert.NoError(t, err)
+	assert.Equal(t, "type: string", string(b))
+
+	_, err = fetcher.Fetch("specs/nope.yaml")
+	assert.Error(t, err)
+}
+
+func TestCleanFSPath(t *testing.T) {
+	assert.Equal(t, ".", CleanFSPath(""))
+	assert.Equal(t, ".", CleanFSPath("/"))
+	assert.Equal(t, "a/b.yaml", CleanFSPath("./a/b.yaml"))
+	assert.Equal(t, "a/b.yaml", CleanFSPath("/a/c/../b.yaml"))
+}
+
+func TestSpecIndex_FSFetcher_ExplodedSpec(t *testing.T) {
+	yml := `openapi: 3.1.0
+components:
+  schemas:
+    thing:
+      properties:
+        thong:
+          $ref: 'schemas/thongs.yaml#/components/schemas/thong'`
+
+	thongs := `components:
+  schemas:
+    thong:
+      type: object
+      properties:
+        size:
+          $ref: 'sizes.yaml#/size'`
+
+	sizes := `size:
+  type: string`
+
+	fileSystem := fstest.MapFS{
+		"specs/schemas/thongs.yaml": &fstest.MapFile{Data: []byte(thongs)},
+		"specs/schemas/sizes.yaml":  &fstest.MapFile{Data: []byte(sizes)},
+	}
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &rootNode)
+
+	c := CreateClosedAPIIndexConfig()
+	c.AllowFileLookup = true
+	c.BasePath = "specs"
+	c.FileFetcher = NewFSFetcher(fileSystem)
+
+	index := NewSpecIndexWithConfig(&rootNode, c)
+	assert.Len(t, index.GetReferenceIndexErrors(), 0)
+	assert.Len(t, index.GetChildren(), 1)
+
+	child := index.GetChildren()[0]
+	assert.Len(t, child.GetReferenceIndexErrors(), 0)
+	assert.NotNil(t, child.GetMappedReferences()["sizes.yaml#/size"])
+}
@@ -8,13 +8,9 @@ import (
 	"github.com/pb33f/libopenapi/utils"
 	"github.com/vmware-labs/yaml-jsonpath/pkg/yamlpath"
 	"gopkg.in/yaml.v3"
-	"io/ioutil"
-	"net/http"
 	"net/url"
-	"os"
 	"path/filepath"
 	"strings"
-	"time"
 )
 
 // FindComponent will locate a component by its reference, returns nil if nothing is found.
@@ -25,6 +21,11 @@ func (index *SpecIndex) FindComponent(componentId string, parent *yaml.Node) *Re
 		return nil
 	}
 
+	// check for schemas identified by an '$id' or '$anchor' (3.1+), in the scope of the parent.
+	if ref := index.FindSchemaById(index.GetReferenceKey(parent, componentId)); ref != nil {
+		return ref
+	}
+
 	remoteLookup := func(id string) (*yaml.Node, *yaml.Node, error) {
 		if index.config.AllowRemoteLookup {
 			return index.lookupRemoteReference(id)
@@ -85,18 +86,14 @@ func (index *SpecIndex) FindComponent(componentId string, parent *yaml.Node) *Re
 	return nil
 }
 
-var httpClient = &http.Client{Timeout: time.Duration(60) * time.Second}
-
-func getRemoteDoc(u string, d chan []byte, e chan error) {
-	resp, err := httpClient.Get(u)
+func getRemoteDoc(fetcher Fetcher, u string, d chan []byte, e chan error) {
+	body, err := fetcher.Fetch(u)
 	if err != nil {
 		e <- err
 		close(e)
 		close(d)
 		return
 	}
-	var body []byte
-	body, _ = ioutil.ReadAll(resp.Body)
 	d <- body
 	close(e)
 	close(d)
@@ -121,7 +118,7 @@ func (index *SpecIndex) lookupRemoteReference(ref string) (*yaml.Node, *yaml.Nod
 		go func(uri string) {
 			bc := make(chan []byte)
 			ec := make(chan error)
-			go getRemoteDoc(uri, bc, ec)
+			go getRemoteDoc(index.getRemoteFetcher(), uri, bc, ec)
 			select {
 			case v := <-bc:
 				body = v
@@ -193,7 +190,7 @@ func (index *SpecIndex) lookupFileReference(ref string) (*yaml.Node, *yaml.Node,
 
 		// try and read the file off the local file system, if it fails
 		// check for a baseURL and then ask our remote lookup function to go try and get it.
-		body, err := os.ReadFile(fileToRead)
+		body, err := index.getFileFetcher().Fetch(fileToRead)
 
 		if err != nil {
 
@@ -353,6 +350,8 @@ func (index *SpecIndex) performExternalLookup(uri []string, componentId string,
 					BasePath:          newBasePath,
 					AllowRemoteLookup: index.config.AllowRemoteLookup,
 					AllowFileLookup:   index.config.AllowFileLookup,
+					RemoteFetcher:     index.config.RemoteFetcher,
+					FileFetcher:       index.config.FileFetcher,
 					ParentIndex:       index,
 					seenRemoteSources: index.config.seenRemoteSources,
 					remoteLock:        index.config.remoteLock,
@@ -6,7 +6,6 @@ package index
 import (
 	"golang.org/x/sync/syncmap"
 	"gopkg.in/yaml.v3"
-	"net/http"
 	"net/url"
 	"os"
 	"sync"
@@ -33,6 +32,7 @@ type Reference struct {
 	RemoteLocation        string
 	Path                  string              // this won't always be available.
 	RequiredRefProperties map[string][]string // definition names (eg, #/definitions/One) to a list of required properties on this definition which reference that definition
+	BaseURI               string              // the '$id' base URI scope the reference was found in (3.1+ only)
 }
 
 // ReferenceMapped is a helper struct for mapped references put into sequence (we lose the key)
@@ -72,6 +72,16 @@ type SpecIndexConfig struct {
 	AllowRemoteLookup bool // Allow remote lookups for references. Defaults to false
 	AllowFileLookup   bool // Allow file lookups for references. Defaults to false
 
+	// RemoteFetcher is used to retrieve remote documents when AllowRemoteLookup is enabled. If not set, then
+	// an HTTPFetcher using a default *http.Client with a 60-second timeout is used.
+	//
+	// Set this to use a custom HTTP client (auth headers, proxies, retries) or to serve documents from memory.
+	RemoteFetcher Fetcher
+
+	// FileFetcher is used to read local files when AllowFileLookup is enabled. If not set, then the
+	// local file system is used (OSFetcher).
+	FileFetcher Fetcher
+
 	// ParentIndex allows the index to be created with knowledge of a parent, before being parsed. This allows
 	// a breakglass to be used to prevent loops, checking the tree before recursing down.
 	ParentIndex *SpecIndex
@@ -92,6 +102,8 @@ func CreateOpenAPIIndexConfig() *SpecIndexConfig {
 		BasePath:          cw,
 		AllowRemoteLookup: true,
 		AllowFileLookup:   true,
+		RemoteFetcher:     defaultRemoteFetcher,
+		FileFetcher:       defaultFileFetcher,
 		seenRemoteSources: &syncmap.Map{},
 	}
 }
@@ -106,6 +118,8 @@ func CreateClosedAPIIndexConfig() *SpecIndexConfig {
 		BasePath:          cw,
 		AllowRemoteLookup: false,
 		AllowFileLookup:   false,
+		RemoteFetcher:     defaultRemoteFetcher,
+		FileFetcher:       defaultFileFetcher,
 		seenRemoteSources: &syncmap.Map{},
 	}
 }
@@ -204,6 +218,9 @@ type SpecIndex struct {
 	allSummaries                        []*DescriptionReference                       // every single summary found in the spec.
 	allEnums                            []*EnumReference                              // every single enum found in the spec.
 	allObjectsWithProperties            []*ObjectReference                            // every single object with properties found in the spec.
+	schemaIdRegistry                    map[string]*Reference                         // every '$id' and '$anchor' found in the spec (3.1+ only)
+	schemaIdScope                       []string                                      // stack of '$id' base URIs, used while extracting refs.
+	schemaIdRefScopes                   map[*yaml.Node]string                         // the '$id' base URI of every reference made inside an '$id' scope (3.1+ only)
 	enumCount                           int
 	descriptionCount                    int
 	summaryCount                        int
@@ -218,7 +235,6 @@ type SpecIndex struct {
 	allowCircularReferences             bool                       // decide if you want to error out, or allow circular references, default is false.
 	relativePath                        string                     // relative path of the spec file.
 	config                              *SpecIndexConfig           // configuration for the index
-	httpClient                          *http.Client
 	componentIndexChan                  chan bool
 	polyComponentIndexChan              chan bool
 
@@ -5,9 +5,7 @@ package index
 
 import (
     "gopkg.in/yaml.v3"
-    "net/http"
     "strings"
-    "time"
 )
 
 func isHttpMethod(val string) bool {
@@ -85,7 +83,8 @@ func boostrapIndexCollections(rootNode *yaml.Node, index *SpecIndex) {
     index.seenRemoteSources = make(map[string]*yaml.Node)
     index.seenLocalSources = make(map[string]*yaml.Node)
     index.opServersRefs = make(map[string]map[string][]*Reference)
-    index.httpClient = &http.Client{Timeout: time.Duration(5) * time.Second}
+    index.schemaIdRegistry = make(map[string]*Reference)
+    index.schemaIdRefScopes = make(map[*yaml.Node]string)
     index.componentIndexChan = make(chan bool)
     index.polyComponentIndexChan = make(chan bool)
 }
@@ -0,0 +1,311 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// GraphNodeKind is the kind of thing a node in a ReferenceGraph represents.
+type GraphNodeKind string
+
+const (
+	// GraphNodeOperation is an operation, in 'paths' or 'webhooks'.
+	GraphNodeOperation GraphNodeKind = "operation"
+
+	// GraphNodePathItem is a path item, used for references made outside any operation (like shared parameters).
+	GraphNodePathItem GraphNodeKind = "path"
+
+	// GraphNodeComponent is a component (or a Swagger definition, parameter or response).
+	GraphNodeComponent GraphNodeKind = "component"
+
+	// GraphNodeFile is an external file (or remote document) that is referenced.
+	GraphNodeFile GraphNodeKind = "file"
+
+	// GraphNodeDocument is the root document, used for references that do not belong to anything else.
+	GraphNodeDocument GraphNodeKind = "document"
+)
+
+// GraphNode is an operation, component or external file in a ReferenceGraph.
+type GraphNode struct {
+	// ID is unique within the graph. Local things use the JSON pointer of the thing (for example
+	// '#/components/schemas/Pet'), external files use the location of the file.
+	ID string `json:"id"`
+
+	// Kind is what the node represents.
+	Kind GraphNodeKind `json:"kind"`
+
+	// Name is a readable name for the node, like 'GET /pets' or 'Pet'.
+	Name string `json:"name"`
+
+	// Circular is true if the node is part of a circular reference.
+	Circular bool `json:"circular,omitempty"`
+
+	// Edges are the references made from this node.
+	Edges []*GraphEdge `json:"edges"`
+}
+
+// GraphEdge is a reference from one node in a ReferenceGraph to another.
+type GraphEdge struct {
+	// To is the ID of the node being referenced.
+	To string `json:"to"`
+
+	// Label is the property that holds the reference, relative to the node making it (like 'allOf', 'items'
+	// or 'properties.pet'). Array positions are left out.
+	Label string `json:"label"`
+
+	// Reference is the reference as it was written.
+	Reference string `json:"ref"`
+
+	// Circular is true if the reference is part of a circular reference.
+	Circular bool `json:"circular,omitempty"`
+}
+
+// ReferenceGraph is the dependency graph of a specification, it contains every operation, component and
+// external file, and all the references between them.
+type ReferenceGraph struct {
+	// Nodes are in the order they appear in the specification, external files are added as they are found.
+	Nodes []*GraphNode `json:"nodes"`
+
+	nodes map[string]*GraphNode
+}
+
+// GetReferenceGraph builds the dependency graph of the specification. Circular references are highlighted if
+// they are known, which is the case once a document model has been built, or the resolver has checked the
+// index for circular references.
+//
+// Only references made by the root document are included, references between external files are not.
+func (index *SpecIndex) GetReferenceGraph() *ReferenceGraph {
+	graph := &ReferenceGraph{nodes: make(map[string]*GraphNode)}
+	root := index.GetRootNode()
+	if root != nil && root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	if root == nil {
+		return graph
+	}
+	graph.walk(root, nil)
+
+	for _, result := range index.GetCircularReferences() {
+		if result == nil || result.LoopIndex < 0 || result.LoopIndex >= len(result.Journey) {
+			continue
+		}
+		var prev *GraphNode
+		for _, ref := range result.Journey[result.LoopIndex:] {
+			if ref == nil {
+				continue
+			}
+			id, _, _ := graphTarget(ref.Definition)
+			node := graph.nodes[id]
+			if node == nil {
+				continue
+			}
+			node.Circular = true
+			if prev != nil {
+				for _, edge := range prev.Edges {
+					if edge.To == node.ID {
+						edge.Circular = true
+					}
+				}
+			}
+			prev = node
+		}
+	}
+	return graph
+}
+
+// Node returns the node with an ID, or nil if there is no such node.
+func (g *ReferenceGraph) Node(id string) *GraphNode {
+	return g.nodes[id]
+}
+
+// walk finds every reference under a node, path is the location of the node in the document.
+func (g *ReferenceGraph) walk(node *yaml.Node, path []string) {
+	if node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+	switch node.Kind {
+	case yaml.SequenceNode:
+		// array positions are not part of the path, they are left out of edge labels.
+		for _, n := range node.Content {
+			g.walk(n, path)
+		}
+	case yaml.MappingNode:
+		// every operation and component is in the graph, even if it makes no references.
+		id, kind, name, depth := graphOwner(path)
+		if depth == len(path) && (kind == GraphNodeOperation || kind == GraphNodeComponent) {
+			g.add(id, kind, name)
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			k, v := node.Content[i], node.Content[i+1]
+			if k.Value == "$ref" && v.Kind == yaml.ScalarNode && v.Value != "" {
+				g.reference(path, v.Value)
+				continue
+			}
+			if k.Value == "example" || strings.HasPrefix(k.Value, "x-") {
+				continue
+			}
+			g.walk(v, append(path[:len(path):len(path)], k.Value))
+		}
+	}
+}
+
+// reference adds an edge for a reference found at a location in the document.
+func (g *ReferenceGraph) reference(path []string, ref string) {
+	id, kind, name, depth := graphOwner(path)
+	from := g.add(id, kind, name)
+
+	label := strings.Join(path[depth:], ".")
+	if label == "" {
+		label = "$ref"
+	}
+
+	to := g.add(graphTarget(ref))
+	for _, edge := range from.Edges {
+		if edge.To == to.ID && edge.Reference == ref && edge.Label == label {
+			return
+		}
+	}
+	from.Edges = append(from.Edges, &GraphEdge{To: to.ID, Label: label, Reference: ref})
+}
+
+// add returns the node with an ID, creating it if it does not exist.
+func (g *ReferenceGraph) add(id string, kind GraphNodeKind, name string) *GraphNode {
+	if n := g.nodes[id]; n != nil {
+		return n
+	}
+	n := &GraphNode{ID: id, Kind: kind, Name: name, Edges: []*GraphEdge{}}
+	g.nodes[id] = n
+	g.Nodes = append(g.Nodes, n)
+	return n
+}
+
+// graphOwner works out which node owns a location in the document, depth is the number of path segments
+// that locate the owner.
+func graphOwner(path []string) (id string, kind GraphNodeKind, name string, depth int) {
+	pointer := func(segments []string) string {
+		escaped := make([]string, len(segments))
+		for i, s := range segments {
+			escaped[i] = utils.EscapePointerSegment(s)
+		}
+		return "#/" + strings.Join(escaped, "/")
+	}
+	if len(path) >= 2 {
+		switch path[0] {
+		case "paths", "webhooks":
+			if len(path) >= 3 && isGraphMethod(path[2]) {
+				return pointer(path[:3]), GraphNodeOperation, strings.ToUpper(path[2]) + " " + path[1], 3
+			}
+			return pointer(path[:2]), GraphNodePathItem, path[1], 2
+		case "components":
+			if len(path) >= 3 {
+				return pointer(path[:3]), GraphNodeComponent, path[2], 3
+			}
+		case "definitions", "parameters", "responses":
+			return pointer(path[:2]), GraphNodeComponent, path[1], 2
+		}
+	}
+	return "#", GraphNodeDocument, "document", 0
+}
+
+// graphTarget works out which node a reference points to.
+func graphTarget(ref string) (string, GraphNodeKind, string) {
+	location, fragment, _ := strings.Cut(ref, "#")
+	if location != "" {
+		return location, GraphNodeFile, location
+	}
+	var path []string
+	for _, segment := range strings.Split(strings.TrimPrefix(fragment, "/"), "/") {
+		path = append(path, utils.UnescapePointerSegment(segment))
+	}
+	id, kind, name, _ := graphOwner(path)
+	return id, kind, name
+}
+
+// isGraphMethod returns true if a path item key is an operation.
+func isGraphMethod(key string) bool {
+	switch key {
+	case "get", "put", "post", "delete", "options", "head", "patch", "trace":
+		return true
+	}
+	return false
+}
+
+// RenderDOT renders the graph in the Graphviz DOT language, circular references are drawn in red.
+func (g *ReferenceGraph) RenderDOT() []byte {
+	var b strings.Builder
+	quote := func(s string) string {
+		return `"` + strings.ReplaceAll(strings.ReplaceAll(s, `\`, `\\`), `"`, `\"`) + `"`
+	}
+	shapes := map[GraphNodeKind]string{
+		GraphNodeOperation: "box", GraphNodePathItem: "box", GraphNodeComponent: "ellipse",
+		GraphNodeFile: "note", GraphNodeDocument: "folder",
+	}
+	b.WriteString("digraph references {\n")
+	for _, n := range g.Nodes {
+		fmt.Fprintf(&b, "  %s [label=%s shape=%s", quote(n.ID), quote(n.Name), shapes[n.Kind])
+		if n.Circular {
+			b.WriteString(" color=red")
+		}
+		b.WriteString("];\n")
+	}
+	for _, n := range g.Nodes {
+		for _, e := range n.Edges {
+			fmt.Fprintf(&b, "  %s -> %s [label=%s", quote(n.ID), quote(e.To), quote(e.Label))
+			if e.Circular {
+				b.WriteString(" color=red")
+			}
+			b.WriteString("];\n")
+		}
+	}
+	b.WriteString("}\n")
+	return []byte(b.String())
+}
+
+// RenderMermaid renders the graph as a Mermaid flowchart, circular references are drawn in red.
+func (g *ReferenceGraph) RenderMermaid() []byte {
+	var b strings.Builder
+	quote := func(s string) string {
+		return `"` + strings.ReplaceAll(s, `"`, "#quot;") + `"`
+	}
+	ids := make(map[string]string, len(g.Nodes))
+	var circularNodes []string
+	b.WriteString("flowchart LR\n")
+	for i, n := range g.Nodes {
+		ids[n.ID] = fmt.Sprintf("n%d", i)
+		fmt.Fprintf(&b, "  %s[%s]\n", ids[n.ID], quote(n.Name))
+		if n.Circular {
+			circularNodes = append(circularNodes, ids[n.ID])
+		}
+	}
+	var circularEdges []string
+	link := 0
+	for _, n := range g.Nodes {
+		for _, e := range n.Edges {
+			fmt.Fprintf(&b, "  %s -->|%s| %s\n", ids[n.ID], quote(e.Label), ids[e.To])
+			if e.Circular {
+				circularEdges = append(circularEdges, fmt.Sprint(link))
+			}
+			link++
+		}
+	}
+	if len(circularNodes) > 0 {
+		b.WriteString("  classDef circular stroke:#f00,stroke-width:2px\n")
+		fmt.Fprintf(&b, "  class %s circular\n", strings.Join(circularNodes, ","))
+	}
+	if len(circularEdges) > 0 {
+		fmt.Fprintf(&b, "  linkStyle %s stroke:#f00\n", strings.Join(circularEdges, ","))
+	}
+	return []byte(b.String())
+}
+
+// RenderJSON renders the graph as a JSON adjacency list, each node contains the edges that leave it.
+func (g *ReferenceGraph) RenderJSON() ([]byte, error) {
+	return json.MarshalIndent(g, "", "  ")
+}
@@ -0,0 +1,185 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+const graphSpec = `openapi: 3.1.0
+paths:
+  /pets:
+    parameters:
+      - $ref: '#/components/parameters/Limit'
+    get:
+      responses:
+        "200":
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+components:
+  parameters:
+    Limit:
+      name: limit
+      in: query
+  schemas:
+    Pet:
+      allOf:
+        - $ref: '#/components/schemas/Animal'
+        - $ref: 'models.yaml#/Owner'
+      properties:
+        friend:
+          $ref: '#/components/schemas/Animal'
+    Animal:
+      properties:
+        pet:
+          $ref: '#/components/schemas/Pet'`
+
+func graphIndex(t *testing.T, spec string) *SpecIndex {
+	var rootNode yaml.Node
+	assert.NoError(t, yaml.Unmarshal([]byte(spec), &rootNode))
+	return NewSpecIndexWithConfig(&rootNode, &SpecIndexConfig{AllowRemoteLookup: false, AllowFileLookup: false})
+}
+
+func graphCircular(idx *SpecIndex) {
+	pet := idx.GetMappedReferences()["#/components/schemas/Pet"]
+	animal := idx.GetMappedReferences()["#/components/schemas/Animal"]
+	idx.SetCircularReferences([]*CircularReferenceResult{{
+		Journey:   []*Reference{pet, animal, pet},
+		Start:     pet,
+		LoopPoint: pet,
+	}})
+}
+
+func TestSpecIndex_GetReferenceGraph(t *testing.T) {
+	idx := graphIndex(t, graphSpec)
+	graph := idx.GetReferenceGraph()
+
+	var ids []string
+	for _, n := range graph.Nodes {
+		ids = append(ids, n.ID)
+	}
+	assert.Equal(t, []string{"#/paths/~1pets", "#/components/parameters/Limit", "#/paths/~1pets/get",
+		"#/components/schemas/Pet", "#/components/schemas/Animal", "models.yaml"}, ids)
+
+	op := graph.Node("#/paths/~1pets/get")
+	assert.Equal(t, GraphNodeOperation, op.Kind)
+	assert.Equal(t, "GET /pets", op.Name)
+	assert.Len(t, op.Edges, 1)
+	assert.Equal(t, "responses.200.content.application/json.schema.items", op.Edges[0].Label)
+	assert.Equal(t, GraphNodePathItem, graph.Node("#/paths/~1pets").Kind)
+	assert.Equal(t, "parameters", graph.Node("#/paths/~1pets").Edges[0].Label)
+
+	pet := graph.Node("#/components/schemas/Pet")
+	assert.Equal(t, GraphNodeComponent, pet.Kind)
+	assert.Len(t, pet.Edges, 3)
+	assert.Equal(t, "allOf", pet.Edges[0].Label)
+	assert.Equal(t, "#/components/schemas/Animal", pet.Edges[0].To)
+	assert.Equal(t, "allOf", pet.Edges[1].Label)
+	assert.Equal(t, "models.yaml", pet.Edges[1].To)
+	assert.Equal(t, "models.yaml#/Owner", pet.Edges[1].Reference)
+	assert.Equal(t, "properties.friend", pet.Edges[2].Label)
+	assert.Equal(t, GraphNodeFile, graph.Node("models.yaml").Kind)
+	assert.False(t, pet.Circular)
+}
+
+func TestSpecIndex_GetReferenceGraph_Circular(t *testing.T) {
+	idx := graphIndex(t, graphSpec)
+	graphCircular(idx)
+	graph := idx.GetReferenceGraph()
+
+	pet := graph.Node("#/components/schemas/Pet")
+	animal := graph.Node("#/components/schemas/Animal")
+	assert.True(t, pet.Circular)
+	assert.True(t, animal.Circular)
+	assert.False(t, graph.Node("#/paths/~1pets/get").Circular)
+	assert.True(t, pet.Edges[0].Circular)
+	assert.False(t, pet.Edges[1].Circular)
+	assert.True(t, pet.Edges[2].Circular)
+	assert.True(t, animal.Edges[0].Circular)
+}
+
+func TestReferenceGraph_RenderDOT(t *testing.T) {
+	idx := graphIndex(t, graphSpec)
+	graphCircular(idx)
+	dot := string(idx.GetReferenceGraph().RenderDOT())
+
+	assert.True(t, strings.HasPrefix(dot, "digraph references {\n"))
+	assert.Contains(t, dot, `  "#/paths/~1pets/get" [label="GET /pets" shape=box];`)
+	assert.Contains(t, dot, `  "#/components/schemas/Pet" [label="Pet" shape=ellipse color=red];`)
+	assert.Contains(t, dot, `  "models.yaml" [label="models.yaml" shape=note];`)
+	assert.Contains(t, dot, `  "#/components/schemas/Pet" -> "models.yaml" [label="allOf"];`)
+	assert.Contains(t, dot, `  "#/components/schemas/Animal" -> "#/components/schemas/Pet" [label="properties.pet" color=red];`)
+}
+
+func TestReferenceGraph_RenderMermaid(t *testing.T) {
+	idx := graphIndex(t, graphSpec)
+	graphCircular(idx)
+	mermaid := string(idx.GetReferenceGraph().RenderMermaid())
+
+	assert.Equal(t, `flowchart LR
+  n0["/pets"]
+  n1["Limit"]
+  n2["GET /pets"]
+  n3["Pet"]
+  n4["Animal"]
+  n5["models.yaml"]
+  n0 -->|"parameters"| n1
+  n2 -->|"responses.200.content.application/json.schema.items"| n3
+  n3 -->|"allOf"| n4
+  n3 -->|"allOf"| n5
+  n3 -->|"properties.friend"| n4
+  n4 -->|"properties.pet"| n3
+  classDef circular stroke:#f00,stroke-width:2px
+  class n3,n4 circular
+  linkStyle 2,4,5 stroke:#f00
+`, mermaid)
+}
+
+func TestReferenceGraph_RenderJSON(t *testing.T) {
+	idx := graphIndex(t, graphSpec)
+	out, err := idx.GetReferenceGraph().RenderJSON()
+	assert.NoError(t, err)
+
+	var graph ReferenceGraph
+	assert.NoError(t, json.Unmarshal(out, &graph))
+	assert.Len(t, graph.Nodes, 6)
+	assert.Equal(t, "#/components/schemas/Pet", graph.Nodes[3].ID)
+	assert.Equal(t, "properties.friend", graph.Nodes[3].Edges[2].Label)
+	assert.Empty(t, graph.Nodes[1].Edges)
+}
+
+func TestSpecIndex_GetReferenceGraph_Swagger(t *testing.T) {
+	idx := graphIndex(t, `swagger: 2.0
+paths:
+  /pets:
+    post:
+      parameters:
+        - in: body
+          name: pet
+          schema:
+            $ref: '#/definitions/Pet'
+definitions:
+  Pet:
+    properties:
+      tags:
+        type: array
+        items:
+          $ref: '#/definitions/Tag'
+  Tag:
+    type: string`)
+	graph := idx.GetReferenceGraph()
+	assert.Len(t, graph.Nodes, 3)
+	assert.Equal(t, "parameters.schema", graph.Node("#/paths/~1pets/post").Edges[0].Label)
+	assert.Equal(t, "properties.tags.items", graph.Node("#/definitions/Pet").Edges[0].Label)
+	assert.Equal(t, "#/definitions/Tag", graph.Node("#/definitions/Pet").Edges[0].To)
+}
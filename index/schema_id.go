@@ -0,0 +1,181 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/vmware-labs/yaml-jsonpath/pkg/yamlpath"
+	"gopkg.in/yaml.v3"
+)
+
+// OpenAPI 3.1 schemas can use '$id' to set a new base URI for themselves and any child schemas, and
+// '$anchor' (or '$dynamicAnchor') to give a schema a plain name fragment. References can then use these
+// identifiers instead of a JSON pointer, for example '$ref: "#foo"' or '$ref: "https://example.com/schemas/pet"'.
+//
+// The index builds a registry of every identifier while scanning in ExtractRefs, and consults that registry
+// before falling back to a standard lookup in FindComponent.
+
+const (
+	schemaIdLabel            = "$id"
+	schemaAnchorLabel        = "$anchor"
+	schemaDynamicAnchorLabel = "$dynamicAnchor"
+)
+
+// ResolveSchemaURI will resolve a reference against a base URI, following RFC 3986. If there is no base URI,
+// the reference is returned untouched. Relative base URIs (such as 'schemas/pet.json') stay relative.
+func ResolveSchemaURI(base, ref string) string {
+	if base == "" {
+		return ref
+	}
+	b, err := url.Parse(base)
+	if err != nil {
+		return ref
+	}
+	r, err := url.Parse(ref)
+	if err != nil {
+		return ref
+	}
+	resolved := b.ResolveReference(r).String()
+
+	// url.ResolveReference roots relative paths, which would change a relative identifier.
+	if !b.IsAbs() && !strings.HasPrefix(base, "/") && !strings.HasPrefix(ref, "/") {
+		resolved = strings.TrimPrefix(resolved, "/")
+	}
+	return resolved
+}
+
+// GetSchemaIdRegistry returns a map of every '$id' and '$anchor' found in the specification, keyed by the fully
+// resolved URI. Anchors are keyed as '<base URI>#<anchor>'.
+func (index *SpecIndex) GetSchemaIdRegistry() map[string]*Reference {
+	return index.schemaIdRegistry
+}
+
+// FindSchemaById will locate a schema in the index using a URI built from a '$id' and / or an '$anchor'.
+// The URI can also include a JSON pointer fragment, which is evaluated relative to the schema that
+// declares the '$id'. Returns nil if nothing is found.
+func (index *SpecIndex) FindSchemaById(uri string) *Reference {
+	if len(index.schemaIdRegistry) == 0 {
+		return nil
+	}
+	if r := index.schemaIdRegistry[uri]; r != nil {
+		return r
+	}
+
+	// check for a JSON pointer, relative to a schema identified by an '$id'
+	segs := strings.SplitN(uri, "#", 2)
+	if len(segs) != 2 || !strings.HasPrefix(segs[1], "/") {
+		return nil
+	}
+	idRef := index.schemaIdRegistry[segs[0]]
+	if idRef == nil {
+		return nil
+	}
+	name, friendlySearch := utils.ConvertComponentIdIntoFriendlyPathSearch(fmt.Sprintf("#%s", segs[1]))
+	path, err := yamlpath.NewPath(friendlySearch)
+	if path == nil || err != nil {
+		return nil
+	}
+	res, _ := path.Find(idRef.Node)
+	if len(res) == 1 {
+		return &Reference{
+			Definition: uri,
+			Name:       name,
+			Node:       res[0],
+			Path:       fmt.Sprintf("%s%s", idRef.Path, strings.TrimPrefix(friendlySearch, "$")),
+		}
+	}
+	return nil
+}
+
+// GetReferenceKey returns the key a reference is mapped by (see GetMappedReferences). A reference made inside
+// an '$id' scope (3.1+) is mapped by the URI it resolves to, as the same relative reference (like '#name') can
+// point to a different schema in another scope. Any other reference is mapped by its definition.
+func (index *SpecIndex) GetReferenceKey(refNode *yaml.Node, definition string) string {
+	if index == nil || refNode == nil {
+		return definition
+	}
+	if base, ok := index.schemaIdRefScopes[refNode]; ok {
+		return ResolveSchemaURI(base, definition)
+	}
+	return definition
+}
+
+// findSchemaIdReference will resolve a reference against the base URI it was found in, and then look it up
+// in the '$id' / '$anchor' registry.
+func (index *SpecIndex) findSchemaIdReference(ref *Reference) *Reference {
+	found := index.FindSchemaById(ResolveSchemaURI(ref.BaseURI, ref.Definition))
+	if found == nil {
+		return nil
+	}
+	return &Reference{
+		Definition: ref.Definition,
+		Name:       found.Name,
+		Node:       found.Node,
+		Path:       found.Path,
+	}
+}
+
+// currentSchemaIdScope returns the base URI of the schema currently being scanned.
+func (index *SpecIndex) currentSchemaIdScope() string {
+	if len(index.schemaIdScope) > 0 {
+		return index.schemaIdScope[len(index.schemaIdScope)-1]
+	}
+	if index.config != nil && index.config.BaseURL != nil {
+		return index.config.BaseURL.String()
+	}
+	return ""
+}
+
+// registerSchemaIdentifiers will check a map node for '$id', '$anchor' and '$dynamicAnchor' values, and add them to
+// the registry. If an '$id' is found, a new base URI scope is pushed, and true is returned so the caller
+// knows to pop the scope once the node has been scanned.
+func (index *SpecIndex) registerSchemaIdentifiers(node *yaml.Node, seenPath []string) bool {
+	if !utils.IsNodeMap(node) {
+		return false
+	}
+	base := index.currentSchemaIdScope()
+	nodePath := fmt.Sprintf("$.%s", strings.Join(seenPath, "."))
+	pushed := false
+
+	_, idNode := utils.FindKeyNodeTop(schemaIdLabel, node.Content)
+	if idNode != nil && utils.IsNodeStringValue(idNode) && idNode.Value != "" {
+		base = strings.TrimSuffix(ResolveSchemaURI(base, idNode.Value), "#")
+		segs := strings.Split(base, "/")
+		index.schemaIdRegistry[base] = &Reference{
+			Definition: base,
+			Name:       segs[len(segs)-1],
+			Node:       node,
+			Path:       nodePath,
+		}
+		index.schemaIdScope = append(index.schemaIdScope, base)
+		pushed = true
+	}
+
+	for _, label := range []string{schemaAnchorLabel, schemaDynamicAnchorLabel} {
+		_, anchorNode := utils.FindKeyNodeTop(label, node.Content)
+		if anchorNode != nil && utils.IsNodeStringValue(anchorNode) && anchorNode.Value != "" {
+			uri := fmt.Sprintf("%s#%s", base, anchorNode.Value)
+			if index.schemaIdRegistry[uri] == nil {
+				index.schemaIdRegistry[uri] = &Reference{
+					Definition: uri,
+					Name:       anchorNode.Value,
+					Node:       node,
+					Path:       nodePath,
+				}
+			}
+		}
+	}
+	return pushed
+}
+
+// popSchemaIdScope will remove the last base URI scope pushed by registerSchemaIdentifiers
+func (index *SpecIndex) popSchemaIdScope() {
+	if len(index.schemaIdScope) > 0 {
+		index.schemaIdScope = index.schemaIdScope[:len(index.schemaIdScope)-1]
+	}
+}
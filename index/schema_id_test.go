@@ -0,0 +1,166 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestResolveSchemaURI(t *testing.T) {
+	assert.Equal(t, "#foo", ResolveSchemaURI("", "#foo"))
+	assert.Equal(t, "https://example.com/schemas/pet#foo", ResolveSchemaURI("https://example.com/schemas/pet", "#foo"))
+	assert.Equal(t, "https://example.com/schemas/owner", ResolveSchemaURI("https://example.com/schemas/pet", "owner"))
+	assert.Equal(t, "https://example.com/owner", ResolveSchemaURI("https://example.com/schemas/pet", "/owner"))
+	assert.Equal(t, "https://pb33f.io/pet", ResolveSchemaURI("https://example.com/schemas/pet", "https://pb33f.io/pet"))
+	assert.Equal(t, "schemas/owner.json", ResolveSchemaURI("schemas/pet.json", "owner.json"))
+	assert.Equal(t, "pet.json#foo", ResolveSchemaURI("pet.json", "#foo"))
+	assert.Equal(t, "/schemas/owner.json", ResolveSchemaURI("/schemas/pet.json", "owner.json"))
+}
+
+func TestSpecIndex_SchemaId_Anchor(t *testing.T) {
+	yml := `openapi: 3.1.0
+components:
+  schemas:
+    Pet:
+      $anchor: pet
+      type: object
+    Owner:
+      type: object
+      properties:
+        pet:
+          $ref: '#pet'`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &rootNode)
+
+	index := NewSpecIndexWithConfig(&rootNode, CreateClosedAPIIndexConfig())
+	assert.Len(t, index.GetReferenceIndexErrors(), 0)
+	assert.Len(t, index.GetSchemaIdRegistry(), 1)
+
+	ref := index.GetMappedReferences()["#pet"]
+	assert.NotNil(t, ref)
+	assert.Equal(t, "pet", ref.Name)
+	assert.Equal(t, 5, ref.Node.Line)
+	assert.Equal(t, ref.Node, index.FindComponent("#pet", nil).Node)
+}
+
+func TestSpecIndex_SchemaId_AbsoluteAndRelative(t *testing.T) {
+	yml := `openapi: 3.1.0
+components:
+  schemas:
+    Pet:
+      $id: https://example.com/schemas/pet
+      type: object
+      properties:
+        name:
+          $anchor: name
+          type: string
+        owner:
+          $ref: 'owner'
+    Owner:
+      $id: https://example.com/schemas/owner
+      type: object
+      properties:
+        pet:
+          $ref: 'https://example.com/schemas/pet'
+        petName:
+          $ref: 'pet#name'
+        petNamePointer:
+          $ref: 'pet#/properties/name'`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &rootNode)
+
+	// remote lookups are not allowed, so everything has to come from the registry.
+	index := NewSpecIndexWithConfig(&rootNode, CreateClosedAPIIndexConfig())
+	assert.Len(t, index.GetReferenceIndexErrors(), 0)
+	assert.Len(t, index.GetSchemaIdRegistry(), 3)
+	assert.NotNil(t, index.GetSchemaIdRegistry()["https://example.com/schemas/pet#name"])
+
+	// references made inside an '$id' scope are mapped by the URI they resolve to.
+	mapped := index.GetMappedReferences()
+	assert.Equal(t, 5, mapped["https://example.com/schemas/pet"].Node.Line)
+	assert.Equal(t, 14, mapped["https://example.com/schemas/owner"].Node.Line)
+	assert.Equal(t, 9, mapped["https://example.com/schemas/pet#name"].Node.Line)
+	assert.Equal(t, 9, mapped["https://example.com/schemas/pet#/properties/name"].Node.Line)
+	assert.Equal(t, "name", mapped["https://example.com/schemas/pet#/properties/name"].Name)
+	assert.Nil(t, mapped["pet#name"])
+
+	seq := index.GetAllSequencedReferences()
+	assert.Len(t, seq, 4)
+	assert.Equal(t, "https://example.com/schemas/pet", seq[0].BaseURI)
+	assert.Equal(t, "https://example.com/schemas/owner", seq[3].BaseURI)
+	assert.Nil(t, index.FindSchemaById("https://example.com/schemas/cat"))
+	assert.Nil(t, index.FindSchemaById("https://example.com/schemas/pet#/properties/age"))
+}
+
+func TestSpecIndex_SchemaId_BaseURL(t *testing.T) {
+	yml := `openapi: 3.1.0
+components:
+  schemas:
+    Pet:
+      $id: schemas/pet
+      type: object
+    Owner:
+      properties:
+        pet:
+          $ref: 'https://example.com/specs/schemas/pet'`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &rootNode)
+
+	c := CreateClosedAPIIndexConfig()
+	c.BaseURL, _ = url.Parse("https://example.com/specs/openapi.yaml")
+	index := NewSpecIndexWithConfig(&rootNode, c)
+	assert.Len(t, index.GetReferenceIndexErrors(), 0)
+	assert.NotNil(t, index.GetSchemaIdRegistry()["https://example.com/specs/schemas/pet"])
+	assert.Equal(t, 5, index.GetMappedReferences()["https://example.com/specs/schemas/pet"].Node.Line)
+}
+
+func TestSpecIndex_SchemaId_SameAnchorInTwoScopes(t *testing.T) {
+	yml := `openapi: 3.1.0
+components:
+  schemas:
+    Pet:
+      $id: https://example.com/schemas/pet
+      type: object
+      properties:
+        name:
+          $anchor: name
+          type: string
+        nickname:
+          $ref: '#name'
+    Car:
+      $id: https://example.com/schemas/car
+      type: object
+      properties:
+        name:
+          $anchor: name
+          type: integer
+        model:
+          $ref: '#name'`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &rootNode)
+
+	index := NewSpecIndexWithConfig(&rootNode, CreateClosedAPIIndexConfig())
+	assert.Len(t, index.GetReferenceIndexErrors(), 0)
+	assert.Len(t, index.GetAllReferences(), 2)
+
+	mapped := index.GetMappedReferences()
+	assert.Equal(t, 9, mapped["https://example.com/schemas/pet#name"].Node.Line)
+	assert.Equal(t, 18, mapped["https://example.com/schemas/car#name"].Node.Line)
+	assert.Nil(t, mapped["#name"])
+
+	// the reference is looked up in the scope of the schema that makes it.
+	seq := index.GetAllSequencedReferences()
+	assert.Equal(t, "https://example.com/schemas/car#name", index.GetReferenceKey(seq[1].Node, seq[1].Definition))
+	assert.Equal(t, 18, index.FindComponent("#name", seq[1].Node).Node.Line)
+	assert.Equal(t, 9, index.FindComponent("#name", seq[0].Node).Node.Line)
+	assert.Equal(t, "#name", index.GetReferenceKey(nil, "#name"))
+}
@@ -130,6 +130,11 @@ func (index *SpecIndex) GetGlobalTagsNode() *yaml.Node {
 	return index.tagsNode
 }
 
+// GetConfig returns the configuration the index was created with.
+func (index *SpecIndex) GetConfig() *SpecIndexConfig {
+	return index.config
+}
+
 // SetCircularReferences is a convenience method for the resolver to pass in circular references
 // if the resolver is used.
 func (index *SpecIndex) SetCircularReferences(refs []*CircularReferenceResult) {
@@ -0,0 +1,239 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// UnusedComponent is a component that cannot be reached from any operation or webhook.
+type UnusedComponent struct {
+	// Section is the type of component, like 'schemas' or 'parameters' (or 'definitions' for Swagger).
+	Section string
+
+	// Name is the name of the component.
+	Name string
+
+	// Definition is the reference that would be used for the component, like '#/components/schemas/Pet'.
+	Definition string
+
+	// KeyNode is the node that holds the name of the component.
+	KeyNode *yaml.Node
+
+	// Node is the component itself.
+	Node *yaml.Node
+}
+
+// unusedComponentSections are the sections of a specification that contain components, keyed by the path to
+// the section.
+var unusedComponentSections = [][]string{
+	{"components", "schemas"},
+	{"components", "responses"},
+	{"components", "parameters"},
+	{"components", "examples"},
+	{"components", "requestBodies"},
+	{"components", "headers"},
+	{"components", "securitySchemes"},
+	{"components", "links"},
+	{"components", "callbacks"},
+	{"components", "pathItems"},
+	{"definitions"},
+	{"parameters"},
+	{"responses"},
+	{"securityDefinitions"},
+}
+
+// GetUnusedComponents returns every component in the root document that is not used by any operation or
+// webhook, in the order they appear in the document. References are followed transitively, including through
+// external files, so a component only used by another unused component is unused as well. Security schemes are
+// used if they are named by a security requirement.
+func (index *SpecIndex) GetUnusedComponents() []*UnusedComponent {
+	root := index.GetRootNode()
+	if root != nil && root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	if root == nil || !utils.IsNodeMap(root) {
+		return nil
+	}
+
+	r := &reachability{
+		root:      index,
+		visited:   make(map[*yaml.Node]bool),
+		security:  make(map[string]bool),
+		elsewhere: make(map[string][][2]int),
+	}
+	for _, start := range []string{"paths", "webhooks"} {
+		if _, n := utils.FindKeyNodeTop(start, root.Content); n != nil {
+			r.walk(n, index)
+		}
+	}
+	if _, n := utils.FindKeyNodeTop("security", root.Content); n != nil {
+		r.requirements(n)
+	}
+
+	var unused []*UnusedComponent
+	for _, section := range unusedComponentSections {
+		n := root
+		for _, key := range section {
+			if _, n = utils.FindKeyNodeTop(key, n.Content); n == nil || !utils.IsNodeMap(n) {
+				break
+			}
+		}
+		if n == nil || !utils.IsNodeMap(n) {
+			continue
+		}
+		name := section[len(section)-1]
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			k, v := n.Content[i], n.Content[i+1]
+			definition := fmt.Sprintf("#/%s/%s", strings.Join(section, "/"), utils.EscapePointerSegment(k.Value))
+			if strings.HasPrefix(k.Value, "x-") || r.used(v) || r.usedElsewhere(definition) {
+				continue
+			}
+			if (name == "securitySchemes" || name == "securityDefinitions") && r.security[k.Value] {
+				continue
+			}
+			unused = append(unused, &UnusedComponent{
+				Section:    name,
+				Name:       k.Value,
+				Definition: definition,
+				KeyNode:    k,
+				Node:       v,
+			})
+		}
+	}
+	sort.SliceStable(unused, func(i, j int) bool {
+		if unused[i].KeyNode.Line != unused[j].KeyNode.Line {
+			return unused[i].KeyNode.Line < unused[j].KeyNode.Line
+		}
+		return unused[i].KeyNode.Column < unused[j].KeyNode.Column
+	})
+	return unused
+}
+
+// reachability records everything that can be reached by following references.
+type reachability struct {
+	// root is the index of the root document.
+	root *SpecIndex
+
+	// visited are the targets of references that have been followed.
+	visited map[*yaml.Node]bool
+
+	// security are the names of security schemes used by security requirements.
+	security map[string]bool
+
+	// elsewhere are the positions of targets found in other documents, keyed by the fragment of the
+	// reference. An external file that refers back to the root document gets its own copy of it, so these are
+	// matched to the root document by position.
+	elsewhere map[string][][2]int
+}
+
+// walk follows every reference under a node, idx is the index of the document the node belongs to.
+func (r *reachability) walk(node *yaml.Node, idx *SpecIndex) {
+	if node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+	switch node.Kind {
+	case yaml.SequenceNode:
+		for _, n := range node.Content {
+			r.walk(n, idx)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			k, v := node.Content[i], node.Content[i+1]
+			switch {
+			case k.Value == "$ref" && utils.IsNodeStringValue(v):
+				r.follow(v.Value, node, idx)
+				continue
+			case k.Value == "security" && utils.IsNodeArray(v):
+				r.requirements(v)
+				continue
+			case k.Value == "discriminator" && utils.IsNodeMap(v):
+				// discriminator mappings are references too.
+				if _, mapping := utils.FindKeyNodeTop("mapping", v.Content); mapping != nil {
+					for j := 1; j < len(mapping.Content); j += 2 {
+						r.follow(mapping.Content[j].Value, mapping, idx)
+					}
+				}
+			}
+			r.walk(v, idx)
+		}
+	}
+}
+
+// follow visits the target of a reference, if it has not been visited already.
+func (r *reachability) follow(ref string, node *yaml.Node, idx *SpecIndex) {
+	found := idx.FindComponent(ref, node)
+	if found == nil || found.Node == nil {
+		return
+	}
+	target := found.Node
+	if target.Kind == yaml.DocumentNode && len(target.Content) > 0 {
+		target = target.Content[0]
+	}
+	if r.visited[target] {
+		return
+	}
+	r.visited[target] = true
+
+	owner := idx
+	uri, fragment, _ := strings.Cut(ref, "#")
+	if uri != "" {
+		if external := idx.GetAllExternalIndexes()[uri]; external != nil {
+			owner = external
+		} else if seen := idx.SearchAncestryForSeenURI(uri); seen != nil {
+			owner = seen
+		}
+	}
+	if uri != "" || owner != r.root {
+		r.elsewhere["#"+fragment] = append(r.elsewhere["#"+fragment], [2]int{target.Line, target.Column})
+	}
+	r.walk(target, owner)
+}
+
+// requirements records the security schemes named by a list of security requirements.
+func (r *reachability) requirements(node *yaml.Node) {
+	for _, requirement := range node.Content {
+		for i := 0; i+1 < len(requirement.Content); i += 2 {
+			r.security[requirement.Content[i].Value] = true
+		}
+	}
+}
+
+// used returns true if a node, or anything inside it, is the target of a reference that has been followed.
+func (r *reachability) used(node *yaml.Node) bool {
+	if r.visited[node] {
+		return true
+	}
+	for _, n := range node.Content {
+		if r.used(n) {
+			return true
+		}
+	}
+	return false
+}
+
+// usedElsewhere returns true if a reference from another document points to the same place in a copy of the
+// root document as a definition (or something inside it).
+func (r *reachability) usedElsewhere(definition string) bool {
+	for fragment, positions := range r.elsewhere {
+		if fragment != definition && !strings.HasPrefix(fragment, definition+"/") {
+			continue
+		}
+		found := r.root.FindComponentInRoot(fragment)
+		if found == nil || found.Node == nil {
+			continue
+		}
+		for _, p := range positions {
+			if p[0] == found.Node.Line && p[1] == found.Node.Column {
+				return true
+			}
+		}
+	}
+	return false
+}
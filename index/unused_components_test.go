@@ -0,0 +1,175 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func unusedNames(unused []*UnusedComponent) []string {
+	var names []string
+	for _, u := range unused {
+		names = append(names, u.Definition)
+	}
+	return names
+}
+
+func TestSpecIndex_GetUnusedComponents(t *testing.T) {
+	spec := `openapi: 3.1.0
+security:
+  - apiKey: []
+paths:
+  /pets:
+    get:
+      security:
+        - oauth: [read]
+      parameters:
+        - $ref: '#/components/parameters/Limit'
+      responses:
+        "200":
+          $ref: '#/components/responses/Pets'
+webhooks:
+  newPet:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Animal/properties/name'
+components:
+  parameters:
+    Limit:
+      name: limit
+      in: query
+    Offset:
+      name: offset
+      in: query
+  responses:
+    Pets:
+      description: pets
+      content:
+        application/json:
+          schema:
+            $ref: '#/components/schemas/Pet'
+  schemas:
+    Pet:
+      oneOf:
+        - $ref: '#/components/schemas/Pet'
+      discriminator:
+        propertyName: kind
+        mapping:
+          dog: '#/components/schemas/Dog'
+      properties:
+        tag:
+          $ref: '#/components/schemas/Tag'
+    Tag:
+      type: string
+    Dog:
+      type: object
+    Animal:
+      properties:
+        name:
+          type: string
+    Orphan:
+      properties:
+        lonely:
+          $ref: '#/components/schemas/Lonely'
+    Lonely:
+      type: string
+    x-extension:
+      type: string
+  securitySchemes:
+    apiKey:
+      type: apiKey
+    oauth:
+      type: oauth2
+    basic:
+      type: http`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(spec), &rootNode)
+	idx := NewSpecIndexWithConfig(&rootNode, CreateOpenAPIIndexConfig())
+
+	unused := idx.GetUnusedComponents()
+	assert.Equal(t, []string{"#/components/parameters/Offset", "#/components/schemas/Orphan",
+		"#/components/schemas/Lonely", "#/components/securitySchemes/basic"}, unusedNames(unused))
+	assert.Equal(t, "schemas", unused[1].Section)
+	assert.Equal(t, "Orphan", unused[1].Name)
+	assert.Equal(t, 56, unused[1].KeyNode.Line)
+	assert.Equal(t, 5, unused[1].KeyNode.Column)
+	assert.Equal(t, 57, unused[1].Node.Line)
+}
+
+func TestSpecIndex_GetUnusedComponents_Swagger(t *testing.T) {
+	spec := `swagger: 2.0
+paths:
+  /pets:
+    get:
+      security:
+        - key: []
+      responses:
+        "200":
+          $ref: '#/responses/Pets'
+responses:
+  Pets:
+    description: pets
+    schema:
+      $ref: '#/definitions/Pet'
+definitions:
+  Pet:
+    type: object
+  Unused:
+    type: object
+securityDefinitions:
+  key:
+    type: apiKey
+  other:
+    type: basic`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(spec), &rootNode)
+	idx := NewSpecIndexWithConfig(&rootNode, CreateOpenAPIIndexConfig())
+	assert.Equal(t, []string{"#/definitions/Unused", "#/securityDefinitions/other"},
+		unusedNames(idx.GetUnusedComponents()))
+}
+
+func TestSpecIndex_GetUnusedComponents_ExternalFile(t *testing.T) {
+	dir := t.TempDir()
+	spec := `openapi: 3.0.3
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: pets
+          content:
+            application/json:
+              schema:
+                $ref: 'models.yaml#/Pet'
+components:
+  schemas:
+    Tag:
+      type: string
+    Unused:
+      type: string`
+	models := `Pet:
+  properties:
+    tag:
+      $ref: 'openapi.yaml#/components/schemas/Tag'`
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "openapi.yaml"), []byte(spec), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "models.yaml"), []byte(models), 0o644))
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(spec), &rootNode)
+	config := CreateClosedAPIIndexConfig()
+	config.AllowFileLookup = true
+	config.BasePath = dir
+	idx := NewSpecIndexWithConfig(&rootNode, config)
+	assert.Equal(t, []string{"#/components/schemas/Unused"}, unusedNames(idx.GetUnusedComponents()))
+}
@@ -0,0 +1,211 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+// Package overlay applies OpenAPI Overlay 1.0 documents to specifications.
+//
+// An overlay is a list of actions, each action selects nodes in a specification using a JSONPath target, and
+// then either merges an update into them, or removes them. See https://github.com/OAI/Overlay-Specification
+package overlay
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/pb33f/libopenapi"
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/vmware-labs/yaml-jsonpath/pkg/yamlpath"
+	"gopkg.in/yaml.v3"
+)
+
+// Overlay is an OpenAPI Overlay document.
+type Overlay struct {
+	// Overlay is the version of the overlay specification, only 1.x versions are supported.
+	Overlay string `yaml:"overlay"`
+
+	// Info describes the overlay.
+	Info Info `yaml:"info"`
+
+	// Extends is the location of the specification the overlay is meant for, it is informational only.
+	Extends string `yaml:"extends,omitempty"`
+
+	// Actions are applied in order.
+	Actions []*Action `yaml:"actions"`
+}
+
+// Info describes an overlay.
+type Info struct {
+	Title   string `yaml:"title"`
+	Version string `yaml:"version"`
+}
+
+// Action changes the nodes selected by a JSONPath target.
+type Action struct {
+	// Target is a JSONPath expression that selects the nodes to change.
+	Target string `yaml:"target"`
+
+	// Description describes the action.
+	Description string `yaml:"description,omitempty"`
+
+	// Update is merged into each target. Objects are merged recursively, arrays have the update appended to
+	// them, and anything else is replaced. The Kind of the node is zero if there is no update.
+	Update yaml.Node `yaml:"update,omitempty"`
+
+	// Remove removes each target from its parent, Update is ignored when Remove is set.
+	Remove bool `yaml:"remove,omitempty"`
+}
+
+// NewOverlay parses an overlay document, in YAML or JSON.
+func NewOverlay(overlayBytes []byte) (*Overlay, error) {
+	var o Overlay
+	if err := yaml.Unmarshal(overlayBytes, &o); err != nil {
+		return nil, fmt.Errorf("unable to parse overlay: %w", err)
+	}
+	if o.Overlay == "" {
+		return nil, errors.New("unable to parse overlay, the 'overlay' version is missing")
+	}
+	if !strings.HasPrefix(o.Overlay, "1.") {
+		return nil, fmt.Errorf("unable to parse overlay, version '%s' is not supported", o.Overlay)
+	}
+	if len(o.Actions) == 0 {
+		return nil, errors.New("unable to parse overlay, there are no actions")
+	}
+	for i, action := range o.Actions {
+		if action == nil || action.Target == "" {
+			return nil, fmt.Errorf("unable to parse overlay, action %d has no target", i)
+		}
+	}
+	return &o, nil
+}
+
+// ApplyToNode applies the actions of the overlay to a specification, in place. Actions with a target that does
+// not match anything are returned, an error is returned if a target is not a valid JSONPath expression, or if an
+// action tries to remove the root of the specification.
+func (o *Overlay) ApplyToNode(root *yaml.Node) ([]*Action, error) {
+	if root == nil {
+		return nil, errors.New("unable to apply overlay, no specification was supplied")
+	}
+	var unmatched []*Action
+	for _, action := range o.Actions {
+		path, err := yamlpath.NewPath(action.Target)
+		if err != nil {
+			return unmatched, fmt.Errorf("unable to apply overlay, target '%s' is not valid: %w", action.Target, err)
+		}
+		targets, _ := path.Find(root)
+		if len(targets) == 0 {
+			unmatched = append(unmatched, action)
+			continue
+		}
+		if action.Remove {
+			parents := make(map[*yaml.Node]*yaml.Node)
+			mapParents(root, parents)
+			for _, target := range targets {
+				if !remove(target, parents[target]) {
+					return unmatched, fmt.Errorf("unable to apply overlay, target '%s' cannot be removed",
+						action.Target)
+				}
+			}
+			continue
+		}
+		if action.Update.Kind == 0 {
+			continue
+		}
+		for _, target := range targets {
+			merge(target, &action.Update)
+		}
+	}
+	return unmatched, nil
+}
+
+// Apply applies an overlay to the specification of an OpenAPI 3 document, then builds the model and renders and
+// reloads it (see libopenapi.Document.RenderAndReload). The new specification, document and model are returned,
+// along with any actions whose target did not match anything.
+//
+// The specification of the document is changed in place, and any model that was already built is rebuilt from it
+// (see libopenapi.Document.Rebuild).
+func Apply(document libopenapi.Document, overlay *Overlay) ([]byte, libopenapi.Document,
+	*libopenapi.DocumentModel[v3high.Document], []*Action, []error) {
+	if document == nil || document.GetSpecInfo() == nil || document.GetSpecInfo().RootNode == nil {
+		return nil, nil, nil, nil, []error{errors.New("unable to apply overlay, no document was supplied")}
+	}
+	if overlay == nil {
+		return nil, nil, nil, nil, []error{errors.New("unable to apply overlay, no overlay was supplied")}
+	}
+	unmatched, err := overlay.ApplyToNode(document.GetSpecInfo().RootNode)
+	if err != nil {
+		return nil, nil, nil, unmatched, []error{err}
+	}
+	rebuildErrs := document.Rebuild()
+	if model, errs := document.BuildV3Model(); model == nil {
+		return nil, nil, nil, unmatched, append(rebuildErrs, errs...)
+	}
+	spec, newDoc, newModel, errs := document.RenderAndReload()
+	return spec, newDoc, newModel, unmatched, errs
+}
+
+// merge merges an update into a target node.
+func merge(target, update *yaml.Node) {
+	if target.Kind == yaml.DocumentNode && len(target.Content) > 0 {
+		target = target.Content[0]
+	}
+	if update.Kind == yaml.DocumentNode && len(update.Content) > 0 {
+		update = update.Content[0]
+	}
+	switch {
+	case target.Kind == yaml.MappingNode && update.Kind == yaml.MappingNode:
+		for i := 0; i+1 < len(update.Content); i += 2 {
+			key, value := update.Content[i], update.Content[i+1]
+			found := false
+			for j := 0; j+1 < len(target.Content); j += 2 {
+				if target.Content[j].Value == key.Value {
+					merge(target.Content[j+1], value)
+					found = true
+					break
+				}
+			}
+			if !found {
+				target.Content = append(target.Content, utils.CopyNode(key), utils.CopyNode(value))
+			}
+		}
+	case target.Kind == yaml.SequenceNode && update.Kind == yaml.SequenceNode:
+		for _, n := range update.Content {
+			target.Content = append(target.Content, utils.CopyNode(n))
+		}
+	case target.Kind == yaml.SequenceNode:
+		target.Content = append(target.Content, utils.CopyNode(update))
+	default:
+		*target = *utils.CopyNode(update)
+	}
+}
+
+// remove removes a node from its parent, it returns false if the node has no parent that it can be removed from.
+func remove(node, parent *yaml.Node) bool {
+	if parent == nil {
+		return false
+	}
+	for i, n := range parent.Content {
+		if n != node {
+			continue
+		}
+		switch parent.Kind {
+		case yaml.MappingNode:
+			if i%2 == 1 {
+				parent.Content = append(parent.Content[:i-1], parent.Content[i+1:]...)
+				return true
+			}
+		case yaml.SequenceNode:
+			parent.Content = append(parent.Content[:i], parent.Content[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// mapParents records the parent of every node under a node.
+func mapParents(node *yaml.Node, parents map[*yaml.Node]*yaml.Node) {
+	for _, n := range node.Content {
+		parents[n] = node
+		mapParents(n, parents)
+	}
+}
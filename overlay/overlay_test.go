@@ -0,0 +1,219 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package overlay
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+const petSpec = `openapi: 3.1.0
+info:
+  title: pets
+  version: 1.0.0
+tags:
+  - name: pets
+paths:
+  /pets:
+    get:
+      description: list pets
+      x-internal: true
+      responses:
+        "200":
+          description: pets
+    post:
+      description: add a pet
+      responses:
+        "201":
+          description: added`
+
+func TestNewOverlay(t *testing.T) {
+	o, err := NewOverlay([]byte(`overlay: 1.0.0
+info:
+  title: public docs
+  version: 1.2.3
+extends: https://pb33f.io/openapi.yaml
+actions:
+  - target: $.info
+    description: change the title
+    update:
+      title: public pets
+  - target: $.paths.*.*[?(@.x-internal)]
+    remove: true`))
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0.0", o.Overlay)
+	assert.Equal(t, "public docs", o.Info.Title)
+	assert.Equal(t, "1.2.3", o.Info.Version)
+	assert.Equal(t, "https://pb33f.io/openapi.yaml", o.Extends)
+	assert.Len(t, o.Actions, 2)
+	assert.Equal(t, "change the title", o.Actions[0].Description)
+	assert.Equal(t, yaml.MappingNode, o.Actions[0].Update.Kind)
+	assert.True(t, o.Actions[1].Remove)
+}
+
+func TestNewOverlay_Invalid(t *testing.T) {
+	_, err := NewOverlay([]byte(`overlay: [`))
+	assert.Error(t, err)
+
+	_, err = NewOverlay([]byte(`info:
+  title: nope`))
+	assert.Equal(t, "unable to parse overlay, the 'overlay' version is missing", err.Error())
+
+	_, err = NewOverlay([]byte(`overlay: 2.0.0`))
+	assert.Equal(t, "unable to parse overlay, version '2.0.0' is not supported", err.Error())
+
+	_, err = NewOverlay([]byte(`overlay: 1.0.0`))
+	assert.Equal(t, "unable to parse overlay, there are no actions", err.Error())
+
+	_, err = NewOverlay([]byte(`overlay: 1.0.0
+actions:
+  - description: no target`))
+	assert.Equal(t, "unable to parse overlay, action 0 has no target", err.Error())
+}
+
+func TestOverlay_ApplyToNode(t *testing.T) {
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte(petSpec), &root)
+
+	o, err := NewOverlay([]byte(`overlay: 1.0.0
+info:
+  title: public docs
+  version: 1.0.0
+actions:
+  - target: $.info
+    update:
+      title: public pets
+      contact:
+        name: pb33f
+  - target: $.tags
+    update:
+      name: public
+  - target: $.paths['/pets'].*[?(@.x-internal)]
+    remove: true
+  - target: $.paths.*.*.responses.*.description
+    update: updated
+  - target: $.webhooks
+    update:
+      nope: true`))
+	assert.NoError(t, err)
+
+	unmatched, err := o.ApplyToNode(&root)
+	assert.NoError(t, err)
+	assert.Len(t, unmatched, 1)
+	assert.Equal(t, "$.webhooks", unmatched[0].Target)
+
+	out, _ := yaml.Marshal(&root)
+	assert.Equal(t, `openapi: 3.1.0
+info:
+    title: public pets
+    version: 1.0.0
+    contact:
+        name: pb33f
+tags:
+    - name: pets
+    - name: public
+paths:
+    /pets:
+        post:
+            description: add a pet
+            responses:
+                "201":
+                    description: updated
+`, string(out))
+}
+
+func TestOverlay_ApplyToNode_Errors(t *testing.T) {
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte(petSpec), &root)
+
+	_, err := (&Overlay{Actions: []*Action{{Target: "$.paths[", Remove: true}}}).ApplyToNode(&root)
+	assert.Error(t, err)
+
+	_, err = (&Overlay{Actions: []*Action{{Target: "$", Remove: true}}}).ApplyToNode(&root)
+	assert.Equal(t, "unable to apply overlay, target '$' cannot be removed", err.Error())
+
+	_, err = (&Overlay{}).ApplyToNode(nil)
+	assert.Error(t, err)
+}
+
+func TestApply(t *testing.T) {
+	spec, _ := os.ReadFile("../test_specs/burgershop.openapi.yaml")
+	doc, err := libopenapi.NewDocument(spec)
+	assert.NoError(t, err)
+
+	o, err := NewOverlay([]byte(`overlay: 1.0.0
+info:
+  title: burgers
+  version: 1.0.0
+actions:
+  - target: $.info
+    update:
+      title: The Public Burger Shop
+  - target: $.paths['/burgers/{burgerId}/dressings']
+    remove: true
+  - target: $.components.schemas.Burger.properties
+    update:
+      vegan:
+        type: boolean
+  - target: $.paths['/missing']
+    remove: true`))
+	assert.NoError(t, err)
+
+	rendered, _, model, unmatched, errs := Apply(doc, o)
+	assert.Empty(t, errs)
+	assert.Len(t, unmatched, 1)
+	assert.Equal(t, "$.paths['/missing']", unmatched[0].Target)
+	assert.True(t, strings.Contains(string(rendered), "The Public Burger Shop"))
+
+	assert.Equal(t, "The Public Burger Shop", model.Model.Info.Title)
+	assert.Nil(t, model.Model.Paths.PathItems.GetOrZero("/burgers/{burgerId}/dressings"))
+	assert.Equal(t, 4, model.Model.Paths.PathItems.Len())
+	burger := model.Model.Components.Schemas.GetOrZero("Burger").Schema()
+	assert.Equal(t, []string{"boolean"}, burger.Properties.GetOrZero("vegan").Schema().Type)
+}
+
+func TestApply_ModelAlreadyBuilt(t *testing.T) {
+	spec, _ := os.ReadFile("../test_specs/burgershop.openapi.yaml")
+	doc, err := libopenapi.NewDocument(spec)
+	assert.NoError(t, err)
+
+	before, errs := doc.BuildV3Model()
+	assert.Empty(t, errs)
+	assert.NotEqual(t, "The Public Burger Shop", before.Model.Info.Title)
+
+	o, err := NewOverlay([]byte(`overlay: 1.0.0
+info:
+  title: burgers
+  version: 1.0.0
+actions:
+  - target: $.info
+    update:
+      title: The Public Burger Shop`))
+	assert.NoError(t, err)
+
+	rendered, newDoc, model, _, errs := Apply(doc, o)
+	assert.Empty(t, errs)
+	assert.True(t, strings.Contains(string(rendered), "The Public Burger Shop"))
+	assert.Equal(t, "The Public Burger Shop", model.Model.Info.Title)
+
+	after, _ := newDoc.BuildV3Model()
+	assert.Equal(t, "The Public Burger Shop", after.Model.Info.Title)
+}
+
+func TestApply_Invalid(t *testing.T) {
+	_, _, _, _, errs := Apply(nil, &Overlay{})
+	assert.Len(t, errs, 1)
+
+	doc, _ := libopenapi.NewDocument([]byte(petSpec))
+	_, _, _, _, errs = Apply(doc, nil)
+	assert.Len(t, errs, 1)
+
+	_, _, _, _, errs = Apply(doc, &Overlay{Actions: []*Action{{Target: "$", Remove: true}}})
+	assert.Len(t, errs, 1)
+}
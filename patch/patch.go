@@ -0,0 +1,416 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+// Package patch applies JSON Patch (RFC 6902) and JSON Merge Patch (RFC 7396) documents to a *yaml.Node tree.
+//
+// Nodes are changed in place, so comments and line positions of anything the patch does not touch are kept.
+package patch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// PatchError is returned when a patch cannot be applied. Nothing is changed when a JSON Patch fails.
+type PatchError struct {
+	// Operation is the position of the operation that failed in a JSON Patch, it is zero for a merge patch.
+	Operation int
+
+	// Op is the operation that failed, like 'add' or 'remove' ('merge' for a merge patch).
+	Op string
+
+	// Path is the JSON pointer that failed.
+	Path string
+
+	// Err describes why the operation failed.
+	Err error
+}
+
+func (e *PatchError) Error() string {
+	if e.Op == "merge" {
+		return fmt.Sprintf("unable to apply merge patch at '%s': %s", e.Path, e.Err.Error())
+	}
+	return fmt.Sprintf("unable to apply patch operation %d (%s) at '%s': %s", e.Operation, e.Op, e.Path,
+		e.Err.Error())
+}
+
+func (e *PatchError) Unwrap() error {
+	return e.Err
+}
+
+// operation is a single JSON Patch operation, Value has a Kind of zero if there is no value.
+type operation struct {
+	Op    string    `yaml:"op"`
+	Path  *string   `yaml:"path"`
+	From  *string   `yaml:"from"`
+	Value yaml.Node `yaml:"value"`
+}
+
+// ApplyJSONPatch applies a JSON Patch (RFC 6902) document to a tree of nodes. The operations are applied in order,
+// and either all of them are applied, or (if one fails) none of them are, and a *PatchError is returned.
+func ApplyJSONPatch(root *yaml.Node, patch []byte) error {
+	if root == nil {
+		return errors.New("unable to apply patch, there is nothing to patch")
+	}
+	var operations []operation
+	if err := yaml.Unmarshal(patch, &operations); err != nil {
+		return fmt.Errorf("unable to parse patch: %w", err)
+	}
+
+	// apply everything to a copy first, so nothing is changed if an operation fails.
+	if err := applyOperations(utils.CopyNode(root), operations); err != nil {
+		return err
+	}
+	return applyOperations(root, operations)
+}
+
+// ApplyMergePatch applies a JSON Merge Patch (RFC 7396) document to a tree of nodes. Objects in the patch are
+// merged into the tree, a null removes a property, and anything else replaces what is in the tree.
+func ApplyMergePatch(root *yaml.Node, patch []byte) error {
+	if root == nil {
+		return errors.New("unable to apply patch, there is nothing to patch")
+	}
+	var p yaml.Node
+	if err := yaml.Unmarshal(patch, &p); err != nil {
+		return fmt.Errorf("unable to parse patch: %w", err)
+	}
+	if len(p.Content) == 0 {
+		return &PatchError{Op: "merge", Err: errors.New("the patch is empty")}
+	}
+	target := root
+	if target.Kind == yaml.DocumentNode && len(target.Content) > 0 {
+		target = target.Content[0]
+	}
+	mergePatch(target, p.Content[0])
+	return nil
+}
+
+// mergePatch merges a patch into a target node.
+func mergePatch(target, patch *yaml.Node) {
+	if patch.Kind != yaml.MappingNode {
+		*target = *keepComments(target, utils.ResetNodeStyle(utils.CopyNode(patch)))
+		return
+	}
+	if target.Kind != yaml.MappingNode {
+		*target = yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	}
+	for i := 0; i+1 < len(patch.Content); i += 2 {
+		key, value := patch.Content[i], patch.Content[i+1]
+		index := -1
+		for j := 0; j+1 < len(target.Content); j += 2 {
+			if target.Content[j].Value == key.Value {
+				index = j
+				break
+			}
+		}
+		switch {
+		case isNull(value):
+			if index >= 0 {
+				target.Content = append(target.Content[:index], target.Content[index+2:]...)
+			}
+		case index >= 0:
+			mergePatch(target.Content[index+1], value)
+		default:
+			v := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			mergePatch(v, value)
+			target.Content = append(target.Content, utils.ResetNodeStyle(utils.CopyNode(key)), v)
+		}
+	}
+}
+
+// applyOperations applies JSON Patch operations to a tree of nodes, stopping at the first failure.
+func applyOperations(root *yaml.Node, operations []operation) error {
+	for i := range operations {
+		op := &operations[i]
+		path := ""
+		if op.Path != nil {
+			path = *op.Path
+		}
+		fail := func(err error) error {
+			return &PatchError{Operation: i, Op: op.Op, Path: path, Err: err}
+		}
+		if op.Path == nil {
+			return fail(errors.New("the operation has no 'path'"))
+		}
+		var err error
+		switch op.Op {
+		case "add", "replace", "test":
+			if op.Value.Kind == 0 {
+				return fail(errors.New("the operation has no 'value'"))
+			}
+			switch op.Op {
+			case "add":
+				err = add(root, path, utils.ResetNodeStyle(utils.CopyNode(&op.Value)))
+			case "replace":
+				err = replace(root, path, utils.ResetNodeStyle(utils.CopyNode(&op.Value)))
+			default:
+				err = test(root, path, &op.Value)
+			}
+		case "remove":
+			_, err = remove(root, path)
+		case "move", "copy":
+			if op.From == nil {
+				return fail(errors.New("the operation has no 'from'"))
+			}
+			if op.Op == "move" {
+				if *op.From != path && strings.HasPrefix(path, *op.From+"/") {
+					return fail(fmt.Errorf("cannot move '%s' into itself", *op.From))
+				}
+				var value *yaml.Node
+				if value, err = remove(root, *op.From); err != nil {
+					return &PatchError{Operation: i, Op: op.Op, Path: *op.From, Err: err}
+				}
+				err = add(root, path, value)
+			} else {
+				var value *yaml.Node
+				if value, err = find(root, *op.From); err != nil {
+					return &PatchError{Operation: i, Op: op.Op, Path: *op.From, Err: err}
+				}
+				err = add(root, path, utils.ResetNodeStyle(utils.CopyNode(value)))
+			}
+		default:
+			return fail(fmt.Errorf("'%s' is not a valid operation", op.Op))
+		}
+		if err != nil {
+			return fail(err)
+		}
+	}
+	return nil
+}
+
+// add adds a value at a JSON pointer, replacing a property, or inserting into an array.
+func add(root *yaml.Node, pointer string, value *yaml.Node) error {
+	parent, last, err := findParent(root, pointer)
+	if err != nil {
+		return err
+	}
+	if parent == nil {
+		replaceRoot(root, value)
+		return nil
+	}
+	switch parent.Kind {
+	case yaml.MappingNode:
+		if i := keyIndex(parent, last); i >= 0 {
+			parent.Content[i+1] = keepComments(parent.Content[i+1], value)
+			return nil
+		}
+		parent.Content = append(parent.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: last}, value)
+		return nil
+	case yaml.SequenceNode:
+		i := len(parent.Content)
+		if last != "-" {
+			if i, err = arrayIndex(last, len(parent.Content)); err != nil {
+				return err
+			}
+		}
+		parent.Content = append(parent.Content[:i], append([]*yaml.Node{value}, parent.Content[i:]...)...)
+		return nil
+	}
+	return fmt.Errorf("cannot add '%s' to a value that is not an object or an array", last)
+}
+
+// replace replaces the value at a JSON pointer, which must exist.
+func replace(root *yaml.Node, pointer string, value *yaml.Node) error {
+	parent, last, err := findParent(root, pointer)
+	if err != nil {
+		return err
+	}
+	if parent == nil {
+		replaceRoot(root, value)
+		return nil
+	}
+	switch parent.Kind {
+	case yaml.MappingNode:
+		if i := keyIndex(parent, last); i >= 0 {
+			parent.Content[i+1] = keepComments(parent.Content[i+1], value)
+			return nil
+		}
+		return fmt.Errorf("'%s' does not exist", last)
+	case yaml.SequenceNode:
+		i, err := arrayIndex(last, len(parent.Content)-1)
+		if err != nil {
+			return err
+		}
+		parent.Content[i] = keepComments(parent.Content[i], value)
+		return nil
+	}
+	return fmt.Errorf("'%s' does not exist", last)
+}
+
+// remove removes the value at a JSON pointer, which must exist, and returns it.
+func remove(root *yaml.Node, pointer string) (*yaml.Node, error) {
+	parent, last, err := findParent(root, pointer)
+	if err != nil {
+		return nil, err
+	}
+	if parent == nil {
+		return nil, errors.New("the whole document cannot be removed")
+	}
+	switch parent.Kind {
+	case yaml.MappingNode:
+		if i := keyIndex(parent, last); i >= 0 {
+			value := parent.Content[i+1]
+			parent.Content = append(parent.Content[:i], parent.Content[i+2:]...)
+			return value, nil
+		}
+	case yaml.SequenceNode:
+		i, err := arrayIndex(last, len(parent.Content)-1)
+		if err != nil {
+			return nil, err
+		}
+		value := parent.Content[i]
+		parent.Content = append(parent.Content[:i], parent.Content[i+1:]...)
+		return value, nil
+	}
+	return nil, fmt.Errorf("'%s' does not exist", last)
+}
+
+// test checks the value at a JSON pointer is equal to a value.
+func test(root *yaml.Node, pointer string, value *yaml.Node) error {
+	found, err := find(root, pointer)
+	if err != nil {
+		return err
+	}
+	expected, err := jsonValue(value)
+	if err != nil {
+		return err
+	}
+	actual, err := jsonValue(found)
+	if err != nil {
+		return err
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		return errors.New("the value does not match")
+	}
+	return nil
+}
+
+// jsonValue decodes a node into the value JSON would decode it to, so every number is a float64 and numbers are
+// equal when their values are equal (RFC 6902, section 4.6).
+func jsonValue(node *yaml.Node) (any, error) {
+	var decoded any
+	if err := node.Decode(&decoded); err != nil {
+		return nil, err
+	}
+	b, err := json.Marshal(decoded)
+	if err != nil {
+		return nil, err
+	}
+	var value any
+	err = json.Unmarshal(b, &value)
+	return value, err
+}
+
+// find returns the value at a JSON pointer.
+func find(root *yaml.Node, pointer string) (*yaml.Node, error) {
+	parent, last, err := findParent(root, pointer)
+	if err != nil {
+		return nil, err
+	}
+	if parent == nil {
+		return documentContent(root), nil
+	}
+	return child(parent, last)
+}
+
+// findParent returns the parent of the value at a JSON pointer, and the last token of the pointer. The parent
+// is nil if the pointer is for the whole document.
+func findParent(root *yaml.Node, pointer string) (*yaml.Node, string, error) {
+	if pointer == "" {
+		return nil, "", nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, "", errors.New("a JSON pointer must start with '/'")
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i := range tokens {
+		tokens[i] = utils.UnescapePointerSegment(tokens[i])
+	}
+	node := documentContent(root)
+	for _, token := range tokens[:len(tokens)-1] {
+		var err error
+		if node, err = child(node, token); err != nil {
+			return nil, "", err
+		}
+	}
+	return node, tokens[len(tokens)-1], nil
+}
+
+// child returns a property of an object, or an item in an array.
+func child(node *yaml.Node, token string) (*yaml.Node, error) {
+	if node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+	switch node.Kind {
+	case yaml.MappingNode:
+		if i := keyIndex(node, token); i >= 0 {
+			return node.Content[i+1], nil
+		}
+	case yaml.SequenceNode:
+		i, err := arrayIndex(token, len(node.Content)-1)
+		if err != nil {
+			return nil, err
+		}
+		return node.Content[i], nil
+	}
+	return nil, fmt.Errorf("'%s' does not exist", token)
+}
+
+// keyIndex returns the position of a key in a mapping node, or -1 if the key does not exist.
+func keyIndex(node *yaml.Node, key string) int {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// arrayIndex parses an array index from a JSON pointer token, it must be between zero and max.
+func arrayIndex(token string, max int) (int, error) {
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("'%s' is not a valid array index", token)
+	}
+	if i > max {
+		return 0, fmt.Errorf("array index %d is out of bounds", i)
+	}
+	return i, nil
+}
+
+// documentContent returns the content of a document node, or the node itself.
+func documentContent(node *yaml.Node) *yaml.Node {
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		return node.Content[0]
+	}
+	return node
+}
+
+// replaceRoot replaces the whole document with a value.
+func replaceRoot(root, value *yaml.Node) {
+	if root.Kind == yaml.DocumentNode {
+		root.Content = []*yaml.Node{value}
+		return
+	}
+	*root = *value
+}
+
+// keepComments moves the comments of a node that is being replaced to its replacement, if it has none.
+func keepComments(old, value *yaml.Node) *yaml.Node {
+	if value.HeadComment == "" && value.LineComment == "" && value.FootComment == "" {
+		value.HeadComment, value.LineComment, value.FootComment = old.HeadComment, old.LineComment, old.FootComment
+	}
+	return value
+}
+
+// isNull returns true if a node is a null.
+func isNull(node *yaml.Node) bool {
+	return node.Kind == yaml.ScalarNode && node.Tag == "!!null"
+}
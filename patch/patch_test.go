@@ -0,0 +1,179 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package patch
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+const petSpec = `openapi: 3.1.0
+info:
+  title: pets # the title
+  version: 1.0.0
+tags:
+  - name: pets
+  - name: admin
+paths:
+  /pets:
+    get:
+      description: list pets`
+
+func parse(t *testing.T, spec string) *yaml.Node {
+	var root yaml.Node
+	assert.NoError(t, yaml.Unmarshal([]byte(spec), &root))
+	return &root
+}
+
+func render(root *yaml.Node) string {
+	out, _ := yaml.Marshal(root)
+	return string(out)
+}
+
+func TestApplyJSONPatch(t *testing.T) {
+	root := parse(t, petSpec)
+	paths := root.Content[0].Content[7]
+
+	err := ApplyJSONPatch(root, []byte(`[
+  {"op": "test", "path": "/info/version", "value": "1.0.0"},
+  {"op": "replace", "path": "/info/version", "value": "1.1.0"},
+  {"op": "add", "path": "/info/description", "value": "all the pets"},
+  {"op": "add", "path": "/tags/1", "value": {"name": "dogs"}},
+  {"op": "add", "path": "/tags/-", "value": {"name": "cats"}},
+  {"op": "remove", "path": "/tags/0"},
+  {"op": "copy", "from": "/paths/~1pets", "path": "/paths/~1dogs"},
+  {"op": "move", "from": "/paths/~1dogs/get/description", "path": "/paths/~1dogs/get/summary"},
+  {"op": "test", "path": "/tags", "value": [{"name": "dogs"}, {"name": "admin"}, {"name": "cats"}]}
+]`))
+	assert.NoError(t, err)
+	assert.Equal(t, `openapi: 3.1.0
+info:
+    title: pets # the title
+    version: 1.1.0
+    description: all the pets
+tags:
+    - name: dogs
+    - name: admin
+    - name: cats
+paths:
+    /pets:
+        get:
+            description: list pets
+    /dogs:
+        get:
+            summary: list pets
+`, render(root))
+
+	// untouched nodes keep their positions.
+	assert.Equal(t, paths, root.Content[0].Content[7])
+	assert.Equal(t, 9, paths.Line)
+}
+
+func TestApplyJSONPatch_Failure(t *testing.T) {
+	root := parse(t, petSpec)
+	before := render(root)
+
+	err := ApplyJSONPatch(root, []byte(`[
+  {"op": "replace", "path": "/info/version", "value": "2.0.0"},
+  {"op": "remove", "path": "/paths/~1pets/post"}
+]`))
+	var patchError *PatchError
+	assert.True(t, errors.As(err, &patchError))
+	assert.Equal(t, 1, patchError.Operation)
+	assert.Equal(t, "remove", patchError.Op)
+	assert.Equal(t, "/paths/~1pets/post", patchError.Path)
+	assert.Equal(t, "unable to apply patch operation 1 (remove) at '/paths/~1pets/post': 'post' does not exist",
+		err.Error())
+
+	// nothing was changed.
+	assert.Equal(t, before, render(root))
+}
+
+func TestApplyJSONPatch_Errors(t *testing.T) {
+	tests := []struct {
+		patch, message string
+	}{
+		{`[{"op": "add", "value": 1}]`, "unable to apply patch operation 0 (add) at '': the operation has no 'path'"},
+		{`[{"op": "add", "path": "/info/x"}]`, "unable to apply patch operation 0 (add) at '/info/x': the operation has no 'value'"},
+		{`[{"op": "move", "path": "/info/x"}]`, "unable to apply patch operation 0 (move) at '/info/x': the operation has no 'from'"},
+		{`[{"op": "nope", "path": "/info"}]`, "unable to apply patch operation 0 (nope) at '/info': 'nope' is not a valid operation"},
+		{`[{"op": "test", "path": "/info/title", "value": "cats"}]`, "unable to apply patch operation 0 (test) at '/info/title': the value does not match"},
+		{`[{"op": "add", "path": "/tags/5", "value": 1}]`, "unable to apply patch operation 0 (add) at '/tags/5': array index 5 is out of bounds"},
+		{`[{"op": "replace", "path": "/tags/01", "value": 1}]`, "unable to apply patch operation 0 (replace) at '/tags/01': '01' is not a valid array index"},
+		{`[{"op": "add", "path": "info/x", "value": 1}]`, "unable to apply patch operation 0 (add) at 'info/x': a JSON pointer must start with '/'"},
+		{`[{"op": "add", "path": "/nope/x", "value": 1}]`, "unable to apply patch operation 0 (add) at '/nope/x': 'nope' does not exist"},
+		{`[{"op": "add", "path": "/openapi/x", "value": 1}]`, "unable to apply patch operation 0 (add) at '/openapi/x': cannot add 'x' to a value that is not an object or an array"},
+		{`[{"op": "remove", "path": ""}]`, "unable to apply patch operation 0 (remove) at '': the whole document cannot be removed"},
+		{`[{"op": "move", "from": "/paths", "path": "/paths/x"}]`, "unable to apply patch operation 0 (move) at '/paths/x': cannot move '/paths' into itself"},
+		{`[{"op": "copy", "from": "/nope", "path": "/x"}]`, "unable to apply patch operation 0 (copy) at '/nope': 'nope' does not exist"},
+	}
+	for _, tc := range tests {
+		err := ApplyJSONPatch(parse(t, petSpec), []byte(tc.patch))
+		if assert.Error(t, err, tc.patch) {
+			assert.Equal(t, tc.message, err.Error())
+		}
+	}
+
+	assert.Error(t, ApplyJSONPatch(nil, []byte(`[]`)))
+	assert.Error(t, ApplyJSONPatch(parse(t, petSpec), []byte(`{"op": "add"}`)))
+}
+
+func TestApplyJSONPatch_TestNumbers(t *testing.T) {
+	root := parse(t, `limits:
+  max: 1
+  sizes: [1, 2.5]`)
+
+	// numbers are equal when their values are, no matter how they are written.
+	assert.NoError(t, ApplyJSONPatch(root, []byte(`[
+  {"op": "test", "path": "/limits/max", "value": 1.0},
+  {"op": "test", "path": "/limits", "value": {"max": 1e0, "sizes": [1.00, 2.5]}}
+]`)))
+	assert.Error(t, ApplyJSONPatch(root, []byte(`[{"op": "test", "path": "/limits/max", "value": 1.5}]`)))
+	assert.Error(t, ApplyJSONPatch(root, []byte(`[{"op": "test", "path": "/limits/max", "value": "1"}]`)))
+}
+
+func TestApplyJSONPatch_ReplaceDocument(t *testing.T) {
+	root := parse(t, petSpec)
+	assert.NoError(t, ApplyJSONPatch(root, []byte(`[{"op": "replace", "path": "", "value": {"openapi": "3.0.3"}}]`)))
+	assert.Equal(t, "openapi: 3.0.3\n", render(root))
+}
+
+func TestApplyMergePatch(t *testing.T) {
+	root := parse(t, petSpec)
+	paths := root.Content[0].Content[7]
+
+	err := ApplyMergePatch(root, []byte(`{
+  "info": {"version": "2.0.0", "contact": {"name": "pb33f", "email": null}},
+  "tags": [{"name": "cats"}],
+  "paths": {"/pets": {"get": {"description": null, "summary": "pets"}}}
+}`))
+	assert.NoError(t, err)
+	assert.Equal(t, `openapi: 3.1.0
+info:
+    title: pets # the title
+    version: 2.0.0
+    contact:
+        name: pb33f
+tags:
+    - name: cats
+paths:
+    /pets:
+        get:
+            summary: pets
+`, render(root))
+	assert.Equal(t, paths, root.Content[0].Content[7])
+
+	assert.NoError(t, ApplyMergePatch(root, []byte(`"replaced"`)))
+	assert.Equal(t, "replaced\n", render(root))
+}
+
+func TestApplyMergePatch_Errors(t *testing.T) {
+	assert.Error(t, ApplyMergePatch(nil, []byte(`{}`)))
+	assert.Error(t, ApplyMergePatch(parse(t, petSpec), []byte(`{`)))
+	assert.Equal(t, "unable to apply merge patch at '': the patch is empty",
+		ApplyMergePatch(parse(t, petSpec), []byte(``)).Error())
+}
@@ -0,0 +1,164 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package resolver
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// CircularReferencePolicy decides what Dereference does when it finds a circular reference.
+type CircularReferencePolicy int
+
+const (
+	// CircularReferenceKeep leaves circular references as a $ref, this is the default.
+	CircularReferenceKeep CircularReferencePolicy = iota
+
+	// CircularReferenceCut inlines circular references until they have been followed DereferenceConfig.MaxDepth
+	// times on a single journey, after that the reference is cut, and replaced with an empty object.
+	CircularReferenceCut
+
+	// CircularReferenceError leaves circular references as a $ref, and returns a *ResolvingError for each one.
+	CircularReferenceError
+)
+
+// DereferenceConfig configures how Dereference handles circular references.
+type DereferenceConfig struct {
+	// CircularReferences is the policy for circular references, defaults to CircularReferenceKeep.
+	CircularReferences CircularReferencePolicy
+
+	// MaxDepth is the number of times a circular reference is followed before it is cut, when the policy
+	// is CircularReferenceCut. Zero cuts the reference the first time it loops.
+	MaxDepth int
+}
+
+// Dereference builds a new copy of the specification, with every reference replaced by what it points to.
+// Unlike Resolve, it is not destructive, the tree held by the index is not touched, and it is safe to use on
+// specifications with circular references (see DereferenceConfig). A nil config uses the defaults.
+//
+// It works for any index, use Document.Index for OpenAPI 3 models, and Swagger.GoLow().Index for Swagger models.
+// References to other files are resolved using the child indexes for those files, but references that are
+// kept (because they are circular, or cannot be found) are left exactly as they were written.
+func (resolver *Resolver) Dereference(config *DereferenceConfig) (*yaml.Node, []*ResolvingError) {
+	if config == nil {
+		config = &DereferenceConfig{}
+	}
+	d := &dereferencer{config: config}
+	root := resolver.specIndex.GetRootNode()
+	if root == nil {
+		return nil, nil
+	}
+	return d.copy(root, resolver.specIndex, nil), d.errors
+}
+
+// dereferencer creates dereferenced copies of nodes.
+type dereferencer struct {
+	config *DereferenceConfig
+	errors []*ResolvingError
+}
+
+// journeyStep is a reference that is being followed.
+type journeyStep struct {
+	ref    string
+	target *yaml.Node
+}
+
+// copy creates a dereferenced copy of a node, idx is the index of the document the node belongs to, and
+// journey contains the references being followed to get here.
+func (d *dereferencer) copy(node *yaml.Node, idx *index.SpecIndex, journey []journeyStep) *yaml.Node {
+	if node.Kind == yaml.AliasNode && node.Alias != nil {
+		return d.copy(node.Alias, idx, journey)
+	}
+	if utils.IsNodeMap(node) {
+		if isRef, _, ref := utils.IsNodeRefValue(node); isRef {
+			return d.reference(node, ref, idx, journey)
+		}
+	}
+	c := *node
+	c.Content = make([]*yaml.Node, len(node.Content))
+	for i := range node.Content {
+		c.Content[i] = d.copy(node.Content[i], idx, journey)
+	}
+	return &c
+}
+
+// reference replaces a reference with a dereferenced copy of what it points to. Any siblings of the
+// reference (like a description) are kept, if the target does not already have them.
+func (d *dereferencer) reference(node *yaml.Node, ref string, idx *index.SpecIndex,
+	journey []journeyStep) *yaml.Node {
+	found := idx.FindComponent(ref, node)
+	if found == nil || found.Node == nil {
+		d.errors = append(d.errors, &ResolvingError{
+			ErrorRef: fmt.Errorf("cannot resolve reference `%s`, it's missing", ref),
+			Node:     node,
+			Path:     journeyPath(journey, ref),
+		})
+		return utils.CopyNode(node)
+	}
+	target := found.Node
+	if target.Kind == yaml.DocumentNode && len(target.Content) > 0 {
+		target = target.Content[0]
+	}
+
+	seen := 0
+	for _, step := range journey {
+		if step.target == target {
+			seen++
+		}
+	}
+	if seen > 0 {
+		switch d.config.CircularReferences {
+		case CircularReferenceCut:
+			if seen > d.config.MaxDepth {
+				return utils.CreateEmptyMapNode()
+			}
+		case CircularReferenceError:
+			d.errors = append(d.errors, &ResolvingError{
+				ErrorRef: fmt.Errorf("circular reference detected: %s", ref),
+				Node:     node,
+				Path:     journeyPath(journey, ref),
+			})
+			return utils.CopyNode(node)
+		default:
+			return utils.CopyNode(node)
+		}
+	}
+
+	owner := idx
+	if uri := strings.Split(ref, "#")[0]; uri != "" {
+		if external := idx.GetAllExternalIndexes()[uri]; external != nil {
+			owner = external
+		} else if seenIndex := idx.SearchAncestryForSeenURI(uri); seenIndex != nil {
+			owner = seenIndex
+		}
+	}
+
+	resolved := d.copy(target, owner, append(journey[:len(journey):len(journey)], journeyStep{ref, target}))
+	if utils.IsNodeMap(resolved) {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i].Value
+			if key == "$ref" {
+				continue
+			}
+			if _, v := utils.FindKeyNodeTop(key, resolved.Content); v == nil {
+				resolved.Content = append(resolved.Content, utils.CopyNode(node.Content[i]),
+					d.copy(node.Content[i+1], idx, journey))
+			}
+		}
+	}
+	return resolved
+}
+
+// journeyPath describes the references followed to get to a reference.
+func journeyPath(journey []journeyStep, ref string) string {
+	steps := make([]string, 0, len(journey)+1)
+	for _, step := range journey {
+		steps = append(steps, step.ref)
+	}
+	return strings.Join(append(steps, ref), " -> ")
+}
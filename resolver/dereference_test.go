@@ -0,0 +1,96 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package resolver
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/pb33f/libopenapi/index"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func dereferenceSpec(t *testing.T, file string, config *DereferenceConfig) (string, string, []*ResolvingError) {
+	spec, err := os.ReadFile(file)
+	assert.NoError(t, err)
+	var rootNode yaml.Node
+	assert.NoError(t, yaml.Unmarshal(spec, &rootNode))
+	before, _ := yaml.Marshal(&rootNode)
+
+	idx := index.NewSpecIndex(&rootNode)
+	dereferenced, errs := NewResolver(idx).Dereference(config)
+	out, err := yaml.Marshal(dereferenced)
+	assert.NoError(t, err)
+
+	// the original tree is untouched.
+	after, _ := yaml.Marshal(&rootNode)
+	assert.Equal(t, string(before), string(after))
+	return string(before), string(out), errs
+}
+
+func TestResolver_Dereference(t *testing.T) {
+	_, out, errs := dereferenceSpec(t, "../test_specs/burgershop.openapi.yaml", nil)
+	assert.Empty(t, errs)
+	assert.NotContains(t, out, "$ref")
+
+	var spec map[string]any
+	assert.NoError(t, yaml.Unmarshal([]byte(out), &spec))
+	schema := spec["paths"].(map[string]any)["/burgers"].(map[string]any)["post"].(map[string]any)["requestBody"].(map[string]any)["content"].(map[string]any)["application/json"].(map[string]any)["schema"].(map[string]any)
+	assert.Contains(t, schema["properties"], "numPatties")
+}
+
+func TestResolver_Dereference_CircularKeep(t *testing.T) {
+	_, out, errs := dereferenceSpec(t, "../test_specs/circular-tests.yaml", nil)
+	assert.Empty(t, errs)
+	assert.Contains(t, out, `"$ref": "#/components/schemas/One"`)
+}
+
+func TestResolver_Dereference_CircularError(t *testing.T) {
+	_, _, errs := dereferenceSpec(t, "../test_specs/circular-tests.yaml",
+		&DereferenceConfig{CircularReferences: CircularReferenceError})
+	assert.NotEmpty(t, errs)
+	for _, err := range errs {
+		assert.True(t, strings.HasPrefix(err.Error(), "circular reference detected: #/components/schemas/"))
+	}
+	assert.Equal(t, "#/components/schemas/Two -> #/components/schemas/One -> #/components/schemas/Two",
+		errs[0].Path)
+}
+
+func TestResolver_Dereference_CircularCut(t *testing.T) {
+	_, shallow, errs := dereferenceSpec(t, "../test_specs/circular-tests.yaml",
+		&DereferenceConfig{CircularReferences: CircularReferenceCut})
+	assert.Empty(t, errs)
+	assert.NotContains(t, shallow, "$ref")
+
+	_, deep, errs := dereferenceSpec(t, "../test_specs/circular-tests.yaml",
+		&DereferenceConfig{CircularReferences: CircularReferenceCut, MaxDepth: 2})
+	assert.Empty(t, errs)
+	assert.NotContains(t, deep, "$ref")
+	assert.Greater(t, len(deep), len(shallow))
+}
+
+func TestResolver_Dereference_Swagger(t *testing.T) {
+	_, out, errs := dereferenceSpec(t, "../test_specs/swagger-circular-tests.yaml",
+		&DereferenceConfig{CircularReferences: CircularReferenceCut, MaxDepth: 1})
+	assert.Empty(t, errs)
+	assert.NotContains(t, out, "$ref")
+}
+
+func TestResolver_Dereference_Missing(t *testing.T) {
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(`openapi: 3.0.3
+components:
+  schemas:
+    Pet:
+      $ref: '#/components/schemas/Missing'`), &rootNode)
+
+	out, errs := NewResolver(index.NewSpecIndex(&rootNode)).Dereference(nil)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "cannot resolve reference `#/components/schemas/Missing`, it's missing: "+
+		"#/components/schemas/Missing [5:7]", errs[0].Error())
+	b, _ := yaml.Marshal(out)
+	assert.Contains(t, string(b), "$ref: '#/components/schemas/Missing'")
+}
@@ -211,7 +211,7 @@ func visitIndex(res *Resolver, idx *index.SpecIndex) {
 
 	// map everything
 	for _, sequenced := range idx.GetAllSequencedReferences() {
-		locatedDef := mappedIndex[sequenced.Definition]
+		locatedDef := mappedIndex[idx.GetReferenceKey(sequenced.Node, sequenced.Definition)]
 		if locatedDef != nil {
 			if !locatedDef.Circular && locatedDef.Seen {
 				sequenced.Node.Content = locatedDef.Node.Content
@@ -344,7 +344,9 @@ func (resolver *Resolver) extractRelatives(node *yaml.Node,
 
 				value := node.Content[i+1].Value
 
-				ref := resolver.specIndex.SearchIndexForReference(value)
+				// a reference made inside an '$id' scope (3.1+) is mapped by the URI it resolves to.
+				key := resolver.specIndex.GetReferenceKey(node, value)
+				ref := resolver.specIndex.SearchIndexForReference(key)
 
 				if ref == nil {
 					_, path := utils.ConvertComponentIdIntoFriendlyPathSearch(value)
@@ -358,14 +360,14 @@ func (resolver *Resolver) extractRelatives(node *yaml.Node,
 				}
 
 				r := &index.Reference{
-					Definition: value,
+					Definition: key,
 					Name:       value,
 					Node:       node,
 				}
 
 				found = append(found, r)
 
-				foundRelatives[value] = true
+				foundRelatives[key] = true
 			}
 
 			if i%2 == 0 && n.Value != "$ref" && n.Value != "" {
@@ -380,7 +382,7 @@ func (resolver *Resolver) extractRelatives(node *yaml.Node,
 						if _, v := utils.FindKeyNodeTop("items", node.Content[i+1].Content); v != nil {
 							if utils.IsNodeMap(v) {
 								if d, _, l := utils.IsNodeRefValue(v); d {
-									ref := resolver.specIndex.GetMappedReferences()[l]
+									ref := resolver.specIndex.GetMappedReferences()[resolver.specIndex.GetReferenceKey(v, l)]
 									if ref != nil && !ref.Circular {
 										circ := false
 										for f := range journey {
@@ -417,7 +419,7 @@ func (resolver *Resolver) extractRelatives(node *yaml.Node,
 							v := node.Content[i+1].Content[q]
 							if utils.IsNodeMap(v) {
 								if d, _, l := utils.IsNodeRefValue(v); d {
-									ref := resolver.specIndex.GetMappedReferences()[l]
+									ref := resolver.specIndex.GetMappedReferences()[resolver.specIndex.GetReferenceKey(v, l)]
 									if ref != nil && !ref.Circular {
 										circ := false
 										for f := range journey {
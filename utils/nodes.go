@@ -66,3 +66,35 @@ func CreateFloatNode(str string) *yaml.Node {
     }
     return n
 }
+
+// CopyNode creates a deep copy of a node. Aliases are replaced with a copy of the node they point to, and the
+// styles of the nodes are kept. A nil node returns nil.
+func CopyNode(node *yaml.Node) *yaml.Node {
+    if node == nil {
+        return nil
+    }
+    if node.Kind == yaml.AliasNode && node.Alias != nil {
+        return CopyNode(node.Alias)
+    }
+    c := *node
+    c.Content = make([]*yaml.Node, len(node.Content))
+    for i := range node.Content {
+        c.Content[i] = CopyNode(node.Content[i])
+    }
+    return &c
+}
+
+// ResetNodeStyle resets the style of a node and everything under it, so it renders the same way no matter if it
+// was read from JSON or YAML. Literal and folded scalars keep their style. The node is returned.
+func ResetNodeStyle(node *yaml.Node) *yaml.Node {
+    if node == nil {
+        return nil
+    }
+    if node.Kind != yaml.ScalarNode || node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) == 0 {
+        node.Style = 0
+    }
+    for _, n := range node.Content {
+        ResetNodeStyle(n)
+    }
+    return node
+}
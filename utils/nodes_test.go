@@ -5,6 +5,7 @@ package utils
 
 import (
     "github.com/stretchr/testify/assert"
+    "gopkg.in/yaml.v3"
     "testing"
 )
 
@@ -47,3 +48,35 @@ func TestCreateRefNode(t *testing.T) {
     assert.Equal(t, "!!str", r.Content[1].Tag)
     assert.Equal(t, "#/components/schemas/MySchema", r.Content[1].Value)
 }
+
+func TestCopyNode(t *testing.T) {
+    var root yaml.Node
+    _ = yaml.Unmarshal([]byte(`pet: &pet
+  name: "fluffy"
+copy: *pet`), &root)
+
+    c := CopyNode(&root)
+    assert.Equal(t, yaml.DoubleQuotedStyle, c.Content[0].Content[1].Content[1].Style)
+
+    // aliases are copied as the node they point to.
+    copied := c.Content[0].Content[3]
+    assert.Equal(t, yaml.MappingNode, copied.Kind)
+    assert.Equal(t, "fluffy", copied.Content[1].Value)
+
+    copied.Content[1].Value = "spot"
+    assert.Equal(t, "fluffy", root.Content[0].Content[1].Content[1].Value)
+    assert.Nil(t, CopyNode(nil))
+}
+
+func TestResetNodeStyle(t *testing.T) {
+    var root yaml.Node
+    _ = yaml.Unmarshal([]byte(`{"name": "fluffy", "description": "a pet"}`), &root)
+    root.Content[0].Content[3].Style = yaml.LiteralStyle
+
+    n := ResetNodeStyle(CopyNode(&root))
+    assert.Equal(t, yaml.Style(0), n.Content[0].Style)
+    assert.Equal(t, yaml.Style(0), n.Content[0].Content[1].Style)
+    assert.Equal(t, yaml.LiteralStyle, n.Content[0].Content[3].Style)
+    assert.Equal(t, yaml.DoubleQuotedStyle, root.Content[0].Content[1].Style)
+    assert.Nil(t, ResetNodeStyle(nil))
+}
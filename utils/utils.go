@@ -561,6 +561,16 @@ func ConvertComponentIdIntoPath(id string) (string, string) {
 		strings.Join(segs[:len(segs)-1], "."), name), "#", "$")
 }
 
+// EscapePointerSegment escapes a segment of a JSON pointer (RFC 6901), '~' becomes '~0' and '/' becomes '~1'.
+func EscapePointerSegment(segment string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(segment, "~", "~0"), "/", "~1")
+}
+
+// UnescapePointerSegment reverses EscapePointerSegment.
+func UnescapePointerSegment(segment string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(segment, "~1", "/"), "~0", "~")
+}
+
 func RenderCodeSnippet(startNode *yaml.Node, specData []string, before, after int) string {
 
 	buf := new(strings.Builder)
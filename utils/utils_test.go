@@ -780,3 +780,9 @@ func TestCheckEnumForDuplicates_FailMultiple(t *testing.T) {
     yaml.Unmarshal([]byte(yml), &rootNode)
     assert.Len(t, CheckEnumForDuplicates(rootNode.Content[0].Content), 3)
 }
+
+func TestEscapePointerSegment(t *testing.T) {
+	assert.Equal(t, "~1pets~1{id}~0", EscapePointerSegment("/pets/{id}~"))
+	assert.Equal(t, "/pets/{id}~", UnescapePointerSegment("~1pets~1{id}~0"))
+	assert.Equal(t, "~01", UnescapePointerSegment(EscapePointerSegment("~01")))
+}
@@ -0,0 +1,87 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package validator
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Validation types, used to group a ValidationError by the part of the request or response that failed.
+const (
+	PathValidation        = "path"
+	ParameterValidation   = "parameter"
+	RequestBodyValidation = "requestBody"
+	ResponseValidation    = "response"
+)
+
+// ValidationError represents a single problem found when validating a request or a response against an
+// OpenAPI document.
+type ValidationError struct {
+	// Message is a short, readable summary of the failure.
+	Message string `json:"message"`
+
+	// Reason is a longer explanation of why the validation failed.
+	Reason string `json:"reason"`
+
+	// ValidationType is the part of the request or response that failed (path, parameter, requestBody or response).
+	ValidationType string `json:"validationType"`
+
+	// ValidationSubType adds detail to the ValidationType, for example the 'in' value of a parameter (path, query,
+	// header or cookie), or 'header' / 'body' for a response.
+	ValidationSubType string `json:"validationSubType,omitempty"`
+
+	// SpecLine and SpecCol are the position of the object in the specification that the request or response
+	// was validated against. They are zero if there is nothing in the specification to point to.
+	SpecLine int `json:"specLine"`
+	SpecCol  int `json:"specColumn"`
+
+	// SchemaFailures holds every schema violation, when a value failed to validate against a schema.
+	SchemaFailures []*SchemaValidationFailure `json:"schemaFailures,omitempty"`
+}
+
+// Error returns a readable version of the ValidationError.
+func (v *ValidationError) Error() string {
+	if v.SpecLine > 0 {
+		return fmt.Sprintf("%s: %s (line %d, column %d)", v.Message, v.Reason, v.SpecLine, v.SpecCol)
+	}
+	return fmt.Sprintf("%s: %s", v.Message, v.Reason)
+}
+
+// SchemaValidationFailure represents a single violation of a schema by a value.
+type SchemaValidationFailure struct {
+	// Reason explains what is wrong with the value.
+	Reason string `json:"reason"`
+
+	// Location is a JSON pointer to the failing value inside the validated instance. An empty Location means
+	// the root of the instance.
+	Location string `json:"location"`
+
+	// SchemaLocation is a JSON pointer to the failing keyword, starting from the schema being validated against,
+	// for example '/properties/name/minLength'.
+	SchemaLocation string `json:"schemaLocation"`
+
+	// Line and Column are the position of the failing keyword in the specification, they are zero if the schema
+	// was not built from a specification.
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// Error returns a readable version of the SchemaValidationFailure.
+func (s *SchemaValidationFailure) Error() string {
+	location := s.Location
+	if location == "" {
+		location = "/"
+	}
+	return fmt.Sprintf("%s: %s", location, s.Reason)
+}
+
+// nodePosition returns the line and column of a node, or zeros if the node is nil.
+func nodePosition(node *yaml.Node) (int, int) {
+	if node == nil {
+		return 0, 0
+	}
+	return node.Line, node.Column
+}
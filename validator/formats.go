@@ -0,0 +1,97 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package validator
+
+import (
+	"encoding/base64"
+	"math"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	uuidRegex        = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	hostnameRegex    = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(\.[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$`)
+	jsonPointerRegex = regexp.MustCompile(`^(/([^~/]|~[01])*)*$`)
+)
+
+// stringFormats contains a check for every string format the validator understands. Formats that are not listed
+// are treated as annotations, and are not validated.
+var stringFormats = map[string]func(string) bool{
+	"date-time": func(s string) bool {
+		_, err := time.Parse(time.RFC3339Nano, s)
+		return err == nil
+	},
+	"date": func(s string) bool {
+		_, err := time.Parse("2006-01-02", s)
+		return err == nil
+	},
+	"time": func(s string) bool {
+		_, err := time.Parse(time.RFC3339Nano, "1970-01-01T"+s)
+		return err == nil
+	},
+	"email": func(s string) bool {
+		addr, err := mail.ParseAddress(s)
+		return err == nil && addr.Address == s
+	},
+	"hostname": func(s string) bool {
+		return len(s) <= 253 && hostnameRegex.MatchString(s)
+	},
+	"ipv4": func(s string) bool {
+		ip := net.ParseIP(s)
+		return ip != nil && ip.To4() != nil && !strings.Contains(s, ":")
+	},
+	"ipv6": func(s string) bool {
+		return net.ParseIP(s) != nil && strings.Contains(s, ":")
+	},
+	"uri": func(s string) bool {
+		u, err := url.Parse(s)
+		return err == nil && u.Scheme != ""
+	},
+	"uri-reference": func(s string) bool {
+		_, err := url.Parse(s)
+		return err == nil
+	},
+	"uuid": uuidRegex.MatchString,
+	"regex": func(s string) bool {
+		_, err := regexp.Compile(s)
+		return err == nil
+	},
+	"json-pointer": jsonPointerRegex.MatchString,
+	"byte": func(s string) bool {
+		_, err := base64.StdEncoding.DecodeString(s)
+		return err == nil
+	},
+}
+
+// checkFormat validates a value against a format. Formats only apply to the types they were designed for, so
+// a value of any other type (or an unknown format) always passes.
+func checkFormat(format string, value any) bool {
+	switch v := value.(type) {
+	case string:
+		if check, ok := stringFormats[format]; ok {
+			return check(v)
+		}
+		return true
+	case bool, nil, []any, map[string]any:
+		return true
+	}
+	n, ok := toFloat(value)
+	if !ok {
+		return true
+	}
+	switch format {
+	case "int32":
+		return n == math.Trunc(n) && n >= math.MinInt32 && n <= math.MaxInt32
+	case "int64":
+		return n == math.Trunc(n) && n >= math.MinInt64 && n <= math.MaxInt64
+	case "float":
+		return math.Abs(n) <= math.MaxFloat32
+	}
+	return true
+}
@@ -0,0 +1,60 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package validator
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckFormat(t *testing.T) {
+	valid := map[string]any{
+		"date-time":     "2023-03-14T15:09:26.535+01:00",
+		"date":          "2023-03-14",
+		"time":          "15:09:26Z",
+		"email":         "dave@pb33f.io",
+		"hostname":      "api.pb33f.io",
+		"ipv4":          "10.0.0.1",
+		"ipv6":          "::1",
+		"uri":           "https://pb33f.io/libopenapi",
+		"uri-reference": "../pets?limit=1",
+		"uuid":          "0c2fb1de-32c3-4d86-bd38-4d9b1b4fdf7a",
+		"regex":         "^[a-z]+$",
+		"json-pointer":  "/paths/~1pets",
+		"byte":          "cGl6emE=",
+		"int32":         json.Number("2147483647"),
+		"int64":         int64(9007199254740991),
+		"float":         1.5,
+		"unknown":       "anything",
+	}
+	for format, value := range valid {
+		assert.True(t, checkFormat(format, value), format)
+	}
+
+	invalid := map[string]any{
+		"date-time":    "2023-03-14 15:09",
+		"date":         "14/03/2023",
+		"time":         "25:00:00Z",
+		"email":        "dave",
+		"hostname":     "-pb33f.io",
+		"ipv4":         "::1",
+		"ipv6":         "10.0.0.1",
+		"uri":          "pets",
+		"uuid":         "0c2fb1de",
+		"regex":        "[a-z",
+		"json-pointer": "paths",
+		"byte":         "%%%",
+		"int32":        json.Number("1.5"),
+	}
+	for format, value := range invalid {
+		assert.False(t, checkFormat(format, value), format)
+	}
+
+	// formats only apply to the types they were designed for.
+	assert.True(t, checkFormat("uuid", 1))
+	assert.True(t, checkFormat("int32", "pizza"))
+	assert.True(t, checkFormat("date", nil))
+}
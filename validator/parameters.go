@@ -0,0 +1,279 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+// Parameter locations, as defined by the 'in' property of a parameter.
+const (
+	paramInPath   = "path"
+	paramInQuery  = "query"
+	paramInHeader = "header"
+	paramInCookie = "cookie"
+)
+
+// validateParameters validates every parameter defined by a path item and operation against a request.
+// Parameters defined by the operation override those defined by the path item (matched by name and location).
+func validateParameters(request *http.Request, pathItem *v3.PathItem, operation *v3.Operation,
+	pathTemplate *compiledTemplate, path string) []*ValidationError {
+
+	pathValues, _ := pathTemplate.match(path)
+	query := request.URL.Query()
+
+	var errs []*ValidationError
+	for _, param := range mergeParameters(pathItem.Parameters, operation.Parameters) {
+		var (
+			values []string
+			found  bool
+		)
+		in := strings.ToLower(param.In)
+		switch in {
+		case paramInPath:
+			var v string
+			v, found = pathValues[param.Name]
+			values = []string{v}
+		case paramInQuery:
+			if param.Style == "deepObject" {
+				values, found = deepObjectValues(param.Name, query)
+			} else {
+				values, found = query[param.Name]
+			}
+		case paramInHeader:
+			values = request.Header.Values(param.Name)
+			found = len(values) > 0
+		case paramInCookie:
+			if c, err := request.Cookie(param.Name); err == nil {
+				values, found = []string{c.Value}, true
+			}
+		default:
+			continue
+		}
+
+		if !found {
+			if param.Required {
+				errs = append(errs, parameterError(param, in,
+					fmt.Sprintf("%s parameter '%s' is missing", in, param.Name),
+					fmt.Sprintf("the %s parameter '%s' is required, but was not found in the request",
+						in, param.Name), nil))
+			}
+			continue
+		}
+		if len(values) == 1 && values[0] == "" && in == paramInQuery && param.AllowEmptyValue {
+			continue
+		}
+
+		schema, value, err := parameterValue(param, in, values)
+		if err != nil {
+			errs = append(errs, parameterError(param, in,
+				fmt.Sprintf("%s parameter '%s' cannot be decoded", in, param.Name), err.Error(), nil))
+			continue
+		}
+		if failures := validateSchema(schema, value); len(failures) > 0 {
+			errs = append(errs, parameterError(param, in,
+				fmt.Sprintf("%s parameter '%s' failed to validate", in, param.Name),
+				fmt.Sprintf("the %s parameter '%s' does not match the schema: %s", in, param.Name,
+					failures[0].Reason), failures))
+		}
+	}
+	return errs
+}
+
+// mergeParameters combines path item and operation parameters, operation parameters win.
+func mergeParameters(pathParams, opParams []*v3.Parameter) []*v3.Parameter {
+	merged := make([]*v3.Parameter, 0, len(pathParams)+len(opParams))
+	for _, pp := range pathParams {
+		overridden := false
+		for _, op := range opParams {
+			if op.Name == pp.Name && strings.EqualFold(op.In, pp.In) {
+				overridden = true
+				break
+			}
+		}
+		if !overridden {
+			merged = append(merged, pp)
+		}
+	}
+	return append(merged, opParams...)
+}
+
+// parameterValue decodes the raw values of a parameter into a JSON value, using the schema (or content) of the
+// parameter to decide what the value should look like. in is the lower case location of the parameter.
+func parameterValue(param *v3.Parameter, in string, values []string) (*base.SchemaProxy, any, error) {
+	if param.Schema == nil {
+		// parameters can use content instead of a schema, only JSON content can be decoded.
+		for _, mediaType := range param.Content.Keys() {
+			mt := param.Content.GetOrZero(mediaType)
+			if !isJSONMediaType(mediaType) || mt.Schema == nil {
+				continue
+			}
+			var value any
+			if err := decodeJSON([]byte(values[0]), &value); err != nil {
+				return nil, nil, fmt.Errorf("the value is not valid JSON: %s", err.Error())
+			}
+			return mt.Schema, value, nil
+		}
+		return nil, nil, nil
+	}
+
+	schema := param.Schema.Schema()
+	if schema == nil {
+		return param.Schema, values[0], nil
+	}
+	style := param.Style
+	if style == "" {
+		style = "form"
+		if in == paramInPath || in == paramInHeader {
+			style = "simple"
+		}
+	}
+	explode := style == "form"
+	if param.Explode != nil {
+		explode = *param.Explode
+	}
+
+	raw := values[0]
+	switch style {
+	case "label":
+		raw = strings.TrimPrefix(raw, ".")
+	case "matrix":
+		raw = strings.TrimPrefix(raw, ";")
+		raw = strings.TrimPrefix(raw, param.Name+"=")
+	}
+
+	separator := ","
+	switch style {
+	case "spaceDelimited":
+		separator = " "
+	case "pipeDelimited":
+		separator = "|"
+	case "label":
+		if explode {
+			separator = "."
+		}
+	case "matrix":
+		if explode {
+			separator = ";" + param.Name + "="
+		}
+	}
+
+	switch {
+	case hasType(schema, "array"):
+		var items []string
+		if style == "form" && explode {
+			items = values
+		} else {
+			items = strings.Split(raw, separator)
+		}
+		var itemSchema *base.Schema
+		if schema.Items != nil && schema.Items.IsA() && schema.Items.A != nil {
+			itemSchema = schema.Items.A.Schema()
+		}
+		arr := make([]any, len(items))
+		for i := range items {
+			arr[i] = coerceValue(itemSchema, items[i])
+		}
+		return param.Schema, arr, nil
+
+	case hasType(schema, "object"):
+		obj := make(map[string]any)
+		if style == "deepObject" {
+			for _, v := range values {
+				if k, val, ok := strings.Cut(v, "="); ok {
+					obj[k] = coerceProperty(schema, k, val)
+				}
+			}
+			return param.Schema, obj, nil
+		}
+		parts := strings.Split(raw, separator)
+		if explode {
+			for _, p := range parts {
+				if k, val, ok := strings.Cut(p, "="); ok {
+					obj[k] = coerceProperty(schema, k, val)
+				}
+			}
+		} else {
+			for i := 0; i+1 < len(parts); i += 2 {
+				obj[parts[i]] = coerceProperty(schema, parts[i], parts[i+1])
+			}
+		}
+		return param.Schema, obj, nil
+	}
+	return param.Schema, coerceValue(schema, raw), nil
+}
+
+// deepObjectValues collects every 'name[key]=value' query parameter for a deepObject parameter, values are
+// returned as 'key=value' strings.
+func deepObjectValues(name string, query map[string][]string) ([]string, bool) {
+	var values []string
+	for k, v := range query {
+		if strings.HasPrefix(k, name+"[") && strings.HasSuffix(k, "]") && len(v) > 0 {
+			values = append(values, fmt.Sprintf("%s=%s", k[len(name)+1:len(k)-1], v[0]))
+		}
+	}
+	return values, len(values) > 0
+}
+
+// coerceProperty coerces the value of an object property using the schema for the property.
+func coerceProperty(schema *base.Schema, name, value string) any {
+	if prop, ok := schema.Properties.Get(name); ok && prop != nil {
+		return coerceValue(prop.Schema(), value)
+	}
+	return value
+}
+
+// coerceValue converts a raw string into the type required by a schema. If the value cannot be converted, it is
+// returned as a string, so the schema reports a type failure.
+func coerceValue(schema *base.Schema, value string) any {
+	if schema == nil {
+		return value
+	}
+	switch {
+	case hasType(schema, "integer"), hasType(schema, "number"):
+		if _, err := strconv.ParseFloat(value, 64); err == nil {
+			return json.Number(value)
+		}
+	case hasType(schema, "boolean"):
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	case hasType(schema, "null"):
+		if value == "" || value == "null" {
+			return nil
+		}
+	}
+	return value
+}
+
+func hasType(schema *base.Schema, t string) bool {
+	for _, st := range schema.Type {
+		if st == t {
+			return true
+		}
+	}
+	return false
+}
+
+func parameterError(param *v3.Parameter, in, message, reason string,
+	failures []*SchemaValidationFailure) *ValidationError {
+	vErr := &ValidationError{
+		Message:           message,
+		Reason:            reason,
+		ValidationType:    ParameterValidation,
+		ValidationSubType: in,
+		SchemaFailures:    failures,
+	}
+	if param.GoLow() != nil {
+		vErr.SpecLine, vErr.SpecCol = nodePosition(param.GoLow().Name.KeyNode)
+	}
+	return vErr
+}
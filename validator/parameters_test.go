@@ -0,0 +1,132 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package validator
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var parameterSpec = `openapi: 3.0.3
+info:
+  title: params
+  version: 1.0.0
+paths:
+  /search/{ids}/{label}:
+    parameters:
+      - name: ids
+        in: path
+        required: true
+        schema:
+          type: array
+          items:
+            type: integer
+      - name: label
+        in: path
+        required: true
+        style: label
+        explode: true
+        schema:
+          type: array
+          items:
+            type: string
+            enum: [a, b]
+    get:
+      parameters:
+        - name: filter
+          in: query
+          style: deepObject
+          schema:
+            type: object
+            properties:
+              min:
+                type: integer
+              active:
+                type: boolean
+        - name: colors
+          in: query
+          style: pipeDelimited
+          explode: false
+          schema:
+            type: array
+            maxItems: 2
+        - name: point
+          in: query
+          explode: false
+          schema:
+            type: object
+            required: [x]
+            properties:
+              x:
+                type: number
+        - name: json
+          in: query
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [q]
+        - name: empty
+          in: query
+          allowEmptyValue: true
+          schema:
+            type: integer
+        - name: X-Nullable
+          in: header
+          schema:
+            type: integer
+            nullable: true
+      responses:
+        '200':
+          description: ok`
+
+func TestValidateParameters_Styles(t *testing.T) {
+	v := newTestValidator(t, parameterSpec)
+
+	req := httptest.NewRequest(http.MethodGet,
+		"/search/1,2,3/.a.b?filter[min]=3&filter[active]=true&colors=red|blue&point=x,1.5"+
+			"&json=%7B%22q%22%3A1%7D&empty=", nil)
+	assert.Empty(t, v.ValidateRequest(req))
+}
+
+func TestValidateParameters_Failures(t *testing.T) {
+	v := newTestValidator(t, parameterSpec)
+
+	req := httptest.NewRequest(http.MethodGet,
+		"/search/1,x/.a.c?filter[min]=low&colors=red|blue|green&point=y,1&json=%7B%7D", nil)
+	errs := v.ValidateRequest(req)
+	assert.Len(t, errs, 6)
+
+	var reasons []string
+	for _, e := range errs {
+		reasons = append(reasons, e.SchemaFailures[0].Error())
+	}
+	assert.Equal(t, []string{
+		"/1: expected integer, but got string",
+		"/1: value must be one of [\"a\", \"b\"]",
+		"/min: expected integer, but got string",
+		"/: must have at most 2 items, but got 3",
+		"/: missing required property 'x'",
+		"/: missing required property 'q'",
+	}, reasons)
+
+	req = httptest.NewRequest(http.MethodGet, "/search/1/.a?json=nope", nil)
+	errs = v.ValidateRequest(req)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "query parameter 'json' cannot be decoded", errs[0].Message)
+}
+
+func TestValidateParameters_Nullable(t *testing.T) {
+	v := newTestValidator(t, parameterSpec)
+
+	req := httptest.NewRequest(http.MethodGet, "/search/1/.a", nil)
+	req.Header.Set("X-Nullable", "nope")
+	errs := v.ValidateRequest(req)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "header", errs[0].ValidationSubType)
+	assert.Equal(t, "expected integer, but got string", errs[0].SchemaFailures[0].Reason)
+}
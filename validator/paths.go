@@ -0,0 +1,213 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package validator
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"gopkg.in/yaml.v3"
+)
+
+// templateParam matches a single path template parameter, like '{petId}'.
+var templateParam = regexp.MustCompile(`{([^{}]+)}`)
+
+// findOperation locates the PathItem and Operation that a request should be validated against, along with
+// the path template that matched.
+func (v *Validator) findOperation(request *http.Request) (*v3.PathItem, *v3.Operation, string, []*ValidationError) {
+	if v.document == nil || v.document.Paths == nil || v.document.Paths.PathItems.Len() == 0 {
+		return nil, nil, "", []*ValidationError{{
+			Message:        "no paths to validate against",
+			Reason:         "the document does not contain any paths",
+			ValidationType: PathValidation,
+		}}
+	}
+
+	var (
+		found, matched            *v3.PathItem
+		operation                 *v3.Operation
+		template, matchedTemplate string
+		bestCount, matchedCount   int
+	)
+	method := strings.ToLower(request.Method)
+	for _, key := range v.document.Paths.PathItems.Keys() {
+		pathItem := v.document.Paths.PathItems.GetOrZero(key)
+		compiled := v.pathTemplate(key)
+		if _, ok := compiled.match(v.stripBasePath(request.URL.EscapedPath(), pathItem)); !ok {
+			continue
+		}
+		// literal segments beat parameters, so '/pets/mine' is preferred over '/pets/{id}', but only paths that
+		// define the operation are candidates.
+		if matched == nil || compiled.params < matchedCount {
+			matched, matchedTemplate, matchedCount = pathItem, key, compiled.params
+		}
+		if op := pathItem.GetOperations()[method]; op != nil && (found == nil || compiled.params < bestCount) {
+			found, operation, template, bestCount = pathItem, op, key, compiled.params
+		}
+	}
+	if matched == nil {
+		return nil, nil, "", []*ValidationError{{
+			Message:        fmt.Sprintf("%s path '%s' not found", request.Method, request.URL.Path),
+			Reason:         fmt.Sprintf("the path '%s' does not match any paths defined in the specification", request.URL.Path),
+			ValidationType: PathValidation,
+		}}
+	}
+	if found == nil {
+		line, col := nodePosition(pathKeyNode(v.document.Paths, matchedTemplate))
+		return matched, nil, matchedTemplate, []*ValidationError{{
+			Message:        fmt.Sprintf("%s operation not found for path '%s'", request.Method, matchedTemplate),
+			Reason:         fmt.Sprintf("the path '%s' does not define a '%s' operation", matchedTemplate, method),
+			ValidationType: PathValidation,
+			SpecLine:       line,
+			SpecCol:        col,
+		}}
+	}
+	return found, operation, template, nil
+}
+
+// pathTemplate returns the compiled path template for a key of the document paths. Templates are compiled when
+// the Validator is created, any path added to the document since is compiled each time it is used.
+func (v *Validator) pathTemplate(template string) *compiledTemplate {
+	if compiled, ok := v.templates[template]; ok {
+		return compiled
+	}
+	return compilePathTemplate(template)
+}
+
+// stripBasePath removes the base path of any matching server from an escaped request path. Servers are taken
+// from the path item, or the document if the path item does not define any.
+func (v *Validator) stripBasePath(path string, pathItem *v3.PathItem) string {
+	servers := v.document.Servers
+	if pathItem != nil && len(pathItem.Servers) > 0 {
+		servers = pathItem.Servers
+	}
+	longest := ""
+	for _, s := range servers {
+		base := serverBasePath(s)
+		if base == "" || base == "/" {
+			continue
+		}
+		if (path == base || strings.HasPrefix(path, base+"/")) && len(base) > len(longest) {
+			longest = base
+		}
+	}
+	if longest == "" {
+		return path
+	}
+	stripped := strings.TrimPrefix(path, longest)
+	if stripped == "" {
+		return "/"
+	}
+	return stripped
+}
+
+// serverBasePath extracts the escaped path from a server URL, replacing any variables with their default values.
+func serverBasePath(server *v3.Server) string {
+	if server == nil {
+		return ""
+	}
+	u := templateParam.ReplaceAllStringFunc(server.URL, func(s string) string {
+		if sv := server.Variables.GetOrZero(strings.Trim(s, "{}")); sv != nil {
+			return sv.Default
+		}
+		return s
+	})
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSuffix(parsed.EscapedPath(), "/")
+}
+
+// compiledTemplate is a path template that is ready to match request paths. Literal segments are compared as
+// they are, segments that contain parameters are matched with a regular expression.
+type compiledTemplate struct {
+	segments []templateSegment
+	params   int
+}
+
+// templateSegment is a single segment of a compiledTemplate, rx and names are only set if the segment contains
+// parameters.
+type templateSegment struct {
+	literal string
+	rx      *regexp.Regexp
+	names   []string
+}
+
+// compilePathTemplate compiles a path template, so it can be matched against request paths.
+func compilePathTemplate(template string) *compiledTemplate {
+	compiled := new(compiledTemplate)
+	for _, seg := range strings.Split(strings.Trim(template, "/"), "/") {
+		names := templateParam.FindAllStringSubmatch(seg, -1)
+		if len(names) == 0 {
+			compiled.segments = append(compiled.segments, templateSegment{literal: seg})
+			continue
+		}
+		// segments can mix literals and parameters, for example '{id}.json', so build a regex for each one.
+		rx := "^"
+		last := 0
+		for _, loc := range templateParam.FindAllStringIndex(seg, -1) {
+			rx += regexp.QuoteMeta(seg[last:loc[0]]) + "([^/]+?)"
+			last = loc[1]
+		}
+		rx += regexp.QuoteMeta(seg[last:]) + "$"
+		segment := templateSegment{rx: regexp.MustCompile(rx)}
+		for _, name := range names {
+			segment.names = append(segment.names, name[1])
+		}
+		compiled.segments = append(compiled.segments, segment)
+		compiled.params += len(names)
+	}
+	return compiled
+}
+
+// match checks if an escaped path (like request.URL.EscapedPath()) matches the template, and returns the value
+// of every parameter in the template. The path is split into segments before anything is unescaped, so an
+// escaped '/' (%2F) stays inside its segment, and every value is unescaped exactly once.
+func (c *compiledTemplate) match(path string) (map[string]string, bool) {
+	pathSegs := strings.Split(strings.Trim(path, "/"), "/")
+	if len(c.segments) != len(pathSegs) {
+		return nil, false
+	}
+	params := make(map[string]string)
+	for i, seg := range c.segments {
+		if seg.rx == nil {
+			if seg.literal != unescapeSegment(pathSegs[i]) {
+				return nil, false
+			}
+			continue
+		}
+		matches := seg.rx.FindStringSubmatch(pathSegs[i])
+		if matches == nil {
+			return nil, false
+		}
+		for j, name := range seg.names {
+			params[name] = unescapeSegment(matches[j+1])
+		}
+	}
+	return params, true
+}
+
+// unescapeSegment unescapes a single path segment, a segment that is not escaped correctly is used as it is.
+func unescapeSegment(segment string) string {
+	if value, err := url.PathUnescape(segment); err == nil {
+		return value
+	}
+	return segment
+}
+
+// pathKeyNode returns the key node for a path in the specification.
+func pathKeyNode(paths *v3.Paths, template string) *yaml.Node {
+	if paths == nil || paths.GoLow() == nil {
+		return nil
+	}
+	if key, _ := paths.GoLow().FindPathAndKey(template); key != nil {
+		return key.KeyNode
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package validator
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel/high"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompiledTemplate_Match(t *testing.T) {
+	params, ok := compilePathTemplate("/pets/{petId}/toys/{toyId}").match("/pets/1/toys/ball%20two")
+	assert.True(t, ok)
+	assert.Equal(t, map[string]string{"petId": "1", "toyId": "ball two"}, params)
+
+	// values are unescaped once, after the path has been split into segments.
+	params, ok = compilePathTemplate("/pets/{petId}/toys/{toyId}").match("/pets/a%2Fb/toys/100%2525")
+	assert.True(t, ok)
+	assert.Equal(t, map[string]string{"petId": "a/b", "toyId": "100%25"}, params)
+
+	params, ok = compilePathTemplate("/reports/{id}.{format}").match("/reports/99.json")
+	assert.True(t, ok)
+	assert.Equal(t, map[string]string{"id": "99", "format": "json"}, params)
+
+	_, ok = compilePathTemplate("/pets/{petId}").match("/pets/1/toys")
+	assert.False(t, ok)
+	_, ok = compilePathTemplate("/pets/{petId}").match("/cats/1")
+	assert.False(t, ok)
+	_, ok = compilePathTemplate("/reports/{id}.json").match("/reports/99.xml")
+	assert.False(t, ok)
+}
+
+func TestValidator_PathTemplate(t *testing.T) {
+	paths := high.NewOrderedMap[string, *v3.PathItem]()
+	paths.Set("/pets/{petId}", &v3.PathItem{})
+	v := NewValidator(&v3.Document{Paths: &v3.Paths{PathItems: paths}})
+
+	// templates of the document are compiled once, anything else is compiled when it is used.
+	assert.Same(t, v.pathTemplate("/pets/{petId}"), v.pathTemplate("/pets/{petId}"))
+	assert.NotSame(t, v.pathTemplate("/cats/{catId}"), v.pathTemplate("/cats/{catId}"))
+	assert.Equal(t, 1, v.pathTemplate("/pets/{petId}").params)
+
+	params, ok := v.pathTemplate("/pets/{petId}").match("/pets/9")
+	assert.True(t, ok)
+	assert.Equal(t, "9", params["petId"])
+}
+
+func TestServerBasePath(t *testing.T) {
+	variables := high.NewOrderedMap[string, *v3.ServerVariable]()
+	variables.Set("version", &v3.ServerVariable{Default: "v1"})
+
+	assert.Equal(t, "/v2", serverBasePath(&v3.Server{URL: "https://api.pets.com/v2/"}))
+	assert.Equal(t, "/v1", serverBasePath(&v3.Server{URL: "/{version}", Variables: variables}))
+	assert.Equal(t, "", serverBasePath(&v3.Server{URL: "https://api.pets.com"}))
+	assert.Equal(t, "", serverBasePath(nil))
+}
+
+func TestValidator_StripBasePath(t *testing.T) {
+	v := NewValidator(&v3.Document{Servers: []*v3.Server{
+		{URL: "https://api.pets.com/v1"},
+		{URL: "https://api.pets.com/v1/beta"},
+	}})
+	assert.Equal(t, "/pets", v.stripBasePath("/v1/pets", nil))
+	assert.Equal(t, "/pets", v.stripBasePath("/v1/beta/pets", nil))
+	assert.Equal(t, "/", v.stripBasePath("/v1", nil))
+	assert.Equal(t, "/v1pets", v.stripBasePath("/v1pets", nil))
+
+	pathItem := &v3.PathItem{Servers: []*v3.Server{{URL: "/v3"}}}
+	assert.Equal(t, "/pets", v.stripBasePath("/v3/pets", pathItem))
+	assert.Equal(t, "/v1/pets", v.stripBasePath("/v1/pets", pathItem))
+}
@@ -0,0 +1,135 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package validator
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+// validateRequestBody validates the body of a request against the request body of an operation.
+func validateRequestBody(request *http.Request, operation *v3.Operation) []*ValidationError {
+	rb := operation.RequestBody
+	if rb == nil {
+		return nil
+	}
+	var line, col int
+	if operation.GoLow() != nil {
+		line, col = nodePosition(operation.GoLow().RequestBody.KeyNode)
+	}
+	newError := func(message, reason string, failures []*SchemaValidationFailure) []*ValidationError {
+		return []*ValidationError{{
+			Message:           message,
+			Reason:            reason,
+			ValidationType:    RequestBodyValidation,
+			ValidationSubType: "body",
+			SpecLine:          line,
+			SpecCol:           col,
+			SchemaFailures:    failures,
+		}}
+	}
+
+	body, err := readBody(&request.Body)
+	if err != nil {
+		return newError("request body cannot be read", err.Error(), nil)
+	}
+	if len(body) == 0 {
+		if rb.Required != nil && *rb.Required {
+			return newError(fmt.Sprintf("%s request body is missing", request.Method),
+				"the request body is required, but the request is empty", nil)
+		}
+		return nil
+	}
+
+	contentType := request.Header.Get("Content-Type")
+	mediaType, mt := findMediaType(rb.Content, contentType)
+	if mt == nil {
+		return newError(fmt.Sprintf("%s request body has an unsupported content type '%s'", request.Method, contentType),
+			fmt.Sprintf("the content type '%s' is not defined by the request body, expected one of %s",
+				contentType, strings.Join(rb.Content.Keys(), ", ")), nil)
+	}
+	if mt.Schema == nil || !isJSONMediaType(mediaType) {
+		return nil
+	}
+
+	var value any
+	if err = decodeJSON(body, &value); err != nil {
+		return newError(fmt.Sprintf("%s request body cannot be decoded", request.Method),
+			fmt.Sprintf("the request body is not valid JSON: %s", err.Error()), nil)
+	}
+	if failures := validateSchema(mt.Schema, value); len(failures) > 0 {
+		return newError(fmt.Sprintf("%s request body failed to validate", request.Method),
+			fmt.Sprintf("the request body does not match the schema: %s", failures[0].Error()), failures)
+	}
+	return nil
+}
+
+// readBody reads a request or response body and then replaces it, so it can be read again.
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if body == nil || *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+	b, err := io.ReadAll(*body)
+	_ = (*body).Close()
+	*body = io.NopCloser(bytes.NewReader(b))
+	return b, err
+}
+
+// findMediaType locates the media type for a content type, exact matches are preferred, then wildcards
+// like 'application/*' and finally '*/*'.
+func findMediaType(content *high.OrderedMap[string, *v3.MediaType], contentType string) (string, *v3.MediaType) {
+	parsed, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		parsed = strings.TrimSpace(strings.ToLower(strings.Split(contentType, ";")[0]))
+	}
+	if parsed == "" {
+		parsed = "application/octet-stream"
+	}
+	for _, k := range content.Keys() {
+		if strings.EqualFold(k, parsed) {
+			return k, content.GetOrZero(k)
+		}
+	}
+	// the media type in the spec can also have parameters, for example 'application/json; charset=utf-8'.
+	for _, k := range content.Keys() {
+		if specType, _, sErr := mime.ParseMediaType(k); sErr == nil && specType == parsed {
+			return k, content.GetOrZero(k)
+		}
+	}
+	if i := strings.Index(parsed, "/"); i > 0 {
+		if v, ok := content.Get(parsed[:i] + "/*"); ok {
+			return parsed, v
+		}
+	}
+	if v, ok := content.Get("*/*"); ok {
+		return parsed, v
+	}
+	return "", nil
+}
+
+// isJSONMediaType checks if a media type is JSON, including structured syntax types like 'application/problem+json'.
+func isJSONMediaType(mediaType string) bool {
+	mt, _, err := mime.ParseMediaType(mediaType)
+	if err != nil {
+		mt = strings.ToLower(mediaType)
+	}
+	return mt == "application/json" || strings.HasSuffix(mt, "+json")
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
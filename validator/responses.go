@@ -0,0 +1,171 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package validator
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"gopkg.in/yaml.v3"
+)
+
+// validateResponse validates a response against the responses defined by an operation.
+func validateResponse(operation *v3.Operation, response *http.Response) []*ValidationError {
+	if response == nil {
+		return []*ValidationError{{
+			Message:        "no response to validate",
+			Reason:         "the response is nil",
+			ValidationType: ResponseValidation,
+		}}
+	}
+	if operation.Responses == nil {
+		return nil
+	}
+	code, resp, keyNode := findResponse(operation.Responses, response.StatusCode)
+	if resp == nil {
+		var line, col int
+		if operation.GoLow() != nil {
+			line, col = nodePosition(operation.GoLow().Responses.KeyNode)
+		}
+		return []*ValidationError{{
+			Message:        fmt.Sprintf("response code %d is not defined", response.StatusCode),
+			Reason:         fmt.Sprintf("the operation does not define a response for code %d, or a default response", response.StatusCode),
+			ValidationType: ResponseValidation,
+			SpecLine:       line,
+			SpecCol:        col,
+		}}
+	}
+	line, col := nodePosition(keyNode)
+	newError := func(subType, message, reason string, failures []*SchemaValidationFailure) *ValidationError {
+		return &ValidationError{
+			Message:           message,
+			Reason:            reason,
+			ValidationType:    ResponseValidation,
+			ValidationSubType: subType,
+			SpecLine:          line,
+			SpecCol:           col,
+			SchemaFailures:    failures,
+		}
+	}
+
+	var errs []*ValidationError
+
+	// headers
+	for _, name := range resp.Headers.Keys() {
+		header := resp.Headers.GetOrZero(name)
+		if header == nil || strings.EqualFold(name, "Content-Type") {
+			continue
+		}
+		values := response.Header.Values(name)
+		if len(values) == 0 {
+			if header.Required {
+				errs = append(errs, headerError(header, newError("header",
+					fmt.Sprintf("response header '%s' is missing", name),
+					fmt.Sprintf("the '%s' response requires the header '%s'", code, name), nil)))
+			}
+			continue
+		}
+		if header.Schema == nil {
+			continue
+		}
+		value := any(coerceValue(header.Schema.Schema(), values[0]))
+		if s := header.Schema.Schema(); s != nil && hasType(s, "array") {
+			parts := strings.Split(strings.Join(values, ","), ",")
+			arr := make([]any, len(parts))
+			for i := range parts {
+				arr[i] = strings.TrimSpace(parts[i])
+				if s.Items != nil && s.Items.IsA() && s.Items.A != nil {
+					arr[i] = coerceValue(s.Items.A.Schema(), strings.TrimSpace(parts[i]))
+				}
+			}
+			value = arr
+		}
+		if failures := validateSchema(header.Schema, value); len(failures) > 0 {
+			errs = append(errs, headerError(header, newError("header",
+				fmt.Sprintf("response header '%s' failed to validate", name),
+				fmt.Sprintf("the response header '%s' does not match the schema: %s", name, failures[0].Reason),
+				failures)))
+		}
+	}
+
+	// body
+	body, err := readBody(&response.Body)
+	if err != nil {
+		return append(errs, newError("body", "response body cannot be read", err.Error(), nil))
+	}
+	if resp.Content.Len() == 0 {
+		return errs
+	}
+	contentType := response.Header.Get("Content-Type")
+	if len(body) == 0 && contentType == "" {
+		return errs
+	}
+	mediaType, mt := findMediaType(resp.Content, contentType)
+	if mt == nil {
+		return append(errs, newError("body",
+			fmt.Sprintf("response body has an unsupported content type '%s'", contentType),
+			fmt.Sprintf("the content type '%s' is not defined by the '%s' response, expected one of %s",
+				contentType, code, strings.Join(resp.Content.Keys(), ", ")), nil))
+	}
+	if mt.Schema == nil || !isJSONMediaType(mediaType) {
+		return errs
+	}
+	var value any
+	if err = decodeJSON(body, &value); err != nil {
+		return append(errs, newError("body", "response body cannot be decoded",
+			fmt.Sprintf("the response body is not valid JSON: %s", err.Error()), nil))
+	}
+	if failures := validateSchema(mt.Schema, value); len(failures) > 0 {
+		errs = append(errs, newError("body",
+			fmt.Sprintf("%d response body failed to validate", response.StatusCode),
+			fmt.Sprintf("the response body does not match the schema: %s", failures[0].Error()), failures))
+	}
+	return errs
+}
+
+// findResponse locates the response for a status code. Exact codes are preferred, then ranges like '2XX', and
+// finally the default response. The code used in the specification is returned along with its key node.
+func findResponse(responses *v3.Responses, statusCode int) (string, *v3.Response, *yaml.Node) {
+	exact := strconv.Itoa(statusCode)
+	wildcard := fmt.Sprintf("%dXX", statusCode/100)
+	for _, code := range []string{exact, wildcard} {
+		for _, key := range responses.Codes.Keys() {
+			if strings.EqualFold(key, code) {
+				return key, responses.Codes.GetOrZero(key), responseKeyNode(responses, key)
+			}
+		}
+	}
+	if responses.Default != nil {
+		var node *yaml.Node
+		if responses.GoLow() != nil {
+			node = responses.GoLow().Default.KeyNode
+		}
+		return "default", responses.Default, node
+	}
+	return "", nil, nil
+}
+
+// responseKeyNode returns the key node for a response code in the specification.
+func responseKeyNode(responses *v3.Responses, code string) *yaml.Node {
+	if responses.GoLow() == nil {
+		return nil
+	}
+	for k := range responses.GoLow().Codes {
+		if k.Value == code {
+			return k.KeyNode
+		}
+	}
+	return nil
+}
+
+// headerError points a ValidationError at a header, if the header was built from a specification.
+func headerError(header *v3.Header, vErr *ValidationError) *ValidationError {
+	if header.GoLow() != nil && header.GoLow().Schema.KeyNode != nil {
+		vErr.SpecLine, vErr.SpecCol = nodePosition(header.GoLow().Schema.KeyNode)
+	}
+	return vErr
+}
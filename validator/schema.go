@@ -0,0 +1,766 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/pb33f/libopenapi/datamodel/low"
+	lowbase "github.com/pb33f/libopenapi/datamodel/low/base"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// maxSchemaDepth stops the evaluator from running away with a circular schema that never consumes any data
+// (for example, an allOf that points back to itself).
+const maxSchemaDepth = 256
+
+// compiled patterns are cached, the same schemas are used over and over again.
+var (
+	patternLock  sync.RWMutex
+	patternCache = make(map[string]*regexp.Regexp)
+)
+
+// failFunc records a failure against a keyword of the schema being evaluated.
+type failFunc func(keyword, reason string, args ...any)
+
+// evaluation is the result of evaluating a value against a schema. Alongside any failures, it tracks the object
+// properties and array items that were evaluated, which is what unevaluatedProperties and unevaluatedItems use.
+type evaluation struct {
+	failures   []*SchemaValidationFailure
+	properties map[string]bool
+	items      map[int]bool
+}
+
+func newEvaluation() *evaluation {
+	return &evaluation{properties: make(map[string]bool), items: make(map[int]bool)}
+}
+
+func (e *evaluation) valid() bool {
+	return len(e.failures) == 0
+}
+
+// add appends the failures of another evaluation.
+func (e *evaluation) add(other *evaluation) {
+	e.failures = append(e.failures, other.failures...)
+}
+
+// merge adds the evaluated properties and items of another evaluation.
+func (e *evaluation) merge(other *evaluation) {
+	for k := range other.properties {
+		e.properties[k] = true
+	}
+	for i := range other.items {
+		e.items[i] = true
+	}
+}
+
+// validateSchema will evaluate a value against a schema, and return every violation found. Values are expected
+// to be plain JSON values (maps, slices, strings, bools, numbers and nil). Numbers can be any Go number type or a
+// json.Number.
+func validateSchema(proxy *base.SchemaProxy, value any) []*SchemaValidationFailure {
+	if proxy == nil {
+		return nil
+	}
+	return evaluateProxy(proxy, value, "", "", 0).failures
+}
+
+// evaluateProxy builds a schema from a proxy (resolving any reference) and evaluates a value against it.
+func evaluateProxy(proxy *base.SchemaProxy, value any, path, schemaPath string, depth int) *evaluation {
+	if proxy == nil {
+		return newEvaluation()
+	}
+	if allowed, ok := booleanSchema(proxy); ok {
+		ev := newEvaluation()
+		if !allowed {
+			line, col := nodePosition(proxy.GoLow().GetValueNode())
+			ev.failures = append(ev.failures, &SchemaValidationFailure{
+				Reason:         "value is not allowed, the schema is 'false'",
+				Location:       path,
+				SchemaLocation: schemaPath,
+				Line:           line,
+				Column:         col,
+			})
+		}
+		return ev
+	}
+	schema, err := proxy.BuildSchema()
+	if schema == nil {
+		ev := newEvaluation()
+		ev.failures = append(ev.failures, &SchemaValidationFailure{
+			Reason:         fmt.Sprintf("unable to build schema: %v", err),
+			Location:       path,
+			SchemaLocation: schemaPath,
+		})
+		return ev
+	}
+	return evaluateSchema(schema, value, path, schemaPath, depth+1)
+}
+
+// evaluateSchema will evaluate a value against a schema. path is a JSON pointer to the value being evaluated,
+// schemaPath is a JSON pointer to the schema being used.
+func evaluateSchema(schema *base.Schema, value any, path, schemaPath string, depth int) *evaluation {
+	ev := newEvaluation()
+	if schema == nil {
+		return ev
+	}
+	if depth > maxSchemaDepth {
+		ev.failures = append(ev.failures,
+			newSchemaFailure(schema, "", path, schemaPath, "schema is too deep, it may be circular"))
+		return ev
+	}
+	fail := func(keyword, reason string, args ...any) {
+		ev.failures = append(ev.failures, newSchemaFailure(schema, keyword, path, schemaPath+"/"+keyword,
+			fmt.Sprintf(reason, args...)))
+	}
+
+	// 3.0 nullable: null is allowed, regardless of anything else.
+	if value == nil && schema.Nullable != nil && *schema.Nullable {
+		return ev
+	}
+
+	// type
+	if len(schema.Type) > 0 && !matchesType(schema.Type, value) {
+		fail("type", "expected %s, but got %s", strings.Join(schema.Type, " or "), jsonType(value))
+	}
+
+	// enum, const and format
+	if len(schema.Enum) > 0 {
+		found := false
+		for _, e := range schema.Enum {
+			if jsonEqual(e, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			fail("enum", "value must be one of %s", describeValues(schema.Enum))
+		}
+	}
+	if hasConst(schema) && !jsonEqual(schema.Const, value) {
+		fail("const", "value must be %s", describeValue(schema.Const))
+	}
+	if schema.Format != "" && !checkFormat(schema.Format, value) {
+		fail("format", "value is not a valid '%s'", schema.Format)
+	}
+
+	switch v := value.(type) {
+	case string:
+		evaluateString(schema, v, fail)
+	case []any:
+		evaluateArray(schema, v, path, schemaPath, depth, ev, fail)
+	case map[string]any:
+		evaluateObject(schema, v, path, schemaPath, depth, ev, fail)
+	default:
+		if n, ok := toFloat(value); ok {
+			evaluateNumber(schema, n, fail)
+		}
+	}
+
+	evaluateComposition(schema, value, path, schemaPath, depth, ev, fail)
+
+	// unevaluated keywords must run last, they depend on everything else that has been evaluated.
+	switch v := value.(type) {
+	case []any:
+		evaluateUnevaluatedItems(schema, v, path, schemaPath, depth, ev, fail)
+	case map[string]any:
+		evaluateUnevaluatedProperties(schema, v, path, schemaPath, depth, ev, fail)
+	}
+	return ev
+}
+
+func evaluateString(schema *base.Schema, value string, fail failFunc) {
+	length := int64(utf8.RuneCountInString(value))
+	if schema.MaxLength != nil && length > *schema.MaxLength {
+		fail("maxLength", "length must be <= %d, but got %d", *schema.MaxLength, length)
+	}
+	if schema.MinLength != nil && length < *schema.MinLength {
+		fail("minLength", "length must be >= %d, but got %d", *schema.MinLength, length)
+	}
+	if schema.Pattern != "" {
+		if rx := compilePattern(schema.Pattern); rx != nil && !rx.MatchString(value) {
+			fail("pattern", "value does not match pattern '%s'", schema.Pattern)
+		}
+	}
+}
+
+func evaluateNumber(schema *base.Schema, value float64, fail failFunc) {
+	if schema.MultipleOf != nil && *schema.MultipleOf > 0 {
+		q := value / *schema.MultipleOf
+		if math.Abs(q-math.Round(q)) > 1e-9 {
+			fail("multipleOf", "%s is not a multiple of %s", formatNumber(value), formatNumber(*schema.MultipleOf))
+		}
+	}
+	if schema.Maximum != nil {
+		if schema.ExclusiveMaximum != nil && schema.ExclusiveMaximum.IsA() && schema.ExclusiveMaximum.A {
+			if value >= *schema.Maximum {
+				fail("maximum", "must be < %s, but got %s", formatNumber(*schema.Maximum), formatNumber(value))
+			}
+		} else if value > *schema.Maximum {
+			fail("maximum", "must be <= %s, but got %s", formatNumber(*schema.Maximum), formatNumber(value))
+		}
+	}
+	if schema.ExclusiveMaximum != nil && schema.ExclusiveMaximum.IsB() && value >= schema.ExclusiveMaximum.B {
+		fail("exclusiveMaximum", "must be < %s, but got %s",
+			formatNumber(schema.ExclusiveMaximum.B), formatNumber(value))
+	}
+	if schema.Minimum != nil {
+		if schema.ExclusiveMinimum != nil && schema.ExclusiveMinimum.IsA() && schema.ExclusiveMinimum.A {
+			if value <= *schema.Minimum {
+				fail("minimum", "must be > %s, but got %s", formatNumber(*schema.Minimum), formatNumber(value))
+			}
+		} else if value < *schema.Minimum {
+			fail("minimum", "must be >= %s, but got %s", formatNumber(*schema.Minimum), formatNumber(value))
+		}
+	}
+	if schema.ExclusiveMinimum != nil && schema.ExclusiveMinimum.IsB() && value <= schema.ExclusiveMinimum.B {
+		fail("exclusiveMinimum", "must be > %s, but got %s",
+			formatNumber(schema.ExclusiveMinimum.B), formatNumber(value))
+	}
+}
+
+func evaluateArray(schema *base.Schema, value []any, path, schemaPath string, depth int, ev *evaluation,
+	fail failFunc) {
+
+	// prefixItems (3.1) validate items by position, items then applies to everything after them.
+	for i, ps := range schema.PrefixItems {
+		if i >= len(value) {
+			break
+		}
+		ev.add(evaluateProxy(ps, value[i], fmt.Sprintf("%s/%d", path, i),
+			fmt.Sprintf("%s/prefixItems/%d", schemaPath, i), depth))
+		ev.items[i] = true
+	}
+	start := len(schema.PrefixItems)
+	if schema.Items != nil {
+		switch {
+		case schema.Items.IsA() && schema.Items.A != nil:
+			for i := start; i < len(value); i++ {
+				ev.add(evaluateProxy(schema.Items.A, value[i], fmt.Sprintf("%s/%d", path, i),
+					schemaPath+"/items", depth))
+				ev.items[i] = true
+			}
+		case schema.Items.IsB() && !schema.Items.B:
+			if len(value) > start {
+				fail("items", "must have at most %d items, but got %d", start, len(value))
+			}
+		case schema.Items.IsB() && schema.Items.B:
+			for i := start; i < len(value); i++ {
+				ev.items[i] = true
+			}
+		}
+	}
+
+	if schema.Contains != nil {
+		matches := int64(0)
+		for i := range value {
+			if evaluateProxy(schema.Contains, value[i], fmt.Sprintf("%s/%d", path, i),
+				schemaPath+"/contains", depth).valid() {
+				matches++
+				ev.items[i] = true
+			}
+		}
+		minimum := int64(1)
+		if schema.MinContains != nil {
+			minimum = *schema.MinContains
+		}
+		if matches < minimum {
+			fail("contains", "must contain at least %d matching items, but got %d", minimum, matches)
+		}
+		if schema.MaxContains != nil && matches > *schema.MaxContains {
+			fail("maxContains", "must contain at most %d matching items, but got %d", *schema.MaxContains, matches)
+		}
+	}
+
+	length := int64(len(value))
+	if schema.MaxItems != nil && length > *schema.MaxItems {
+		fail("maxItems", "must have at most %d items, but got %d", *schema.MaxItems, length)
+	}
+	if schema.MinItems != nil && length < *schema.MinItems {
+		fail("minItems", "must have at least %d items, but got %d", *schema.MinItems, length)
+	}
+	if schema.UniqueItems != nil && *schema.UniqueItems {
+		for i := 0; i < len(value); i++ {
+			for j := i + 1; j < len(value); j++ {
+				if jsonEqual(value[i], value[j]) {
+					fail("uniqueItems", "items at index %d and %d are equal", i, j)
+					return
+				}
+			}
+		}
+	}
+}
+
+func evaluateObject(schema *base.Schema, value map[string]any, path, schemaPath string, depth int,
+	ev *evaluation, fail failFunc) {
+
+	for _, r := range schema.Required {
+		if _, ok := value[r]; !ok {
+			fail("required", "missing required property '%s'", r)
+		}
+	}
+	for _, name := range schema.DependentRequired.Keys() {
+		if _, ok := value[name]; !ok {
+			continue
+		}
+		for _, r := range schema.DependentRequired.GetOrZero(name) {
+			if _, found := value[r]; !found {
+				fail("dependentRequired", "missing property '%s', it is required when '%s' is present", r, name)
+			}
+		}
+	}
+	count := int64(len(value))
+	if schema.MaxProperties != nil && count > *schema.MaxProperties {
+		fail("maxProperties", "must have at most %d properties, but got %d", *schema.MaxProperties, count)
+	}
+	if schema.MinProperties != nil && count < *schema.MinProperties {
+		fail("minProperties", "must have at least %d properties, but got %d", *schema.MinProperties, count)
+	}
+
+	// evaluate properties in a stable order, so failures are always reported the same way.
+	patterns := schema.PatternProperties.Keys()
+	for _, k := range sortedKeys(value) {
+		propPath := fmt.Sprintf("%s/%s", path, utils.EscapePointerSegment(k))
+
+		if schema.PropertyNames != nil {
+			if names := evaluateProxy(schema.PropertyNames, k, path, schemaPath+"/propertyNames",
+				depth); !names.valid() {
+				fail("propertyNames", "property name '%s' is not valid: %s", k, names.failures[0].Reason)
+			}
+		}
+
+		matched := false
+		if prop, ok := schema.Properties.Get(k); ok {
+			ev.add(evaluateProxy(prop, value[k], propPath,
+				fmt.Sprintf("%s/properties/%s", schemaPath, utils.EscapePointerSegment(k)), depth))
+			matched = true
+		}
+		for _, p := range patterns {
+			if rx := compilePattern(p); rx != nil && rx.MatchString(k) {
+				ev.add(evaluateProxy(schema.PatternProperties.GetOrZero(p), value[k], propPath,
+					fmt.Sprintf("%s/patternProperties/%s", schemaPath, utils.EscapePointerSegment(p)), depth))
+				matched = true
+			}
+		}
+		if matched {
+			ev.properties[k] = true
+			continue
+		}
+
+		// additionalProperties only applies to properties not matched by properties or patternProperties.
+		switch ap := schema.AdditionalProperties.(type) {
+		case bool:
+			if !ap {
+				fail("additionalProperties", "property '%s' is not allowed", k)
+				continue
+			}
+		case *base.SchemaProxy:
+			ev.add(evaluateProxy(ap, value[k], propPath, schemaPath+"/additionalProperties", depth))
+		case nil:
+			continue
+		default:
+			// a schema without a type is not modeled as a schema, so build one from the specification.
+			if proxy := additionalPropertiesProxy(schema); proxy != nil {
+				ev.add(evaluateProxy(proxy, value[k], propPath, schemaPath+"/additionalProperties", depth))
+			}
+		}
+		ev.properties[k] = true
+	}
+
+	for _, name := range schema.DependentSchemas.Keys() {
+		if _, ok := value[name]; !ok {
+			continue
+		}
+		dep := evaluateProxy(schema.DependentSchemas.GetOrZero(name), value, path,
+			fmt.Sprintf("%s/dependentSchemas/%s", schemaPath, utils.EscapePointerSegment(name)), depth)
+		ev.add(dep)
+		ev.merge(dep)
+	}
+}
+
+func evaluateComposition(schema *base.Schema, value any, path, schemaPath string, depth int, ev *evaluation,
+	fail failFunc) {
+
+	for i, s := range schema.AllOf {
+		sub := evaluateProxy(s, value, path, fmt.Sprintf("%s/allOf/%d", schemaPath, i), depth)
+		ev.add(sub)
+		ev.merge(sub)
+	}
+
+	// a discriminator picks the schema to use, rather than trying every schema in oneOf (or anyOf). The other
+	// keyword is still evaluated as usual.
+	var picked string
+	if obj, ok := value.(map[string]any); ok && schema.Discriminator != nil {
+		picked = evaluateDiscriminator(schema, obj, path, schemaPath, depth, ev, fail)
+	}
+	if len(schema.AnyOf) > 0 && picked != "anyOf" {
+		passed := false
+		for i, s := range schema.AnyOf {
+			if sub := evaluateProxy(s, value, path, fmt.Sprintf("%s/anyOf/%d", schemaPath, i), depth); sub.valid() {
+				passed = true
+				ev.merge(sub)
+			}
+		}
+		if !passed {
+			fail("anyOf", "value does not match any of the schemas in anyOf")
+		}
+	}
+	if len(schema.OneOf) > 0 && picked != "oneOf" {
+		var passed []string
+		for i, s := range schema.OneOf {
+			if sub := evaluateProxy(s, value, path, fmt.Sprintf("%s/oneOf/%d", schemaPath, i), depth); sub.valid() {
+				passed = append(passed, strconv.Itoa(i))
+				ev.merge(sub)
+			}
+		}
+		switch len(passed) {
+		case 1:
+		case 0:
+			fail("oneOf", "value does not match any of the schemas in oneOf")
+		default:
+			fail("oneOf", "value matches more than one schema in oneOf (%s), it must match exactly one",
+				strings.Join(passed, ", "))
+		}
+	}
+
+	if schema.Not != nil {
+		if evaluateProxy(schema.Not, value, path, schemaPath+"/not", depth).valid() {
+			fail("not", "value must not match the schema in not")
+		}
+	}
+
+	// if / then / else (3.1), failures of 'if' are never reported, it only decides which branch to use.
+	if schema.If != nil {
+		if cond := evaluateProxy(schema.If, value, path, schemaPath+"/if", depth); cond.valid() {
+			ev.merge(cond)
+			if schema.Then != nil {
+				sub := evaluateProxy(schema.Then, value, path, schemaPath+"/then", depth)
+				ev.add(sub)
+				ev.merge(sub)
+			}
+		} else if schema.Else != nil {
+			sub := evaluateProxy(schema.Else, value, path, schemaPath+"/else", depth)
+			ev.add(sub)
+			ev.merge(sub)
+		}
+	}
+}
+
+// evaluateDiscriminator uses the discriminator property of an object to select a schema from oneOf or anyOf.
+// The mapping is used first, then the name of the referenced schema. The keyword that was evaluated is returned,
+// or an empty string when the discriminator could not pick a schema and some of the schemas are not references,
+// in which case the keyword should be evaluated as usual.
+func evaluateDiscriminator(schema *base.Schema, value map[string]any, path, schemaPath string, depth int,
+	ev *evaluation, fail failFunc) string {
+
+	keyword, candidates := "oneOf", schema.OneOf
+	if len(candidates) == 0 {
+		keyword, candidates = "anyOf", schema.AnyOf
+	}
+	if len(candidates) == 0 {
+		return ""
+	}
+	d := schema.Discriminator
+	raw, ok := value[d.PropertyName]
+	if !ok {
+		fail("discriminator", "missing discriminator property '%s'", d.PropertyName)
+		return keyword
+	}
+	name, ok := raw.(string)
+	if !ok {
+		fail("discriminator", "discriminator property '%s' must be a string, but got %s", d.PropertyName,
+			jsonType(raw))
+		return keyword
+	}
+	target := d.Mapping.GetOrZero(name)
+	inline := false
+	for i, c := range candidates {
+		if !c.IsReference() {
+			inline = true
+			continue
+		}
+		ref := c.GetReference()
+		if (target != "" && (ref == target || strings.HasSuffix(ref, "/"+target))) ||
+			(target == "" && ref[strings.LastIndex(ref, "/")+1:] == name) {
+			sub := evaluateProxy(c, value, path, fmt.Sprintf("%s/%s/%d", schemaPath, keyword, i), depth)
+			ev.add(sub)
+			ev.merge(sub)
+			return keyword
+		}
+	}
+	if inline {
+		return ""
+	}
+	fail("discriminator", "discriminator value '%s' does not match any of the schemas in %s", name, keyword)
+	return keyword
+}
+
+func evaluateUnevaluatedItems(schema *base.Schema, value []any, path, schemaPath string, depth int,
+	ev *evaluation, fail failFunc) {
+
+	if schema.UnevaluatedItems == nil {
+		return
+	}
+	allowed, isBool := booleanSchema(schema.UnevaluatedItems)
+	for i := range value {
+		if ev.items[i] {
+			continue
+		}
+		switch {
+		case isBool && !allowed:
+			fail("unevaluatedItems", "item at index %d is not allowed", i)
+		case !isBool:
+			ev.add(evaluateProxy(schema.UnevaluatedItems, value[i], fmt.Sprintf("%s/%d", path, i),
+				schemaPath+"/unevaluatedItems", depth))
+		}
+		ev.items[i] = true
+	}
+}
+
+func evaluateUnevaluatedProperties(schema *base.Schema, value map[string]any, path, schemaPath string, depth int,
+	ev *evaluation, fail failFunc) {
+
+	if schema.UnevaluatedProperties == nil {
+		return
+	}
+	allowed, isBool := booleanSchema(schema.UnevaluatedProperties)
+	for _, k := range sortedKeys(value) {
+		if ev.properties[k] {
+			continue
+		}
+		switch {
+		case isBool && !allowed:
+			fail("unevaluatedProperties", "property '%s' is not allowed", k)
+		case !isBool:
+			ev.add(evaluateProxy(schema.UnevaluatedProperties, value[k],
+				fmt.Sprintf("%s/%s", path, utils.EscapePointerSegment(k)), schemaPath+"/unevaluatedProperties", depth))
+		}
+		ev.properties[k] = true
+	}
+}
+
+// booleanSchema determines if a proxy is a boolean schema (3.1+), like 'unevaluatedProperties: false'.
+func booleanSchema(proxy *base.SchemaProxy) (bool, bool) {
+	if proxy.GoLow() == nil || proxy.GoLow().GetValueNode() == nil {
+		return false, false
+	}
+	node := proxy.GoLow().GetValueNode()
+	if node.Kind != yaml.ScalarNode || node.Tag != "!!bool" {
+		return false, false
+	}
+	b, err := strconv.ParseBool(node.Value)
+	return b, err == nil
+}
+
+// additionalPropertiesProxy builds a schema for additionalProperties when it was not modeled as one.
+func additionalPropertiesProxy(schema *base.Schema) *base.SchemaProxy {
+	if schema.GoLow() == nil {
+		return nil
+	}
+	node := schema.GoLow().AdditionalProperties.ValueNode
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	sp := new(lowbase.SchemaProxy)
+	_ = sp.Build(node, nil)
+	return base.NewSchemaProxy(&low.NodeReference[*lowbase.SchemaProxy]{Value: sp, ValueNode: node})
+}
+
+// newSchemaFailure creates a new SchemaValidationFailure, using the position of the keyword in the
+// specification (if the schema was built from one).
+func newSchemaFailure(schema *base.Schema, keyword, path, schemaPath, reason string) *SchemaValidationFailure {
+	line, col := nodePosition(schemaKeywordNode(schema, keyword))
+	return &SchemaValidationFailure{
+		Reason:         reason,
+		Location:       path,
+		SchemaLocation: schemaPath,
+		Line:           line,
+		Column:         col,
+	}
+}
+
+// schemaKeywordNode will locate the key node for a keyword in the low-level schema. If the keyword cannot be
+// found, the node of the schema itself is returned.
+func schemaKeywordNode(schema *base.Schema, keyword string) *yaml.Node {
+	if schema == nil || schema.GoLow() == nil {
+		return nil
+	}
+	if keyword != "" {
+		field := reflect.ValueOf(schema.GoLow()).Elem().FieldByNameFunc(func(name string) bool {
+			return strings.EqualFold(name, keyword)
+		})
+		if field.IsValid() {
+			if kn, ok := field.Interface().(low.HasKeyNode); ok && kn.GetKeyNode() != nil {
+				return kn.GetKeyNode()
+			}
+		}
+	}
+	if schema.ParentProxy != nil && schema.ParentProxy.GoLow() != nil {
+		return schema.ParentProxy.GoLow().GetValueNode()
+	}
+	return nil
+}
+
+// hasConst determines if a schema has a const value, a const of null is only detectable using the low model.
+func hasConst(schema *base.Schema) bool {
+	if schema.Const != nil {
+		return true
+	}
+	return schema.GoLow() != nil && schema.GoLow().Const.ValueNode != nil
+}
+
+// matchesType checks a value against a list of JSON schema types.
+func matchesType(types []string, value any) bool {
+	actual := jsonType(value)
+	for _, t := range types {
+		switch {
+		case t == actual:
+			return true
+		case t == "integer" && actual == "number":
+			if n, ok := toFloat(value); ok && n == math.Trunc(n) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// jsonType returns the JSON type name of a value.
+func jsonType(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	if _, ok := toFloat(value); ok {
+		return "number"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// toFloat converts any number type (including json.Number) into a float64.
+func toFloat(value any) (float64, bool) {
+	switch n := value.(type) {
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int8:
+		return float64(n), true
+	case int16:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint8:
+		return float64(n), true
+	case uint16:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+// jsonEqual compares two JSON values, numbers are compared by value regardless of their Go type.
+func jsonEqual(a, b any) bool {
+	if af, ok := toFloat(a); ok {
+		bf, ok := toFloat(b)
+		return ok && af == bf
+	}
+	switch av := a.(type) {
+	case []any:
+		bv, ok := b.([]any)
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for i := range av {
+			if !jsonEqual(av[i], bv[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]any:
+		bv, ok := b.(map[string]any)
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for k := range av {
+			if _, found := bv[k]; !found || !jsonEqual(av[k], bv[k]) {
+				return false
+			}
+		}
+		return true
+	case map[any]any:
+		converted := make(map[string]any, len(av))
+		for k, v := range av {
+			converted[fmt.Sprint(k)] = v
+		}
+		return jsonEqual(converted, b)
+	}
+	if _, ok := b.(map[any]any); ok {
+		return jsonEqual(b, a)
+	}
+	return a == b
+}
+
+func compilePattern(pattern string) *regexp.Regexp {
+	patternLock.RLock()
+	rx, ok := patternCache[pattern]
+	patternLock.RUnlock()
+	if ok {
+		return rx
+	}
+	rx, _ = regexp.Compile(pattern) // patterns that Go cannot compile are ignored.
+	patternLock.Lock()
+	patternCache[pattern] = rx
+	patternLock.Unlock()
+	return rx
+}
+
+func formatNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
+
+func describeValue(value any) string {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(b)
+}
+
+func describeValues(values []any) string {
+	d := make([]string, len(values))
+	for i := range values {
+		d[i] = describeValue(values[i])
+	}
+	return fmt.Sprintf("[%s]", strings.Join(d, ", "))
+}
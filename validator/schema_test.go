@@ -0,0 +1,183 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package validator
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/pb33f/libopenapi/datamodel/low"
+	lowbase "github.com/pb33f/libopenapi/datamodel/low/base"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func buildSchemaProxy(t *testing.T, yml string) *base.SchemaProxy {
+	var node yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &node)
+
+	sp := new(lowbase.SchemaProxy)
+	assert.NoError(t, sp.Build(node.Content[0], nil))
+	return base.NewSchemaProxy(&low.NodeReference[*lowbase.SchemaProxy]{
+		Value:     sp,
+		ValueNode: node.Content[0],
+	})
+}
+
+func reasons(failures []*SchemaValidationFailure) []string {
+	var r []string
+	for _, f := range failures {
+		r = append(r, f.Error())
+	}
+	return r
+}
+
+func TestValidateSchema_Types(t *testing.T) {
+	sp := buildSchemaProxy(t, `type: [integer, "null"]`)
+	assert.Empty(t, validateSchema(sp, 1))
+	assert.Empty(t, validateSchema(sp, json.Number("2.0")))
+	assert.Empty(t, validateSchema(sp, nil))
+	assert.Equal(t, []string{"/: expected integer or null, but got number"}, reasons(validateSchema(sp, 1.5)))
+	assert.Equal(t, []string{"/: expected integer or null, but got boolean"}, reasons(validateSchema(sp, true)))
+
+	sp = buildSchemaProxy(t, `type: string
+nullable: true`)
+	assert.Empty(t, validateSchema(sp, nil))
+	assert.Equal(t, []string{"/: expected string, but got object"},
+		reasons(validateSchema(sp, map[string]any{})))
+}
+
+func TestValidateSchema_Numbers(t *testing.T) {
+	sp := buildSchemaProxy(t, `type: number
+multipleOf: 0.01
+minimum: 0
+maximum: 10
+exclusiveMaximum: true`)
+	assert.Empty(t, validateSchema(sp, json.Number("9.99")))
+	assert.Equal(t, []string{"/: 1.005 is not a multiple of 0.01"}, reasons(validateSchema(sp, 1.005)))
+	assert.Equal(t, []string{"/: must be < 10, but got 10"}, reasons(validateSchema(sp, 10)))
+	assert.Equal(t, []string{"/: must be >= 0, but got -1"}, reasons(validateSchema(sp, -1)))
+
+	sp = buildSchemaProxy(t, `exclusiveMinimum: 1
+exclusiveMaximum: 5`)
+	assert.Empty(t, validateSchema(sp, 3))
+	assert.Equal(t, []string{"/: must be > 1, but got 1"}, reasons(validateSchema(sp, 1)))
+	assert.Equal(t, []string{"/: must be < 5, but got 5"}, reasons(validateSchema(sp, 5)))
+}
+
+func TestValidateSchema_Strings(t *testing.T) {
+	sp := buildSchemaProxy(t, `type: string
+minLength: 2
+maxLength: 3
+pattern: ^[a-zé]+$`)
+	assert.Empty(t, validateSchema(sp, "éé"))
+	assert.Equal(t, []string{"/: length must be >= 2, but got 1"}, reasons(validateSchema(sp, "a")))
+	assert.Equal(t, []string{
+		"/: length must be <= 3, but got 4",
+		"/: value does not match pattern '^[a-zé]+$'",
+	}, reasons(validateSchema(sp, "ABCD")))
+}
+
+func TestValidateSchema_EnumAndConst(t *testing.T) {
+	sp := buildSchemaProxy(t, `enum: [1, pizza, true]`)
+	assert.Empty(t, validateSchema(sp, json.Number("1")))
+	assert.Empty(t, validateSchema(sp, "pizza"))
+	assert.Empty(t, validateSchema(sp, true))
+	assert.Equal(t, []string{`/: value must be one of [1, "pizza", true]`},
+		reasons(validateSchema(sp, "burger")))
+
+	sp = buildSchemaProxy(t, `const: pizza`)
+	assert.Empty(t, validateSchema(sp, "pizza"))
+	assert.Equal(t, []string{`/: value must be "pizza"`}, reasons(validateSchema(sp, "burger")))
+
+	sp = buildSchemaProxy(t, `const: null`)
+	assert.Empty(t, validateSchema(sp, nil))
+	assert.Len(t, validateSchema(sp, "pizza"), 1)
+}
+
+func TestValidateSchema_Arrays(t *testing.T) {
+	sp := buildSchemaProxy(t, `type: array
+minItems: 1
+maxItems: 3
+uniqueItems: true
+items:
+  type: integer`)
+	assert.Empty(t, validateSchema(sp, []any{1, 2}))
+	assert.Equal(t, []string{"/: must have at least 1 items, but got 0"}, reasons(validateSchema(sp, []any{})))
+	assert.Equal(t, []string{
+		"/1: expected integer, but got string",
+		"/: must have at most 3 items, but got 4",
+		"/: items at index 0 and 2 are equal",
+	}, reasons(validateSchema(sp, []any{1, "2", json.Number("1"), 4})))
+}
+
+func TestValidateSchema_Objects(t *testing.T) {
+	sp := buildSchemaProxy(t, `type: object
+required: [name]
+minProperties: 1
+maxProperties: 2
+properties:
+  name:
+    type: string
+  a/b:
+    type: integer
+additionalProperties: false`)
+	assert.Empty(t, validateSchema(sp, map[string]any{"name": "pizza"}))
+
+	failures := validateSchema(sp, map[string]any{"a/b": "x", "extra": true, "more": 1})
+	assert.Equal(t, []string{
+		"/: missing required property 'name'",
+		"/: must have at most 2 properties, but got 3",
+		"/a~1b: expected integer, but got string",
+		"/: property 'extra' is not allowed",
+		"/: property 'more' is not allowed",
+	}, reasons(failures))
+	assert.Equal(t, "/properties/a~1b/type", failures[2].SchemaLocation)
+	assert.Equal(t, 9, failures[2].Line)
+	assert.Equal(t, 10, failures[3].Line)
+
+	sp = buildSchemaProxy(t, `additionalProperties:
+  type: integer`)
+	assert.Equal(t, []string{"/pizza: expected integer, but got string"},
+		reasons(validateSchema(sp, map[string]any{"pizza": "one", "burger": 2})))
+}
+
+func TestValidateSchema_Composition(t *testing.T) {
+	sp := buildSchemaProxy(t, `allOf:
+  - minimum: 1
+  - maximum: 10
+anyOf:
+  - type: integer
+  - minimum: 5
+oneOf:
+  - multipleOf: 2
+  - multipleOf: 3
+not:
+  const: 7`)
+	assert.Empty(t, validateSchema(sp, 4))
+	assert.Equal(t, []string{"/: value matches more than one schema in oneOf (0, 1), it must match exactly one"},
+		reasons(validateSchema(sp, 6)))
+	assert.Equal(t, []string{
+		"/: value does not match any of the schemas in oneOf",
+		"/: value must not match the schema in not",
+	}, reasons(validateSchema(sp, 7)))
+	assert.Equal(t, []string{
+		"/: must be <= 10, but got 11",
+		"/: value does not match any of the schemas in oneOf",
+	}, reasons(validateSchema(sp, 11)))
+	assert.Equal(t, []string{
+		"/: must be >= 1, but got 0.5",
+		"/: value does not match any of the schemas in anyOf",
+		"/: value does not match any of the schemas in oneOf",
+	}, reasons(validateSchema(sp, 0.5)))
+
+	failures := validateSchema(sp, 11)
+	assert.Equal(t, "/allOf/1/maximum", failures[0].SchemaLocation)
+	assert.Equal(t, 3, failures[0].Line)
+}
+
+func TestValidateSchema_Nil(t *testing.T) {
+	assert.Nil(t, validateSchema(nil, "pizza"))
+}
@@ -0,0 +1,108 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/pb33f/libopenapi/datamodel/low"
+	lowbase "github.com/pb33f/libopenapi/datamodel/low/base"
+	"github.com/pb33f/libopenapi/index"
+)
+
+// SchemaValidator validates values against a *base.SchemaProxy, without the need for a request or a response.
+//
+// Every keyword modeled by base.Schema is supported, including the 3.1 keywords (prefixItems, if / then / else,
+// patternProperties, unevaluatedProperties and friends), the 3.0 'nullable' keyword, discriminators and common
+// formats. References ($ref) are resolved using the index of the document the schema was built from, or the
+// index supplied to NewSchemaValidatorWithIndex.
+type SchemaValidator struct {
+	index *index.SpecIndex
+}
+
+// NewSchemaValidator creates a new SchemaValidator. References are resolved using the index of the document
+// each schema was built from.
+func NewSchemaValidator() *SchemaValidator {
+	return &SchemaValidator{}
+}
+
+// NewSchemaValidatorWithIndex creates a new SchemaValidator that resolves references using a *index.SpecIndex.
+// Use this for schemas that were not built as part of a document, for example a schema built by hand from a
+// *yaml.Node, that contains references to another document.
+func NewSchemaValidatorWithIndex(idx *index.SpecIndex) *SchemaValidator {
+	return &SchemaValidator{index: idx}
+}
+
+// ValidateSchema validates a value against a schema, and returns every violation found. An empty slice means
+// the value is valid.
+//
+// The value can be anything that can be marshaled into JSON, for example a map[string]any, a slice or a struct.
+func (s *SchemaValidator) ValidateSchema(schema *base.SchemaProxy, value any) []*SchemaValidationFailure {
+	if schema == nil {
+		return nil
+	}
+	normalized, err := normalizeValue(value)
+	if err != nil {
+		return []*SchemaValidationFailure{{Reason: fmt.Sprintf("value cannot be converted into JSON: %s", err.Error())}}
+	}
+	return validateSchema(s.bind(schema), normalized)
+}
+
+// ValidateSchemaJSON decodes JSON and validates it against a schema. An error is returned if the JSON cannot
+// be decoded.
+func (s *SchemaValidator) ValidateSchemaJSON(schema *base.SchemaProxy, data []byte) ([]*SchemaValidationFailure, error) {
+	var value any
+	if err := decodeJSON(data, &value); err != nil {
+		return nil, fmt.Errorf("unable to decode JSON: %s", err.Error())
+	}
+	if schema == nil {
+		return nil, nil
+	}
+	return validateSchema(s.bind(schema), value), nil
+}
+
+// bind rebuilds a schema using the index of the validator, so references can be resolved.
+func (s *SchemaValidator) bind(schema *base.SchemaProxy) *base.SchemaProxy {
+	if s.index == nil || schema.GoLow() == nil || schema.GoLow().GetValueNode() == nil {
+		return schema
+	}
+	node := schema.GoLow().GetValueNode()
+	sp := new(lowbase.SchemaProxy)
+	_ = sp.Build(node, s.index)
+	return base.NewSchemaProxy(&low.NodeReference[*lowbase.SchemaProxy]{Value: sp, ValueNode: node})
+}
+
+// normalizeValue converts a value into plain JSON values, using a round trip through encoding/json.
+func normalizeValue(value any) (any, error) {
+	switch value.(type) {
+	case nil, bool, string, json.Number, float64, int, int64:
+		return value, nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var normalized any
+	err = decodeJSON(data, &normalized)
+	return normalized, err
+}
+
+// decodeJSON decodes JSON, keeping numbers as json.Number so they are not rounded. Anything after the JSON value
+// (other than whitespace) is an error.
+func decodeJSON(data []byte, value *any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(value); err != nil {
+		return err
+	}
+	if err := decoder.Decode(new(any)); err != io.EOF {
+		return errors.New("unexpected data after the JSON value")
+	}
+	return nil
+}
@@ -0,0 +1,306 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package validator
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/index"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+var discriminatorSpec = `openapi: 3.1.0
+info:
+  title: pets
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Pet:
+      oneOf:
+        - $ref: '#/components/schemas/Cat'
+        - $ref: '#/components/schemas/Dog'
+      discriminator:
+        propertyName: kind
+        mapping:
+          doggo: '#/components/schemas/Dog'
+    Animal:
+      oneOf:
+        - $ref: '#/components/schemas/Cat'
+        - type: object
+          required: [kind, fins]
+          properties:
+            kind:
+              const: Fish
+      anyOf:
+        - required: [name]
+        - required: [nickname]
+      discriminator:
+        propertyName: kind
+    Cat:
+      type: object
+      required: [kind, lives]
+      properties:
+        kind:
+          type: string
+        lives:
+          type: integer
+    Dog:
+      type: object
+      required: [kind, bark]
+      properties:
+        kind:
+          type: string
+        bark:
+          type: string`
+
+func TestSchemaValidator_Discriminator(t *testing.T) {
+	v := newTestValidator(t, discriminatorSpec)
+	pet := v.document.Components.Schemas.GetOrZero("Pet")
+	sv := NewSchemaValidator()
+
+	// a cat could also be a dog (and vice versa) without the discriminator, as neither is closed.
+	failures, err := sv.ValidateSchemaJSON(pet, []byte(`{"kind":"Cat","lives":9}`))
+	assert.NoError(t, err)
+	assert.Empty(t, failures)
+	assert.Empty(t, sv.ValidateSchema(pet, map[string]any{"kind": "doggo", "bark": "woof"}))
+
+	failures = sv.ValidateSchema(pet, map[string]any{"kind": "Cat", "bark": "woof"})
+	assert.Equal(t, []string{"/: missing required property 'lives'"}, reasons(failures))
+	assert.Equal(t, "/oneOf/0/required", failures[0].SchemaLocation)
+
+	assert.Equal(t, []string{"/: discriminator value 'Fish' does not match any of the schemas in oneOf"},
+		reasons(sv.ValidateSchema(pet, map[string]any{"kind": "Fish"})))
+	assert.Equal(t, []string{"/: missing discriminator property 'kind'"},
+		reasons(sv.ValidateSchema(pet, map[string]any{})))
+	assert.Equal(t, []string{"/: discriminator property 'kind' must be a string, but got number"},
+		reasons(sv.ValidateSchema(pet, map[string]any{"kind": 1})))
+}
+
+func TestSchemaValidator_DiscriminatorInline(t *testing.T) {
+	v := newTestValidator(t, discriminatorSpec)
+	animal := v.document.Components.Schemas.GetOrZero("Animal")
+	sv := NewSchemaValidator()
+
+	// the discriminator picks the cat, anyOf is still evaluated.
+	assert.Empty(t, sv.ValidateSchema(animal, map[string]any{"kind": "Cat", "lives": 9, "name": "tom"}))
+	assert.Equal(t, []string{"/: value does not match any of the schemas in anyOf"},
+		reasons(sv.ValidateSchema(animal, map[string]any{"kind": "Cat", "lives": 9})))
+
+	// a fish is not a reference, so oneOf is evaluated as usual.
+	assert.Empty(t, sv.ValidateSchema(animal, map[string]any{"kind": "Fish", "fins": 2, "nickname": "nemo"}))
+	assert.Equal(t, []string{"/: value does not match any of the schemas in oneOf"},
+		reasons(sv.ValidateSchema(animal, map[string]any{"kind": "Fish", "name": "nemo"})))
+}
+
+func TestSchemaValidator_WithIndex(t *testing.T) {
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte(discriminatorSpec), &root)
+	idx := index.NewSpecIndexWithConfig(&root, index.CreateOpenAPIIndexConfig())
+
+	sp := buildSchemaProxy(t, `type: array
+items:
+  $ref: '#/components/schemas/Cat'`)
+
+	// without an index, the reference cannot be found.
+	failures := NewSchemaValidator().ValidateSchema(sp, []any{})
+	assert.Len(t, failures, 1)
+	assert.Contains(t, failures[0].Reason, "unable to build schema")
+
+	sv := NewSchemaValidatorWithIndex(idx)
+	assert.Empty(t, sv.ValidateSchema(sp, []any{map[string]any{"kind": "Cat", "lives": 9}}))
+	assert.Equal(t, []string{"/0/lives: expected integer, but got string"},
+		reasons(sv.ValidateSchema(sp, []any{map[string]any{"kind": "Cat", "lives": "nine"}})))
+}
+
+func TestSchemaValidator_IfThenElse(t *testing.T) {
+	sp := buildSchemaProxy(t, `type: object
+if:
+  properties:
+    country:
+      const: US
+then:
+  properties:
+    postcode:
+      pattern: ^[0-9]{5}$
+else:
+  properties:
+    postcode:
+      minLength: 6`)
+	sv := NewSchemaValidator()
+	assert.Empty(t, sv.ValidateSchema(sp, map[string]any{"country": "US", "postcode": "90210"}))
+	assert.Empty(t, sv.ValidateSchema(sp, map[string]any{"country": "UK", "postcode": "SW1A 1AA"}))
+
+	failures := sv.ValidateSchema(sp, map[string]any{"country": "US", "postcode": "SW1A 1AA"})
+	assert.Equal(t, []string{"/postcode: value does not match pattern '^[0-9]{5}$'"}, reasons(failures))
+	assert.Equal(t, "/then/properties/postcode/pattern", failures[0].SchemaLocation)
+
+	failures = sv.ValidateSchema(sp, map[string]any{"country": "UK", "postcode": "123"})
+	assert.Equal(t, "/else/properties/postcode/minLength", failures[0].SchemaLocation)
+
+	// a missing property does not fail 'if', so 'then' is used.
+	failures = sv.ValidateSchema(sp, map[string]any{"postcode": "123"})
+	assert.Equal(t, "/then/properties/postcode/pattern", failures[0].SchemaLocation)
+}
+
+func TestSchemaValidator_PrefixItems(t *testing.T) {
+	sp := buildSchemaProxy(t, `type: array
+prefixItems:
+  - type: number
+  - type: string
+items: false`)
+	sv := NewSchemaValidator()
+	assert.Empty(t, sv.ValidateSchema(sp, []any{1, "a"}))
+	assert.Empty(t, sv.ValidateSchema(sp, []any{1}))
+	assert.Equal(t, []string{
+		"/1: expected string, but got number",
+		"/: must have at most 2 items, but got 3",
+	}, reasons(sv.ValidateSchema(sp, []any{1, 2, 3})))
+
+	sp = buildSchemaProxy(t, `prefixItems:
+  - type: number
+items:
+  type: string`)
+	assert.Equal(t, []string{"/2: expected string, but got number"},
+		reasons(sv.ValidateSchema(sp, []any{1, "a", 3})))
+}
+
+func TestSchemaValidator_Contains(t *testing.T) {
+	sp := buildSchemaProxy(t, `contains:
+  type: string
+minContains: 2
+maxContains: 3`)
+	sv := NewSchemaValidator()
+	assert.Empty(t, sv.ValidateSchema(sp, []any{"a", 1, "b"}))
+	assert.Equal(t, []string{"/: must contain at least 2 matching items, but got 1"},
+		reasons(sv.ValidateSchema(sp, []any{"a", 1})))
+	assert.Equal(t, []string{"/: must contain at most 3 matching items, but got 4"},
+		reasons(sv.ValidateSchema(sp, []string{"a", "b", "c", "d"})))
+}
+
+func TestSchemaValidator_UnevaluatedProperties(t *testing.T) {
+	sp := buildSchemaProxy(t, `allOf:
+  - properties:
+      name:
+        type: string
+properties:
+  age:
+    type: integer
+patternProperties:
+  ^x-:
+    type: string
+unevaluatedProperties: false`)
+	sv := NewSchemaValidator()
+	assert.Empty(t, sv.ValidateSchema(sp, map[string]any{"name": "fido", "age": 3, "x-tag": "dog"}))
+	failures := sv.ValidateSchema(sp, map[string]any{"name": "fido", "colour": "brown", "x-tag": 1})
+	assert.Equal(t, []string{
+		"/x-tag: expected string, but got number",
+		"/: property 'colour' is not allowed",
+	}, reasons(failures))
+	assert.Equal(t, "/unevaluatedProperties", failures[1].SchemaLocation)
+	assert.Equal(t, 11, failures[1].Line)
+
+	sp = buildSchemaProxy(t, `prefixItems:
+  - type: string
+unevaluatedItems:
+  type: integer`)
+	assert.Equal(t, []string{"/2: expected integer, but got string"},
+		reasons(sv.ValidateSchema(sp, []any{"a", 1, "b"})))
+}
+
+func TestSchemaValidator_PatternAndAdditionalProperties(t *testing.T) {
+	sp := buildSchemaProxy(t, `type: object
+propertyNames:
+  maxLength: 5
+patternProperties:
+  ^[0-9]+$:
+    type: integer
+additionalProperties:
+  minLength: 2`)
+	sv := NewSchemaValidator()
+	assert.Empty(t, sv.ValidateSchema(sp, map[string]any{"1": 1, "name": "ab"}))
+	assert.Equal(t, []string{
+		"/1: expected integer, but got string",
+		"/name: length must be >= 2, but got 1",
+		"/: property name 'toolong' is not valid: length must be <= 5, but got 7",
+	}, reasons(sv.ValidateSchema(sp, map[string]any{"1": "one", "name": "a", "toolong": "abc"})))
+}
+
+func TestSchemaValidator_Dependencies(t *testing.T) {
+	sp := buildSchemaProxy(t, `dependentRequired:
+  card: [billing]
+dependentSchemas:
+  billing:
+    required: [postcode]`)
+	sv := NewSchemaValidator()
+	assert.Empty(t, sv.ValidateSchema(sp, map[string]any{"card": 1, "billing": 1, "postcode": 1}))
+	assert.Equal(t, []string{"/: missing property 'billing', it is required when 'card' is present"},
+		reasons(sv.ValidateSchema(sp, map[string]any{"card": 1})))
+	assert.Equal(t, []string{"/: missing required property 'postcode'"},
+		reasons(sv.ValidateSchema(sp, map[string]any{"billing": 1})))
+}
+
+func TestSchemaValidator_Nullable(t *testing.T) {
+	sp := buildSchemaProxy(t, `type: object
+properties:
+  name:
+    type: string
+    nullable: true
+  age:
+    type: integer`)
+	sv := NewSchemaValidator()
+
+	failures, err := sv.ValidateSchemaJSON(sp, []byte(`{"name":null,"age":null}`))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/age: expected integer, but got null"}, reasons(failures))
+}
+
+func TestSchemaValidator_Formats(t *testing.T) {
+	sp := buildSchemaProxy(t, `type: object
+properties:
+  id:
+    type: string
+    format: uuid
+  when:
+    type: string
+    format: date-time
+  count:
+    type: integer
+    format: int32`)
+	sv := NewSchemaValidator()
+
+	failures, err := sv.ValidateSchemaJSON(sp,
+		[]byte(`{"id":"nope","when":"2023-01-01T10:00:00Z","count":2147483648}`))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"/count: value is not a valid 'int32'",
+		"/id: value is not a valid 'uuid'",
+	}, reasons(failures))
+}
+
+func TestSchemaValidator_Values(t *testing.T) {
+	type pet struct {
+		Name string `json:"name"`
+		Age  int    `json:"age"`
+	}
+	sp := buildSchemaProxy(t, `type: object
+properties:
+  name:
+    minLength: 1
+  age:
+    minimum: 1`)
+	sv := NewSchemaValidator()
+	assert.Empty(t, sv.ValidateSchema(sp, pet{Name: "fido", Age: 3}))
+	assert.Len(t, sv.ValidateSchema(sp, &pet{}), 2)
+	assert.Len(t, sv.ValidateSchema(sp, make(chan int)), 1)
+	assert.Nil(t, sv.ValidateSchema(nil, "pizza"))
+
+	_, err := sv.ValidateSchemaJSON(sp, []byte(`{`))
+	assert.Error(t, err)
+	_, err = sv.ValidateSchemaJSON(sp, []byte(`{"name":"fido"} []`))
+	assert.Error(t, err)
+	_, err = sv.ValidateSchemaJSON(sp, []byte("{\"name\":\"fido\"}\n"))
+	assert.NoError(t, err)
+}
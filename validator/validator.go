@@ -0,0 +1,66 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+// Package validator validates HTTP requests and responses against an OpenAPI 3+ document.
+//
+// A request is matched to a PathItem and Operation (using path templates, servers and the request method), and
+// then the parameters, request body and responses are checked against the schemas defined by the operation.
+// Every problem found is returned as a *ValidationError, which points back to the line and column of the
+// specification that the request or response failed against.
+//
+// Values can also be validated against a single schema, without a request or a response, using a SchemaValidator.
+package validator
+
+import (
+	"net/http"
+
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+// Validator validates requests and responses against a high-level OpenAPI 3+ Document.
+type Validator struct {
+	document  *v3.Document
+	templates map[string]*compiledTemplate
+}
+
+// NewValidator creates a new Validator for a v3.Document. The path templates of the document are compiled up
+// front, so requests can be matched without compiling anything.
+func NewValidator(document *v3.Document) *Validator {
+	v := &Validator{document: document, templates: make(map[string]*compiledTemplate)}
+	if document != nil && document.Paths != nil {
+		for _, key := range document.Paths.PathItems.Keys() {
+			v.templates[key] = compilePathTemplate(key)
+		}
+	}
+	return v
+}
+
+// ValidateRequest will validate an *http.Request against the document. The request is matched to an operation,
+// then its path, query, header and cookie parameters and its body are validated. An empty slice means the
+// request is valid.
+//
+// If the request has a body, it is read and then replaced, so it can still be read by whatever handles the
+// request next.
+func (v *Validator) ValidateRequest(request *http.Request) []*ValidationError {
+	pathItem, operation, pathTemplate, errs := v.findOperation(request)
+	if len(errs) > 0 {
+		return errs
+	}
+	errs = append(errs, validateParameters(request, pathItem, operation, v.pathTemplate(pathTemplate),
+		v.stripBasePath(request.URL.EscapedPath(), pathItem))...)
+	errs = append(errs, validateRequestBody(request, operation)...)
+	return errs
+}
+
+// ValidateResponse will validate an *http.Response against the operation matched by the *http.Request that
+// created it. The status code, response headers and response body are validated. An empty slice means the
+// response is valid.
+//
+// If the response has a body, it is read and then replaced, so it can still be read by the caller.
+func (v *Validator) ValidateResponse(request *http.Request, response *http.Response) []*ValidationError {
+	_, operation, _, errs := v.findOperation(request)
+	if len(errs) > 0 {
+		return errs
+	}
+	return validateResponse(operation, response)
+}
@@ -0,0 +1,344 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package validator
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+var petstore = `openapi: 3.1.0
+info:
+  title: pets
+  version: 1.0.0
+servers:
+  - url: https://{region}.pets.com/{base}
+    variables:
+      region:
+        default: eu
+      base:
+        default: api/v1
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+            maximum: 100
+        - name: tags
+          in: query
+          schema:
+            type: array
+            items:
+              type: string
+      responses:
+        '200':
+          description: pets
+          headers:
+            X-Rate-Limit:
+              required: true
+              schema:
+                type: integer
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        '201':
+          description: created
+        4XX:
+          description: bad
+          content:
+            application/problem+json:
+              schema:
+                type: object
+                required: [title]
+  /pets/{petId}:
+    parameters:
+      - name: petId
+        in: path
+        required: true
+        schema:
+          type: integer
+    get:
+      parameters:
+        - name: X-Request-Id
+          in: header
+          required: true
+          schema:
+            type: string
+            format: uuid
+        - name: session
+          in: cookie
+          schema:
+            type: string
+            minLength: 3
+      responses:
+        default:
+          description: pet
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+  /pets/mine:
+    get:
+      responses:
+        '200':
+          description: mine
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+          minLength: 1
+        age:
+          type: integer
+          minimum: 0`
+
+func newTestValidator(t *testing.T, spec string) *Validator {
+	doc, err := libopenapi.NewDocument([]byte(spec))
+	assert.NoError(t, err)
+	model, errs := doc.BuildV3Model()
+	assert.Empty(t, errs)
+	return NewValidator(&model.Model)
+}
+
+func TestValidator_ValidateRequest_Valid(t *testing.T) {
+	v := newTestValidator(t, petstore)
+
+	req := httptest.NewRequest(http.MethodGet, "https://eu.pets.com/api/v1/pets?limit=10&tags=a&tags=b", nil)
+	assert.Empty(t, v.ValidateRequest(req))
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/pets", strings.NewReader(`{"name":"fido","age":3}`))
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	assert.Empty(t, v.ValidateRequest(req))
+
+	// the body can still be read after validation.
+	b, _ := io.ReadAll(req.Body)
+	assert.Equal(t, `{"name":"fido","age":3}`, string(b))
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/pets/mine", nil)
+	assert.Empty(t, v.ValidateRequest(req))
+}
+
+func TestValidator_ValidateRequest_PathNotFound(t *testing.T) {
+	v := newTestValidator(t, petstore)
+
+	errs := v.ValidateRequest(httptest.NewRequest(http.MethodGet, "/api/v1/cats", nil))
+	assert.Len(t, errs, 1)
+	assert.Equal(t, PathValidation, errs[0].ValidationType)
+	assert.Equal(t, "GET path '/api/v1/cats' not found", errs[0].Message)
+
+	errs = v.ValidateRequest(httptest.NewRequest(http.MethodDelete, "/api/v1/pets", nil))
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "DELETE operation not found for path '/pets'", errs[0].Message)
+	assert.Equal(t, 13, errs[0].SpecLine)
+	assert.Equal(t, 3, errs[0].SpecCol)
+}
+
+func TestValidator_ValidateRequest_MethodOnTemplatedPath(t *testing.T) {
+	v := newTestValidator(t, `openapi: 3.1.0
+paths:
+  /pets/mine:
+    get:
+      responses:
+        '200':
+          description: mine
+  /pets/{id}:
+    post:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        '200':
+          description: updated`)
+
+	// '/pets/mine' is the better match, but only '/pets/{id}' defines post.
+	assert.Empty(t, v.ValidateRequest(httptest.NewRequest(http.MethodPost, "/pets/mine", nil)))
+	assert.Empty(t, v.ValidateRequest(httptest.NewRequest(http.MethodGet, "/pets/mine", nil)))
+
+	errs := v.ValidateRequest(httptest.NewRequest(http.MethodDelete, "/pets/mine", nil))
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "DELETE operation not found for path '/pets/mine'", errs[0].Message)
+}
+
+func TestValidator_ValidateRequest_EscapedPath(t *testing.T) {
+	v := newTestValidator(t, `openapi: 3.1.0
+paths:
+  /files/{name}:
+    get:
+      parameters:
+        - name: name
+          in: Path
+          required: true
+          schema:
+            const: a/b%20c
+        - name: version
+          in: Query
+          required: true
+          schema:
+            type: integer
+      responses:
+        '200':
+          description: file`)
+
+	// an escaped '/' does not split the segment, and values are only unescaped once.
+	errs := v.ValidateRequest(httptest.NewRequest(http.MethodGet, "/files/a%2Fb%2520c?version=1", nil))
+	assert.Empty(t, errs)
+
+	errs = v.ValidateRequest(httptest.NewRequest(http.MethodGet, "/files/a/b%2520c?version=1", nil))
+	assert.Len(t, errs, 1)
+	assert.Equal(t, PathValidation, errs[0].ValidationType)
+
+	errs = v.ValidateRequest(httptest.NewRequest(http.MethodGet, "/files/a%2Fb%20c", nil))
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "path", errs[0].ValidationSubType)
+	assert.Equal(t, "path parameter 'name' failed to validate", errs[0].Message)
+	assert.Equal(t, "query", errs[1].ValidationSubType)
+	assert.Equal(t, "query parameter 'version' is missing", errs[1].Message)
+}
+
+func TestValidator_ValidateRequest_Parameters(t *testing.T) {
+	v := newTestValidator(t, petstore)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/pets/abc", nil)
+	req.AddCookie(&http.Cookie{Name: "session", Value: "ab"})
+	errs := v.ValidateRequest(req)
+	assert.Len(t, errs, 3)
+
+	assert.Equal(t, ParameterValidation, errs[0].ValidationType)
+	assert.Equal(t, "path", errs[0].ValidationSubType)
+	assert.Equal(t, "path parameter 'petId' failed to validate", errs[0].Message)
+	assert.Equal(t, 60, errs[0].SpecLine)
+	assert.Equal(t, "expected integer, but got string", errs[0].SchemaFailures[0].Reason)
+	assert.Equal(t, 64, errs[0].SchemaFailures[0].Line)
+
+	assert.Equal(t, "header parameter 'X-Request-Id' is missing", errs[1].Message)
+	assert.Equal(t, "cookie parameter 'session' failed to validate", errs[2].Message)
+
+	errs = v.ValidateRequest(httptest.NewRequest(http.MethodGet, "/api/v1/pets?limit=101", nil))
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "the query parameter 'limit' does not match the schema: must be <= 100, but got 101",
+		errs[0].Reason)
+}
+
+func TestValidator_ValidateRequest_Body(t *testing.T) {
+	v := newTestValidator(t, petstore)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/pets", nil)
+	errs := v.ValidateRequest(req)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "POST request body is missing", errs[0].Message)
+	assert.Equal(t, 42, errs[0].SpecLine)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/pets", strings.NewReader(`<pet/>`))
+	req.Header.Set("Content-Type", "application/xml")
+	errs = v.ValidateRequest(req)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "POST request body has an unsupported content type 'application/xml'", errs[0].Message)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/pets", strings.NewReader(`{"name":`))
+	req.Header.Set("Content-Type", "application/json")
+	errs = v.ValidateRequest(req)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "POST request body cannot be decoded", errs[0].Message)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/pets", strings.NewReader(`{"name":"fido"} {"name":"rex"}`))
+	req.Header.Set("Content-Type", "application/json")
+	errs = v.ValidateRequest(req)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "POST request body cannot be decoded", errs[0].Message)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/pets", strings.NewReader(`{"name":"","age":-1}`))
+	req.Header.Set("Content-Type", "application/json")
+	errs = v.ValidateRequest(req)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, RequestBodyValidation, errs[0].ValidationType)
+	assert.Len(t, errs[0].SchemaFailures, 2)
+	assert.Equal(t, "/age", errs[0].SchemaFailures[0].Location)
+	assert.Equal(t, "/properties/age/minimum", errs[0].SchemaFailures[0].SchemaLocation)
+	assert.Equal(t, 101, errs[0].SchemaFailures[0].Line)
+	assert.Equal(t, "/name", errs[0].SchemaFailures[1].Location)
+	assert.Equal(t, 98, errs[0].SchemaFailures[1].Line)
+}
+
+func TestValidator_ValidateResponse(t *testing.T) {
+	v := newTestValidator(t, petstore)
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/pets", nil)
+
+	newResponse := func(code int, contentType, body string) *http.Response {
+		res := &http.Response{
+			StatusCode: code,
+			Header:     http.Header{},
+			Body:       io.NopCloser(bytes.NewReader([]byte(body))),
+		}
+		if contentType != "" {
+			res.Header.Set("Content-Type", contentType)
+		}
+		return res
+	}
+
+	res := newResponse(200, "application/json", `[{"name":"fido"}]`)
+	res.Header.Set("X-Rate-Limit", "10")
+	assert.Empty(t, v.ValidateResponse(req, res))
+
+	res = newResponse(200, "application/json", `[{"age":"old"}]`)
+	errs := v.ValidateResponse(req, res)
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "response header 'X-Rate-Limit' is missing", errs[0].Message)
+	assert.Equal(t, "200 response body failed to validate", errs[1].Message)
+	assert.Equal(t, 28, errs[1].SpecLine)
+	assert.Len(t, errs[1].SchemaFailures, 2)
+
+	errs = v.ValidateResponse(req, newResponse(500, "", ""))
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "response code 500 is not defined", errs[0].Message)
+	assert.Equal(t, 27, errs[0].SpecLine)
+
+	// ranges and defaults
+	post := httptest.NewRequest(http.MethodPost, "/api/v1/pets", nil)
+	errs = v.ValidateResponse(post, newResponse(404, "application/problem+json", `{}`))
+	assert.Len(t, errs, 1)
+	assert.Equal(t, 51, errs[0].SpecLine)
+
+	get := httptest.NewRequest(http.MethodGet, "/api/v1/pets/1", nil)
+	assert.Empty(t, v.ValidateResponse(get, newResponse(200, "application/json", `{"name":"fido"}`)))
+	errs = v.ValidateResponse(get, newResponse(200, "text/plain", `fido`))
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "response body has an unsupported content type 'text/plain'", errs[0].Message)
+
+	assert.Len(t, v.ValidateResponse(get, nil), 1)
+}
+
+func TestValidator_NoPaths(t *testing.T) {
+	v := NewValidator(&v3.Document{})
+	errs := v.ValidateRequest(httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "no paths to validate against", errs[0].Message)
+}
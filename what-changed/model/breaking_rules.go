@@ -0,0 +1,208 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// BreakingRule overrides the breaking classification of changes made to a property. A nil value leaves the
+// classification of that kind of change alone.
+type BreakingRule struct {
+	// Added applies to a property or object being added.
+	Added *bool `json:"added,omitempty" yaml:"added,omitempty"`
+
+	// Modified applies to the value of a property being changed.
+	Modified *bool `json:"modified,omitempty" yaml:"modified,omitempty"`
+
+	// Removed applies to a property or object being removed.
+	Removed *bool `json:"removed,omitempty" yaml:"removed,omitempty"`
+
+	// Renamed applies to an object being renamed or moved.
+	Renamed *bool `json:"renamed,omitempty" yaml:"renamed,omitempty"`
+}
+
+// BreakingRules overrides the breaking classification of changes, keyed by object type (like 'schema' or
+// 'operation') and then by property (like 'enum' or 'description'). Either key can be '*' to match anything,
+// a rule for an object type and property wins over a rule for the object type and '*', which wins over a rule
+// for '*' and the property.
+//
+// For example, the following rules make adding an enum value to a schema breaking, and make removing a
+// description never breaking:
+//
+//	schema:
+//	  enum:
+//	    added: true
+//	'*':
+//	  description:
+//	    removed: false
+//
+// See BreakingRuleObjectTypes for the object types that can be used.
+type BreakingRules map[string]map[string]*BreakingRule
+
+// breakingRuleObjectTypes are the object types rules can be keyed by, for each type of change model.
+var breakingRuleObjectTypes = map[reflect.Type]string{
+	reflect.TypeOf(CallbackChanges{}):            "callback",
+	reflect.TypeOf(ComponentsChanges{}):          "components",
+	reflect.TypeOf(ContactChanges{}):             "contact",
+	reflect.TypeOf(DiscriminatorChanges{}):       "discriminator",
+	reflect.TypeOf(DocumentChanges{}):            "document",
+	reflect.TypeOf(EncodingChanges{}):            "encoding",
+	reflect.TypeOf(ExampleChanges{}):             "example",
+	reflect.TypeOf(ExamplesChanges{}):            "examples",
+	reflect.TypeOf(ExtensionChanges{}):           "extensions",
+	reflect.TypeOf(ExternalDocChanges{}):         "externalDocs",
+	reflect.TypeOf(HeaderChanges{}):              "header",
+	reflect.TypeOf(InfoChanges{}):                "info",
+	reflect.TypeOf(ItemsChanges{}):               "items",
+	reflect.TypeOf(LicenseChanges{}):             "license",
+	reflect.TypeOf(LinkChanges{}):                "link",
+	reflect.TypeOf(MediaTypeChanges{}):           "mediaType",
+	reflect.TypeOf(OAuthFlowsChanges{}):          "oauthFlows",
+	reflect.TypeOf(OAuthFlowChanges{}):           "oauthFlow",
+	reflect.TypeOf(OperationChanges{}):           "operation",
+	reflect.TypeOf(ParameterChanges{}):           "parameter",
+	reflect.TypeOf(PathItemChanges{}):            "pathItem",
+	reflect.TypeOf(PathsChanges{}):               "paths",
+	reflect.TypeOf(RequestBodyChanges{}):         "requestBody",
+	reflect.TypeOf(ResponseChanges{}):            "response",
+	reflect.TypeOf(ResponsesChanges{}):           "responses",
+	reflect.TypeOf(SchemaChanges{}):              "schema",
+	reflect.TypeOf(ScopesChanges{}):              "scopes",
+	reflect.TypeOf(SecurityRequirementChanges{}): "securityRequirement",
+	reflect.TypeOf(SecuritySchemeChanges{}):      "securityScheme",
+	reflect.TypeOf(ServerChanges{}):              "server",
+	reflect.TypeOf(ServerVariableChanges{}):      "serverVariable",
+	reflect.TypeOf(TagChanges{}):                 "tag",
+	reflect.TypeOf(XMLChanges{}):                 "xml",
+}
+
+// BreakingRuleObjectTypes returns the object types that BreakingRules can be keyed by, sorted by name.
+func BreakingRuleObjectTypes() []string {
+	var types []string
+	for _, t := range breakingRuleObjectTypes {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// LoadBreakingRules parses BreakingRules from YAML (or JSON). An error is returned if the rules can't be parsed,
+// or if they use an object type that does not exist.
+func LoadBreakingRules(rulesBytes []byte) (BreakingRules, error) {
+	var rules BreakingRules
+	if err := yaml.Unmarshal(rulesBytes, &rules); err != nil {
+		return nil, fmt.Errorf("unable to parse breaking rules: %w", err)
+	}
+	known := BreakingRuleObjectTypes()
+	for objectType := range rules {
+		i := sort.SearchStrings(known, objectType)
+		if objectType != "*" && (i == len(known) || known[i] != objectType) {
+			return nil, fmt.Errorf("unable to parse breaking rules, unknown object type '%s' (expected one of %s)",
+				objectType, strings.Join(known, ", "))
+		}
+	}
+	return rules, nil
+}
+
+// IsBreaking returns the breaking classification the rules give to a type of change (like PropertyAdded) made to
+// a property of an object type. The second value is false if no rule covers the change.
+func (rules BreakingRules) IsBreaking(objectType, property string, changeType int) (bool, bool) {
+	for _, key := range [][2]string{{objectType, property}, {objectType, "*"}, {"*", property}, {"*", "*"}} {
+		rule := rules[key[0]][key[1]]
+		if rule == nil {
+			continue
+		}
+		var breaking *bool
+		switch changeType {
+		case PropertyAdded, ObjectAdded:
+			breaking = rule.Added
+		case Modified:
+			breaking = rule.Modified
+		case PropertyRemoved, ObjectRemoved:
+			breaking = rule.Removed
+		case Renamed, Moved:
+			breaking = rule.Renamed
+		}
+		if breaking != nil {
+			return *breaking, true
+		}
+	}
+	return false, false
+}
+
+// Apply overrides the breaking classification of every change in a DocumentChanges tree that a rule covers.
+// Totals (like TotalBreakingChanges) reflect the rules once they have been applied.
+func (rules BreakingRules) Apply(changes *DocumentChanges) {
+	if len(rules) == 0 || changes == nil {
+		return
+	}
+	walkPropertyChanges(reflect.ValueOf(changes), func(objectType string, pc *PropertyChanges) {
+		for _, change := range pc.Changes {
+			if breaking, found := rules.IsBreaking(objectType, change.Property, change.ChangeType); found {
+				change.Breaking = breaking
+			}
+		}
+	})
+}
+
+// walkPropertyChanges walks a change model, and every change model inside it, and calls visit with the object
+// type and PropertyChanges of each one.
+func walkPropertyChanges(value reflect.Value, visit func(objectType string, pc *PropertyChanges)) {
+	switch value.Kind() {
+	case reflect.Ptr:
+		if !value.IsNil() {
+			walkPropertyChanges(value.Elem(), visit)
+		}
+	case reflect.Slice, reflect.Map:
+		if !isChangeModel(value.Type().Elem()) {
+			return
+		}
+		if value.Kind() == reflect.Slice {
+			for i := 0; i < value.Len(); i++ {
+				walkPropertyChanges(value.Index(i), visit)
+			}
+			return
+		}
+		iter := value.MapRange()
+		for iter.Next() {
+			walkPropertyChanges(iter.Value(), visit)
+		}
+	case reflect.Struct:
+		objectType, ok := breakingRuleObjectTypes[value.Type()]
+		if !ok {
+			return
+		}
+		for i := 0; i < value.NumField(); i++ {
+			if value.Type().Field(i).PkgPath != "" {
+				continue
+			}
+			field := value.Field(i)
+			if pc, isPropertyChanges := field.Interface().(*PropertyChanges); isPropertyChanges {
+				if pc != nil {
+					visit(objectType, pc)
+				}
+				continue
+			}
+			if isChangeModel(field.Type()) {
+				walkPropertyChanges(field, visit)
+			}
+		}
+	}
+}
+
+// isChangeModel returns true if a type is a change model, a pointer to one, or a slice or map of them.
+func isChangeModel(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map:
+		return isChangeModel(t.Elem())
+	}
+	_, ok := breakingRuleObjectTypes[t]
+	return ok
+}
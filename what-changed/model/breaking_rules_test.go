@@ -0,0 +1,203 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func compareBreakingRulesDocs(t *testing.T) *DocumentChanges {
+	left := `openapi: 3.1.0
+info:
+  title: pets
+  description: all the pets
+  contact:
+    name: pb33f
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      description: list the pets
+      x-audience: public
+      responses:
+        "200":
+          description: pets
+          content:
+            application/json:
+              schema:
+                type: string
+                description: the kind of pet
+                enum: [cat, dog]`
+
+	right := `openapi: 3.1.0
+info:
+  title: pets
+  contact:
+    name: princess b33f
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: pets
+          content:
+            application/json:
+              schema:
+                type: string
+                enum: [cat, dog, fish]`
+
+	siLeft, _ := datamodel.ExtractSpecInfo([]byte(left))
+	siRight, _ := datamodel.ExtractSpecInfo([]byte(right))
+	lDoc, _ := v3.CreateDocument(siLeft)
+	rDoc, _ := v3.CreateDocument(siRight)
+	changes := CompareDocuments(lDoc, rDoc)
+	assert.NotNil(t, changes)
+	return changes
+}
+
+func findBreakingRulesChange(changes *DocumentChanges, property string) *Change {
+	for _, c := range changes.GetAllChanges() {
+		if c.Property == property {
+			return c
+		}
+	}
+	return nil
+}
+
+func TestLoadBreakingRules(t *testing.T) {
+	rules, err := LoadBreakingRules([]byte(`schema:
+  enum:
+    added: true
+'*':
+  description:
+    removed: false`))
+	assert.NoError(t, err)
+	assert.True(t, *rules["schema"]["enum"].Added)
+	assert.Nil(t, rules["schema"]["enum"].Removed)
+	assert.False(t, *rules["*"]["description"].Removed)
+}
+
+func TestLoadBreakingRules_JSON(t *testing.T) {
+	rules, err := LoadBreakingRules([]byte(`{"operation": {"*": {"modified": true}}}`))
+	assert.NoError(t, err)
+	assert.True(t, *rules["operation"]["*"].Modified)
+}
+
+func TestLoadBreakingRules_UnknownObjectType(t *testing.T) {
+	_, err := LoadBreakingRules([]byte(`schemas:
+  enum:
+    added: true`))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown object type 'schemas'")
+}
+
+func TestLoadBreakingRules_Invalid(t *testing.T) {
+	_, err := LoadBreakingRules([]byte(`schema: [not, rules]`))
+	assert.Error(t, err)
+}
+
+func TestBreakingRules_IsBreaking(t *testing.T) {
+	rules, _ := LoadBreakingRules([]byte(`schema:
+  enum:
+    added: true
+  '*':
+    removed: false
+'*':
+  enum:
+    added: false
+    removed: true
+  '*':
+    modified: true`))
+
+	breaking, found := rules.IsBreaking("schema", "enum", PropertyAdded)
+	assert.True(t, found)
+	assert.True(t, breaking)
+
+	// the object type wins over the property.
+	breaking, found = rules.IsBreaking("schema", "enum", PropertyRemoved)
+	assert.True(t, found)
+	assert.False(t, breaking)
+
+	breaking, found = rules.IsBreaking("parameter", "enum", ObjectAdded)
+	assert.True(t, found)
+	assert.False(t, breaking)
+
+	breaking, found = rules.IsBreaking("parameter", "name", Modified)
+	assert.True(t, found)
+	assert.True(t, breaking)
+
+	_, found = rules.IsBreaking("parameter", "name", PropertyAdded)
+	assert.False(t, found)
+}
+
+func TestBreakingRules_Apply(t *testing.T) {
+	changes := compareBreakingRulesDocs(t)
+	assert.True(t, findBreakingRulesChange(changes, v3.EnumLabel).Breaking) // the schema is used by a response
+	assert.True(t, findBreakingRulesChange(changes, v3.OperationIdLabel).Breaking)
+	assert.Equal(t, 2, changes.TotalBreakingChanges())
+	assert.Equal(t, 2, changes.PathsChanges.TotalBreakingChanges())
+
+	rules, _ := LoadBreakingRules([]byte(`schema:
+  enum:
+    added: false
+operation:
+  operationId:
+    removed: false
+'*':
+  description:
+    removed: false`))
+	rules.Apply(changes)
+
+	assert.False(t, findBreakingRulesChange(changes, v3.EnumLabel).Breaking)
+	assert.False(t, findBreakingRulesChange(changes, v3.OperationIdLabel).Breaking)
+	for _, c := range changes.GetAllChanges() {
+		if c.Property == v3.DescriptionLabel {
+			assert.False(t, c.Breaking)
+		}
+	}
+	assert.Equal(t, 0, changes.TotalBreakingChanges())
+	assert.Equal(t, 0, changes.PathsChanges.TotalBreakingChanges())
+}
+
+func TestBreakingRules_Apply_NonBinding(t *testing.T) {
+	changes := compareBreakingRulesDocs(t)
+	assert.Equal(t, 0, changes.InfoChanges.TotalBreakingChanges())
+
+	rules, _ := LoadBreakingRules([]byte(`info:
+  description:
+    removed: true
+contact:
+  name:
+    modified: true
+extensions:
+  x-audience:
+    removed: true`))
+	rules.Apply(changes)
+
+	assert.Equal(t, 1, changes.InfoChanges.ContactChanges.TotalBreakingChanges())
+	assert.Equal(t, 2, changes.InfoChanges.TotalBreakingChanges())
+	assert.Equal(t, 1, changes.PathsChanges.PathItemsChanges["/pets"].GetChanges.ExtensionChanges.TotalBreakingChanges())
+	assert.Equal(t, 5, changes.TotalBreakingChanges())
+}
+
+func TestBreakingRules_Apply_Nil(t *testing.T) {
+	var rules BreakingRules
+	changes := compareBreakingRulesDocs(t)
+	rules.Apply(changes)
+	assert.Equal(t, 2, changes.TotalBreakingChanges())
+
+	rules = BreakingRules{"*": {"*": {}}}
+	rules.Apply(nil)
+}
+
+func TestBreakingRuleObjectTypes(t *testing.T) {
+	types := BreakingRuleObjectTypes()
+	assert.Len(t, types, len(breakingRuleObjectTypes))
+	assert.Contains(t, types, "schema")
+	assert.Equal(t, "callback", types[0])
+}
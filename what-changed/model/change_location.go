@@ -0,0 +1,243 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// applyChangeLocations sets the JSONPointer, Path and Fingerprint of every change in a DocumentChanges tree.
+//
+// A change is located by the node it was created from, in the right document (or the left one, for removals).
+// Changes made to objects that were resolved from a $ref are located where the object is defined. A change with
+// no node that can be found (like a change to an object in another file) is located under the object that holds
+// it, using its property.
+func applyChangeLocations(changes *DocumentChanges, left, right *index.SpecIndex) {
+	l := &changeLocator{pointers: make(map[*yaml.Node]string)}
+	for _, idx := range []*index.SpecIndex{left, right} {
+		if idx == nil {
+			continue
+		}
+		root := idx.GetRootNode()
+		collectNodePointers(root, "", l.pointers)
+		if root != nil && root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+			root = root.Content[0]
+		}
+		if root != nil && utils.IsNodeMap(root) {
+			if _, swagger := utils.FindKeyNodeTop(v3.SwaggerLabel, root.Content); swagger != nil {
+				l.swagger = true
+			}
+		}
+	}
+	l.locate(reflect.ValueOf(changes), "")
+}
+
+// changeLocator locates the changes in a change model.
+type changeLocator struct {
+	// pointers is the JSON pointer of every node in both documents.
+	pointers map[*yaml.Node]string
+
+	// swagger is true if the documents are Swagger documents, which have no components object.
+	swagger bool
+}
+
+// changeModelSegments are the keys (in a document) of change model fields, when they are not the same as the JSON
+// name of the field. An empty key means the field has no key of its own, like a map of paths.
+var changeModelSegments = map[string]string{
+	"externalDoc":          v3.ExternalDocsLabel,
+	"requestBodies":        v3.RequestBodyLabel,
+	"schemas":              v3.SchemaLabel,
+	"pathItems":            "",
+	"expressions":          "",
+	"response":             "",
+	"extensions":           "",
+	"serverVariables":      v3.VariablesLabel,
+	"oAuthFlow":            v3.FlowsLabel,
+	"authCode":             v3.AuthorizationCodeLabel,
+	"securityRequirements": v3.SecurityLabel,
+}
+
+// fieldSegment returns the key of a change model field in a document.
+func (l *changeLocator) fieldSegment(objectType string, field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+	switch {
+	case objectType == "document" && name == v3.ComponentsLabel && l.swagger:
+		return ""
+	case objectType == "components" && name == v3.SchemasLabel:
+		if l.swagger {
+			return v2.DefinitionsLabel
+		}
+		return v3.SchemasLabel
+	case objectType == "components" && name == v3.SecuritySchemesLabel && l.swagger:
+		return v2.SecurityDefinitionsLabel
+	}
+	if segment, ok := changeModelSegments[name]; ok {
+		return segment
+	}
+	return name
+}
+
+// collectNodePointers records the JSON pointer of every node under a node. Key nodes have the same pointer as
+// their values, and aliases are not followed.
+func collectNodePointers(node *yaml.Node, pointer string, pointers map[*yaml.Node]string) {
+	if node == nil {
+		return
+	}
+	if _, seen := pointers[node]; seen {
+		return
+	}
+	pointers[node] = pointer
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, n := range node.Content {
+			collectNodePointers(n, pointer, pointers)
+		}
+	case yaml.SequenceNode:
+		for i, n := range node.Content {
+			collectNodePointers(n, pointer+"/"+strconv.Itoa(i), pointers)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			p := pointer + "/" + utils.EscapePointerSegment(node.Content[i].Value)
+			if _, seen := pointers[node.Content[i]]; !seen {
+				pointers[node.Content[i]] = p
+			}
+			collectNodePointers(node.Content[i+1], p, pointers)
+		}
+	}
+}
+
+// locate walks a change model, locating the changes of each one. expected is where the model should be in
+// the document, which is used if none of the changes of the model can be located by node.
+func (l *changeLocator) locate(value reflect.Value, expected string) {
+	if value.Kind() == reflect.Ptr {
+		if !value.IsNil() {
+			l.locate(value.Elem(), expected)
+		}
+		return
+	}
+	objectType, ok := breakingRuleObjectTypes[value.Type()]
+	if value.Kind() != reflect.Struct || !ok {
+		return
+	}
+
+	var changes []*Change
+	for i := 0; i < value.NumField(); i++ {
+		if value.Type().Field(i).PkgPath != "" {
+			continue
+		}
+		if pc, isPropertyChanges := value.Field(i).Interface().(*PropertyChanges); isPropertyChanges && pc != nil {
+			changes = append(changes, pc.Changes...)
+		}
+	}
+
+	// the pointer of the model is worked out from the changes that can be located.
+	base, found := expected, false
+	located := make(map[*Change]string, len(changes))
+	for _, c := range changes {
+		pointer, isLocated := nodePointer(c, l.pointers)
+		if !isLocated {
+			continue
+		}
+		located[c] = pointer
+		if !found {
+			if p, isModel := modelPointer(pointer, c.Property); isModel {
+				base, found = p, true
+			}
+		}
+	}
+	for _, c := range changes {
+		pointer, isLocated := located[c]
+
+		// a node outside of the model is the target of a reference (like a schema in a oneOf), the change is
+		// made to the model.
+		if !isLocated || (pointer != base && !strings.HasPrefix(pointer, base+"/")) {
+			pointer = base + "/" + utils.EscapePointerSegment(c.Property)
+		}
+		c.setLocation(pointer)
+	}
+
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if field.PkgPath != "" || !isChangeModel(field.Type) {
+			continue
+		}
+		pointer := base
+		if segment := l.fieldSegment(objectType, field); segment != "" {
+			pointer += "/" + utils.EscapePointerSegment(segment)
+		}
+		f := value.Field(i)
+		switch f.Kind() {
+		case reflect.Map:
+			iter := f.MapRange()
+			for iter.Next() {
+				l.locate(iter.Value(), pointer+"/"+utils.EscapePointerSegment(iter.Key().String()))
+			}
+		case reflect.Slice:
+			// the position of an item is not known, so items are expected at the array.
+			for j := 0; j < f.Len(); j++ {
+				l.locate(f.Index(j), pointer)
+			}
+		default:
+			l.locate(f, pointer)
+		}
+	}
+}
+
+// nodePointer returns the pointer of the node a change was created from, the new node is used if there is one.
+func nodePointer(c *Change, pointers map[*yaml.Node]string) (string, bool) {
+	for _, node := range []*yaml.Node{c.newNode, c.originalNode} {
+		if node == nil {
+			continue
+		}
+		if pointer, ok := pointers[node]; ok {
+			return pointer, true
+		}
+	}
+	return "", false
+}
+
+// modelPointer returns the pointer of the object that holds a located change, by removing the property of the
+// change (and the key of an added or removed map entry) from the end of the pointer.
+func modelPointer(pointer, property string) (string, bool) {
+	segments := strings.Split(pointer, "/")
+	property = utils.EscapePointerSegment(property)
+	for i := len(segments) - 1; i >= len(segments)-2 && i > 0; i-- {
+		if segments[i] == property {
+			return strings.Join(segments[:i], "/"), true
+		}
+	}
+	return "", false
+}
+
+// setLocation sets the JSONPointer, Path and Fingerprint of a change.
+func (c *Change) setLocation(pointer string) {
+	c.JSONPointer = pointer
+	c.Path = readablePath(pointer)
+	sum := sha256.Sum256([]byte(strings.Join([]string{strconv.Itoa(c.ChangeType), pointer, c.Property,
+		c.Original, c.New}, "\n")))
+	c.Fingerprint = fmt.Sprintf("%x", sum)
+}
+
+// readablePath turns a JSON pointer into a dotted path, like 'paths./pets.get'.
+func readablePath(pointer string) string {
+	if pointer == "" {
+		return ""
+	}
+	segments := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	for i := range segments {
+		segments[i] = utils.UnescapePointerSegment(segments[i])
+	}
+	return strings.Join(segments, ".")
+}
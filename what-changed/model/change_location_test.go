@@ -0,0 +1,219 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+var changeLocationLeft = `openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: limit
+          in: query
+        - name: kind
+          in: query
+          description: the kind of pet
+      responses:
+        "200":
+          description: pets
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  name:
+                    type: string
+  /owners:
+    get:
+      responses:
+        "200":
+          description: owners
+components:
+  schemas:
+    Cat:
+      type: object
+    Payload:
+      description: a payload
+      oneOf:
+        - $ref: '#/components/schemas/Cat'`
+
+var changeLocationRight = `openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: limit
+          in: query
+        - name: kind
+          in: query
+          description: the type of pet
+      responses:
+        "200":
+          description: pets
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  name:
+                    type: integer
+components:
+  schemas:
+    Cat:
+      type: object
+    Payload:
+      description: a payload`
+
+func findChangeByPath(changes *DocumentChanges, path string) *Change {
+	for _, c := range changes.GetAllChanges() {
+		if c.Path == path {
+			return c
+		}
+	}
+	return nil
+}
+
+func TestCompareDocuments_ChangeLocations(t *testing.T) {
+	changes := compareSchemaUsageDocs(changeLocationLeft, changeLocationRight)
+	for _, c := range changes.GetAllChanges() {
+		assert.NotEmpty(t, c.JSONPointer)
+		assert.Len(t, c.Fingerprint, 64)
+	}
+
+	c := findChangeByPath(changes, "paths./pets.get.responses.200.content.application/json.schema.properties.name.type")
+	if assert.NotNil(t, c) {
+		assert.Equal(t, "/paths/~1pets/get/responses/200/content/application~1json/schema/properties/name/type",
+			c.JSONPointer)
+		assert.Equal(t, "integer", c.New)
+	}
+
+	// array items are located by their position in the document.
+	c = findChangeByPath(changes, "paths./pets.get.parameters.1.description")
+	if assert.NotNil(t, c) {
+		assert.Equal(t, "the type of pet", c.New)
+	}
+
+	// removals are located in the original document.
+	c = findChangeByPath(changes, "paths./owners")
+	if assert.NotNil(t, c) {
+		assert.Equal(t, ObjectRemoved, c.ChangeType)
+	}
+
+	// the removed reference is located in the schema that made it, not the schema it points to.
+	c = findChangeByPath(changes, "components.schemas.Payload.oneOf")
+	if assert.NotNil(t, c) {
+		assert.Equal(t, ObjectRemoved, c.ChangeType)
+	}
+}
+
+func TestCompareDocuments_ChangeLocations_Fingerprint(t *testing.T) {
+	first := compareSchemaUsageDocs(changeLocationLeft, changeLocationRight)
+	second := compareSchemaUsageDocs(changeLocationLeft, changeLocationRight)
+
+	fingerprints := make(map[string]string)
+	for _, c := range first.GetAllChanges() {
+		fingerprints[c.Fingerprint] = c.Path
+	}
+	assert.Len(t, fingerprints, first.TotalChanges())
+	for _, c := range second.GetAllChanges() {
+		assert.Equal(t, c.Path, fingerprints[c.Fingerprint])
+	}
+
+	// moving things around the document does not change a fingerprint, changing a value does.
+	moved := "openapi: 3.1.0\ninfo:\n  title: pets\n" + changeLocationRight[len("openapi: 3.1.0\n"):]
+	for _, c := range compareSchemaUsageDocs(changeLocationLeft, moved).GetAllChanges() {
+		if c.Property == v3.InfoLabel {
+			continue
+		}
+		assert.Equal(t, c.Path, fingerprints[c.Fingerprint])
+	}
+	c := findChangeByPath(first, "paths./pets.get.parameters.1.description")
+	c.New = "the breed of pet"
+	c.setLocation(c.JSONPointer)
+	assert.Empty(t, fingerprints[c.Fingerprint])
+
+	out, _ := json.Marshal(c)
+	assert.Contains(t, string(out), `"jsonPointer":"/paths/~1pets/get/parameters/1/description"`)
+	assert.Contains(t, string(out), `"path":"paths./pets.get.parameters.1.description"`)
+	assert.Contains(t, string(out), `"fingerprint":"`+c.Fingerprint+`"`)
+}
+
+func TestCompareDocuments_ChangeLocations_Swagger(t *testing.T) {
+	left := `swagger: 2.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: pets
+definitions:
+  Pet:
+    type: object
+    properties:
+      name:
+        type: string`
+
+	right := `swagger: 2.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: all the pets
+definitions:
+  Pet:
+    type: object
+    properties:
+      name:
+        type: integer`
+
+	siLeft, _ := datamodel.ExtractSpecInfo([]byte(left))
+	siRight, _ := datamodel.ExtractSpecInfo([]byte(right))
+	lDoc, _ := v2.CreateDocument(siLeft)
+	rDoc, _ := v2.CreateDocument(siRight)
+	changes := CompareDocuments(lDoc, rDoc)
+
+	assert.NotNil(t, findChangeByPath(changes, "paths./pets.get.responses.200.description"))
+	c := findChangeByPath(changes, "definitions.Pet.properties.name.type")
+	if assert.NotNil(t, c) {
+		assert.Equal(t, "/definitions/Pet/properties/name/type", c.JSONPointer)
+	}
+}
+
+func TestChangeLocator_Expected(t *testing.T) {
+	// changes with no node are located under the object that holds them.
+	changes := &DocumentChanges{
+		PathsChanges: &PathsChanges{
+			PathItemsChanges: map[string]*PathItemChanges{
+				"/pets": {
+					GetChanges: &OperationChanges{
+						PropertyChanges: NewPropertyChanges([]*Change{{ChangeType: Modified, Property: "summary"}}),
+						ExternalDocChanges: &ExternalDocChanges{
+							PropertyChanges: NewPropertyChanges([]*Change{{ChangeType: Modified, Property: "url"}}),
+						},
+					},
+				},
+			},
+		},
+	}
+	applyChangeLocations(changes, nil, nil)
+	summary := changes.PathsChanges.PathItemsChanges["/pets"].GetChanges.Changes[0]
+	assert.Equal(t, "/paths/~1pets/get/summary", summary.JSONPointer)
+	url := changes.PathsChanges.PathItemsChanges["/pets"].GetChanges.ExternalDocChanges.Changes[0]
+	assert.Equal(t, "paths./pets.get.externalDocs.url", url.Path)
+}
+
+func TestReadablePath(t *testing.T) {
+	assert.Equal(t, "", readablePath(""))
+	assert.Equal(t, "paths./a~b.get", readablePath("/paths/~1a~0b/get"))
+}
@@ -24,6 +24,14 @@ const (
 
     // PropertyRemoved means that a property of an object was removed
     PropertyRemoved
+
+    // Renamed means that an object was removed and added again with a new name in the same parent object, for
+    // example a path or a component schema that was renamed. See DetectRenames.
+    Renamed
+
+    // Moved means that an object was removed from one parent object and added to another, for example a
+    // schema property that was moved into a different schema. See DetectRenames.
+    Moved
 )
 
 // WhatChanged is a summary object that contains a high level summary of everything changed.
@@ -90,6 +98,24 @@ type Change struct {
 
     // NewObject represents the new object that has been modified.
     NewObject any `json:"-" yaml:"-"`
+
+    // Similarity is how alike the original and new objects of a Renamed or Moved change are, from 0 to 1.
+    Similarity float64 `json:"similarity,omitempty" yaml:"similarity,omitempty"`
+
+    // JSONPointer is the location of the change in the new document (or the original document, if the value was
+    // removed), as a JSON pointer, like '/paths/~1pets/get/responses/200/description'.
+    JSONPointer string `json:"jsonPointer,omitempty" yaml:"jsonPointer,omitempty"`
+
+    // Path is a readable version of JSONPointer, like 'paths./pets.get.responses.200.description'.
+    Path string `json:"path,omitempty" yaml:"path,omitempty"`
+
+    // Fingerprint identifies the change, it's the same for the same change in every comparison, so it can be used
+    // to suppress or deduplicate changes. Line and column numbers are not part of a fingerprint.
+    Fingerprint string `json:"fingerprint,omitempty" yaml:"fingerprint,omitempty"`
+
+    // originalNode and newNode are the nodes the change was created from, used to locate the change.
+    originalNode *yaml.Node
+    newNode      *yaml.Node
 }
 
 // PropertyChanges holds a slice of Change pointers
@@ -6,10 +6,12 @@ package model
 import (
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
 	"sync"
 
 	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/utils"
 	"gopkg.in/yaml.v3"
 )
 
@@ -30,6 +32,9 @@ func CreateChange(changes *[]*Change, changeType int, property string, leftValue
 		ChangeType: changeType,
 		Property:   property,
 		Breaking:   breaking,
+
+		originalNode: leftValueNode,
+		newNode:      rightValueNode,
 	}
 	// if the left is not nil, we have an original value
 	if leftValueNode != nil && leftValueNode.Value != "" {
@@ -177,6 +182,23 @@ func CheckForModification[T any](l, r *yaml.Node, label string, changes *[]*Chan
 	}
 }
 
+// CheckForNumericModification works the same way as CheckForModification, except that when both the left and right
+// nodes are numbers, they are compared by value. This means '1' and '1.0' are considered the same, but '0.01' and
+// '0.02' are not. Anything that is not a number on both sides falls back to CheckForModification.
+func CheckForNumericModification[T any](l, r *yaml.Node, label string, changes *[]*Change, breaking bool, orig, new T) {
+	if (utils.IsNodeIntValue(l) || utils.IsNodeFloatValue(l)) && (utils.IsNodeIntValue(r) || utils.IsNodeFloatValue(r)) {
+		lv, lErr := strconv.ParseFloat(l.Value, 64)
+		rv, rErr := strconv.ParseFloat(r.Value, 64)
+		if lErr == nil && rErr == nil {
+			if lv != rv {
+				CreateChange(changes, Modified, label, l, r, breaking, orig, new)
+			}
+			return
+		}
+	}
+	CheckForModification(l, r, label, changes, breaking, orig, new)
+}
+
 // CheckMapForChanges checks a left and right low level map for any additions, subtractions or modifications to
 // values. The compareFunc argument should reference the correct comparison function for the generic type.
 func CheckMapForChanges[T any, R any](expLeft, expRight map[low.KeyReference[string]]low.ValueReference[T],
@@ -275,5 +275,8 @@ func (c *ComponentsChanges) TotalBreakingChanges() int {
 	for k := range c.SecuritySchemeChanges {
 		v += c.SecuritySchemeChanges[k].TotalBreakingChanges()
 	}
+	if c.ExtensionChanges != nil {
+		v += c.ExtensionChanges.TotalBreakingChanges()
+	}
 	return v
 }
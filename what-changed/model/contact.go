@@ -23,9 +23,10 @@ func (c *ContactChanges) TotalChanges() int {
     return c.PropertyChanges.TotalChanges()
 }
 
-// TotalBreakingChanges always returns 0 for Contact objects, they are non-binding.
+// TotalBreakingChanges returns 0 for Contact objects, they are non-binding, unless BreakingRules have been applied
+// that make changes to them breaking.
 func (c *ContactChanges) TotalBreakingChanges() int {
-    return 0
+    return c.PropertyChanges.TotalBreakingChanges()
 }
 
 // CompareContact will check a left (original) and right (new) Contact object for any changes. If there
@@ -17,6 +17,7 @@ import (
 	"github.com/pb33f/libopenapi/datamodel/low/base"
 	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
 	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/pb33f/libopenapi/index"
 )
 
 // DocumentChanges represents all the changes made to an OpenAPI document.
@@ -127,6 +128,9 @@ func (d *DocumentChanges) TotalBreakingChanges() int {
 	if d.ComponentsChanges != nil {
 		c += d.ComponentsChanges.TotalBreakingChanges()
 	}
+	if d.ExtensionChanges != nil {
+		c += d.ExtensionChanges.TotalBreakingChanges()
+	}
 	return c
 }
 
@@ -138,10 +142,12 @@ func CompareDocuments(l, r any) *DocumentChanges {
 	var props []*PropertyCheck
 
 	dc := new(DocumentChanges)
+	var lIndex, rIndex *index.SpecIndex
 
 	if reflect.TypeOf(&v2.Swagger{}) == reflect.TypeOf(l) && reflect.TypeOf(&v2.Swagger{}) == reflect.TypeOf(r) {
 		lDoc := l.(*v2.Swagger)
 		rDoc := r.(*v2.Swagger)
+		lIndex, rIndex = lDoc.Index, rDoc.Index
 
 		// version
 		addPropertyCheck(&props, lDoc.Swagger.ValueNode, rDoc.Swagger.ValueNode,
@@ -217,6 +223,7 @@ func CompareDocuments(l, r any) *DocumentChanges {
 	if reflect.TypeOf(&v3.Document{}) == reflect.TypeOf(l) && reflect.TypeOf(&v3.Document{}) == reflect.TypeOf(r) {
 		lDoc := l.(*v3.Document)
 		rDoc := r.(*v3.Document)
+		lIndex, rIndex = lDoc.Index, rDoc.Index
 
 		// version
 		addPropertyCheck(&props, lDoc.Version.ValueNode, rDoc.Version.ValueNode,
@@ -278,6 +285,8 @@ func CompareDocuments(l, r any) *DocumentChanges {
 	if dc.TotalChanges() <= 0 {
 		return nil
 	}
+	applySchemaUsage(dc, lIndex, rIndex)
+	applyChangeLocations(dc, lIndex, rIndex)
 	return dc
 }
 
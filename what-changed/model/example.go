@@ -43,6 +43,9 @@ func (e *ExampleChanges) TotalChanges() int {
 // TotalBreakingChanges returns the total number of breaking changes made to Example
 func (e *ExampleChanges) TotalBreakingChanges() int {
 	l := e.PropertyChanges.TotalBreakingChanges()
+	if e.ExtensionChanges != nil {
+		l += e.ExtensionChanges.TotalBreakingChanges()
+	}
 	return l
 }
 
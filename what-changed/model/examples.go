@@ -23,9 +23,10 @@ func (a *ExamplesChanges) TotalChanges() int {
     return a.PropertyChanges.TotalChanges()
 }
 
-// TotalBreakingChanges will always return 0. Examples cannot break a contract.
+// TotalBreakingChanges returns 0, examples cannot break a contract, unless BreakingRules have been applied that
+// make changes to them breaking.
 func (a *ExamplesChanges) TotalBreakingChanges() int {
-    return 0 // not supported.
+    return a.PropertyChanges.TotalBreakingChanges()
 }
 
 // CompareExamplesV2 compares two Swagger Examples objects, returning a pointer to
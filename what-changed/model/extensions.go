@@ -23,9 +23,10 @@ func (e *ExtensionChanges) TotalChanges() int {
     return e.PropertyChanges.TotalChanges()
 }
 
-// TotalBreakingChanges always returns 0 for Extension objects, they are non-binding.
+// TotalBreakingChanges returns 0 for Extension objects, they are non-binding, unless BreakingRules have been applied
+// that make changes to them breaking.
 func (e *ExtensionChanges) TotalBreakingChanges() int {
-    return 0
+    return e.PropertyChanges.TotalBreakingChanges()
 }
 
 // CompareExtensions will compare a left and right map of Tag/ValueReference models for any changes to
@@ -51,7 +52,7 @@ func CompareExtensions(l, r map[low.KeyReference[string]]low.ValueReference[any]
     var changes []*Change
     for i := range seenLeft {
 
-        CheckForObjectAdditionOrRemoval[any](seenLeft, seenRight, i, &changes, false, true)
+        CheckForObjectAdditionOrRemoval[any](seenLeft, seenRight, i, &changes, false, false)
 
         if seenRight[i] != nil {
             var props []*PropertyCheck
@@ -72,7 +73,7 @@ func CompareExtensions(l, r map[low.KeyReference[string]]low.ValueReference[any]
     }
     for i := range seenRight {
         if seenLeft[i] == nil {
-            CheckForObjectAdditionOrRemoval[any](seenLeft, seenRight, i, &changes, false, true)
+            CheckForObjectAdditionOrRemoval[any](seenLeft, seenRight, i, &changes, false, false)
         }
     }
     ex := new(ExtensionChanges)
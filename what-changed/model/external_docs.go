@@ -33,9 +33,14 @@ func (e *ExternalDocChanges) TotalChanges() int {
     return c
 }
 
-// TotalBreakingChanges always returns 0 for ExternalDoc objects, they are non-binding.
+// TotalBreakingChanges returns 0 for ExternalDoc objects, they are non-binding, unless BreakingRules have been
+// applied that make changes to them breaking.
 func (e *ExternalDocChanges) TotalBreakingChanges() int {
-    return 0
+    c := e.PropertyChanges.TotalBreakingChanges()
+    if e.ExtensionChanges != nil {
+        c += e.ExtensionChanges.TotalBreakingChanges()
+    }
+    return c
 }
 
 // CompareExternalDocs will compare a left (original) and a right (new) slice of ValueReference
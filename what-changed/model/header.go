@@ -78,6 +78,12 @@ func (h *HeaderChanges) TotalBreakingChanges() int {
     if h.SchemaChanges != nil {
         c += h.SchemaChanges.TotalBreakingChanges()
     }
+    for k := range h.ExamplesChanges {
+        c += h.ExamplesChanges[k].TotalBreakingChanges()
+    }
+    if h.ExtensionChanges != nil {
+        c += h.ExtensionChanges.TotalBreakingChanges()
+    }
     return c
 }
 
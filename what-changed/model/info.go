@@ -40,9 +40,17 @@ func (i *InfoChanges) TotalChanges() int {
 	return t
 }
 
-// TotalBreakingChanges always returns 0 for Info objects, they are non-binding.
+// TotalBreakingChanges returns 0 for Info objects, they are non-binding, unless BreakingRules have been applied
+// that make changes to them breaking.
 func (i *InfoChanges) TotalBreakingChanges() int {
-	return 0
+	t := i.PropertyChanges.TotalBreakingChanges()
+	if i.ContactChanges != nil {
+		t += i.ContactChanges.TotalBreakingChanges()
+	}
+	if i.LicenseChanges != nil {
+		t += i.LicenseChanges.TotalBreakingChanges()
+	}
+	return t
 }
 
 // CompareInfo will compare a left (original) and a right (new) Info object. Any changes
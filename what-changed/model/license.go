@@ -23,9 +23,10 @@ func (l *LicenseChanges) TotalChanges() int {
     return l.PropertyChanges.TotalChanges()
 }
 
-// TotalBreakingChanges always returns 0 for License objects, they are non-binding.
+// TotalBreakingChanges returns 0 for License objects, they are non-binding, unless BreakingRules have been applied
+// that make changes to them breaking.
 func (l *LicenseChanges) TotalBreakingChanges() int {
-    return 0
+    return l.PropertyChanges.TotalBreakingChanges()
 }
 
 // CompareLicense will check a left (original) and right (new) License object for any changes. If there
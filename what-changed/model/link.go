@@ -46,6 +46,9 @@ func (l *LinkChanges) TotalBreakingChanges() int {
     if l.ServerChanges != nil {
         c += l.ServerChanges.TotalBreakingChanges()
     }
+    if l.ExtensionChanges != nil {
+        c += l.ExtensionChanges.TotalBreakingChanges()
+    }
     return c
 }
 
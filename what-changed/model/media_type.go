@@ -72,6 +72,9 @@ func (m *MediaTypeChanges) TotalBreakingChanges() int {
             c += m.EncodingChanges[i].TotalBreakingChanges()
         }
     }
+    if m.ExtensionChanges != nil {
+        c += m.ExtensionChanges.TotalBreakingChanges()
+    }
     return c
 }
 
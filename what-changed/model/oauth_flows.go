@@ -76,6 +76,9 @@ func (o *OAuthFlowsChanges) TotalBreakingChanges() int {
     if o.AuthorizationCodeChanges != nil {
         c += o.AuthorizationCodeChanges.TotalBreakingChanges()
     }
+    if o.ExtensionChanges != nil {
+        c += o.ExtensionChanges.TotalBreakingChanges()
+    }
     return c
 }
 
@@ -180,7 +183,11 @@ func (o *OAuthFlowChanges) TotalChanges() int {
 
 // TotalBreakingChanges returns the total number of breaking changes made between two OAuthFlow objects
 func (o *OAuthFlowChanges) TotalBreakingChanges() int {
-    return o.PropertyChanges.TotalBreakingChanges()
+    c := o.PropertyChanges.TotalBreakingChanges()
+    if o.ExtensionChanges != nil {
+        c += o.ExtensionChanges.TotalBreakingChanges()
+    }
+    return c
 }
 
 // CompareOAuthFlow checks a left and a right OAuthFlow object for changes. If found, returns a pointer to
@@ -116,6 +116,9 @@ func (o *OperationChanges) TotalBreakingChanges() int {
 	for k := range o.ServerChanges {
 		c += o.ServerChanges[k].TotalBreakingChanges()
 	}
+	if o.ExtensionChanges != nil {
+		c += o.ExtensionChanges.TotalBreakingChanges()
+	}
 	return c
 }
 
@@ -81,6 +81,12 @@ func (p *ParameterChanges) TotalBreakingChanges() int {
     for i := range p.ContentChanges {
         c += p.ContentChanges[i].TotalBreakingChanges()
     }
+    for k := range p.ExamplesChanges {
+        c += p.ExamplesChanges[k].TotalBreakingChanges()
+    }
+    if p.ExtensionChanges != nil {
+        c += p.ExtensionChanges.TotalBreakingChanges()
+    }
     return c
 }
 
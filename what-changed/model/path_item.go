@@ -139,6 +139,9 @@ func (p *PathItemChanges) TotalBreakingChanges() int {
 	for i := range p.ParameterChanges {
 		c += p.ParameterChanges[i].TotalBreakingChanges()
 	}
+	if p.ExtensionChanges != nil {
+		c += p.ExtensionChanges.TotalBreakingChanges()
+	}
 	return c
 }
 
@@ -49,6 +49,9 @@ func (p *PathsChanges) TotalBreakingChanges() int {
     for k := range p.PathItemsChanges {
         c += p.PathItemsChanges[k].TotalBreakingChanges()
     }
+    if p.ExtensionChanges != nil {
+        c += p.ExtensionChanges.TotalBreakingChanges()
+    }
     return c
 }
 
@@ -0,0 +1,421 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultRenameSimilarity is the similarity an added object must have to a removed one to be reported as a rename,
+// if no other similarity is supplied to DetectRenames.
+const DefaultRenameSimilarity = 0.8
+
+// pathParameters matches the parameters of a path template, like '{id}'.
+var pathParameters = regexp.MustCompile(`{[^}]*}`)
+
+// renameCandidate is an added or removed object, and the PropertyChanges that holds it.
+type renameCandidate struct {
+	change     *Change
+	owner      *PropertyChanges
+	objectType string
+
+	// hash and values of the object, see similarity.
+	hash   string
+	values map[string]int
+}
+
+// newRenameCandidate creates a renameCandidate for the object of a change.
+func newRenameCandidate(change *Change, owner *PropertyChanges, objectType string, object any) *renameCandidate {
+	return &renameCandidate{
+		change:     change,
+		owner:      owner,
+		objectType: objectType,
+		hash:       low.GenerateHashString(object),
+		values:     objectValues(object),
+	}
+}
+
+// renamePair is a removed object that may have been renamed (or moved) to an added one.
+type renamePair struct {
+	removed, added *renameCandidate
+	similarity     float64
+
+	// template is true for paths that only differ by the names of their path parameters.
+	template bool
+}
+
+// DetectRenames is a heuristic pass that looks for objects that were removed and added again under a different
+// name, or in a different place, and replaces each pair of changes with a single Renamed (or Moved) change.
+//
+// Removed and added objects are paired if they are the same kind of object (like a path, a component or a schema
+// property) and are at least as similar as minSimilarity (DefaultRenameSimilarity is used if minSimilarity is
+// zero). Objects with the same hash (see low.GenerateHashString) have a similarity of 1, otherwise the similarity
+// is the fraction of the values in the objects that are the same. Paths that only differ by the names of their
+// path parameters (like '/users/{id}' and '/users/{userId}') are always paired, and their path items are compared
+// with the path parameters matched by position, so anything else that changed (like a removed operation) is still
+// reported, under the new path.
+//
+// Renames are breaking if the name is part of the contract: renaming a path or a schema property is breaking,
+// renaming a component is not (references are updated, clients see no difference), and neither is renaming a
+// path parameter. A move is breaking if the removal or the addition was.
+//
+// BreakingRules should be applied after renames are detected, so rules for renamed objects are used.
+func DetectRenames(changes *DocumentChanges, minSimilarity float64) {
+	if changes == nil {
+		return
+	}
+	if minSimilarity <= 0 {
+		minSimilarity = DefaultRenameSimilarity
+	}
+
+	var removed, added []*renameCandidate
+	walkPropertyChanges(reflect.ValueOf(changes), func(objectType string, pc *PropertyChanges) {
+		for _, change := range pc.Changes {
+			switch change.ChangeType {
+			case ObjectRemoved:
+				if change.OriginalObject != nil {
+					removed = append(removed, newRenameCandidate(change, pc, objectType, change.OriginalObject))
+				}
+			case ObjectAdded:
+				if change.NewObject != nil {
+					added = append(added, newRenameCandidate(change, pc, objectType, change.NewObject))
+				}
+			}
+		}
+	})
+
+	var pairs []*renamePair
+	for _, r := range removed {
+		for _, a := range added {
+			if r.objectType != a.objectType || r.change.Property != a.change.Property ||
+				reflect.TypeOf(r.change.OriginalObject) != reflect.TypeOf(a.change.NewObject) {
+				continue
+			}
+			p := &renamePair{removed: r, added: a, similarity: similarity(r, a)}
+			p.template = r.change.Property == v3.PathLabel &&
+				pathParameters.ReplaceAllString(r.change.Original, "{}") ==
+					pathParameters.ReplaceAllString(a.change.New, "{}")
+			if p.template || p.similarity >= minSimilarity {
+				pairs = append(pairs, p)
+			}
+		}
+	}
+	sort.SliceStable(pairs, func(i, j int) bool {
+		if pairs[i].template != pairs[j].template {
+			return pairs[i].template
+		}
+		if pairs[i].similarity != pairs[j].similarity {
+			return pairs[i].similarity > pairs[j].similarity
+		}
+		if pairs[i].removed.change.Original != pairs[j].removed.change.Original {
+			return pairs[i].removed.change.Original < pairs[j].removed.change.Original
+		}
+		return pairs[i].added.change.New < pairs[j].added.change.New
+	})
+
+	paired := make(map[*Change]bool)
+	replaced := make(map[*Change]*Change)
+	for _, p := range pairs {
+		if paired[p.removed.change] || paired[p.added.change] {
+			continue
+		}
+		paired[p.removed.change], paired[p.added.change] = true, true
+		replaced[p.removed.change] = renamedChange(p)
+		if p.template && changes.PathsChanges != nil {
+			if pc := templatePathChanges(p); pc != nil {
+				if changes.PathsChanges.PathItemsChanges == nil {
+					changes.PathsChanges.PathItemsChanges = make(map[string]*PathItemChanges)
+				}
+				changes.PathsChanges.PathItemsChanges[p.added.change.New] = pc
+			}
+		}
+	}
+	if len(replaced) == 0 {
+		return
+	}
+
+	walkPropertyChanges(reflect.ValueOf(changes), func(_ string, pc *PropertyChanges) {
+		var kept []*Change
+		for _, change := range pc.Changes {
+			if r := replaced[change]; r != nil {
+				kept = append(kept, r)
+				continue
+			}
+			if !paired[change] {
+				kept = append(kept, change)
+			}
+		}
+		pc.Changes = kept
+	})
+}
+
+// renamedChange creates the Renamed (or Moved) change that replaces a pair of changes.
+func renamedChange(p *renamePair) *Change {
+	r, a := p.removed.change, p.added.change
+	changeType := Renamed
+	if p.removed.owner != p.added.owner {
+		changeType = Moved
+	}
+	ctx := new(ChangeContext)
+	if r.Context != nil {
+		ctx.OriginalLine, ctx.OriginalColumn = r.Context.OriginalLine, r.Context.OriginalColumn
+	}
+	if a.Context != nil {
+		ctx.NewLine, ctx.NewColumn = a.Context.NewLine, a.Context.NewColumn
+	}
+
+	var breaking bool
+	switch {
+	case p.template:
+		breaking = false
+	case changeType == Renamed && p.removed.objectType == "components":
+		breaking = false
+	default:
+		breaking = r.Breaking || a.Breaking
+	}
+	c := &Change{
+		Context:        ctx,
+		ChangeType:     changeType,
+		Property:       r.Property,
+		Original:       r.Original,
+		New:            a.New,
+		Breaking:       breaking,
+		OriginalObject: r.OriginalObject,
+		NewObject:      a.NewObject,
+		Similarity:     p.similarity,
+		originalNode:   r.originalNode,
+		newNode:        a.newNode,
+	}
+	if a.JSONPointer != "" {
+		c.setLocation(a.JSONPointer)
+	}
+	return c
+}
+
+// templatePathChanges compares the path items of a pair of paths that only differ by the names of their path
+// parameters. A path parameter that was renamed is compared with the parameter it was renamed to, so the rename
+// itself is not reported. Nil is returned if nothing else changed.
+func templatePathChanges(p *renamePair) *PathItemChanges {
+	r, a := p.removed.change, p.added.change
+	pc := ComparePathItems(r.OriginalObject, a.NewObject)
+	if pc == nil {
+		return nil
+	}
+	renamed := make(map[string]string)
+	oldNames := pathParameters.FindAllString(r.Original, -1)
+	newNames := pathParameters.FindAllString(a.New, -1)
+	for i := range oldNames {
+		if o, n := strings.Trim(oldNames[i], "{}"), strings.Trim(newNames[i], "{}"); o != n {
+			renamed[o] = n
+		}
+	}
+
+	pc.ParameterChanges = renameParameters(pc.PropertyChanges, pc.ParameterChanges, renamed,
+		r.OriginalObject, a.NewObject)
+	operations := []struct {
+		changes *OperationChanges
+		field   string
+	}{
+		{pc.GetChanges, "Get"}, {pc.PutChanges, "Put"}, {pc.PostChanges, "Post"}, {pc.DeleteChanges, "Delete"},
+		{pc.OptionsChanges, "Options"}, {pc.HeadChanges, "Head"}, {pc.PatchChanges, "Patch"},
+		{pc.TraceChanges, "Trace"},
+	}
+	for _, op := range operations {
+		if op.changes != nil {
+			op.changes.ParameterChanges = renameParameters(op.changes.PropertyChanges, op.changes.ParameterChanges,
+				renamed, lowOperation(r.OriginalObject, op.field), lowOperation(a.NewObject, op.field))
+		}
+	}
+	if pc.TotalChanges() == 0 {
+		return nil
+	}
+	if a.JSONPointer != "" {
+		l := &changeLocator{pointers: make(map[*yaml.Node]string)}
+		l.locate(reflect.ValueOf(pc), a.JSONPointer)
+	}
+	return pc
+}
+
+// renameParameters replaces the removal of a renamed path parameter, and the addition of the parameter it was
+// renamed to, with the changes between the two parameters (other than the name).
+func renameParameters(pc *PropertyChanges, params []*ParameterChanges, renamed map[string]string,
+	l, r any) []*ParameterChanges {
+	if pc == nil {
+		return params
+	}
+	added := make(map[string]bool)
+	for _, c := range pc.Changes {
+		if c.ChangeType == ObjectAdded && c.Property == v3.ParametersLabel {
+			added[c.New] = true
+		}
+	}
+	matched := make(map[string]bool)
+	for _, c := range pc.Changes {
+		name := c.Original
+		if c.ChangeType != ObjectRemoved || c.Property != v3.ParametersLabel || !added[renamed[name]] {
+			continue
+		}
+		lp, rp := lowParameter(l, name), lowParameter(r, renamed[name])
+		if lp == nil || rp == nil {
+			continue
+		}
+		matched[name], matched[renamed[name]] = true, true
+		if ch := CompareParameters(lp, rp); ch != nil {
+			var kept []*Change
+			for _, pcc := range ch.Changes {
+				if pcc.Property != v3.NameLabel {
+					kept = append(kept, pcc)
+				}
+			}
+			ch.Changes = kept
+			if ch.TotalChanges() > 0 {
+				params = append(params, ch)
+			}
+		}
+	}
+	var kept []*Change
+	for _, c := range pc.Changes {
+		if c.Property == v3.ParametersLabel &&
+			((c.ChangeType == ObjectRemoved && matched[c.Original]) ||
+				(c.ChangeType == ObjectAdded && matched[c.New])) {
+			continue
+		}
+		kept = append(kept, c)
+	}
+	pc.Changes = kept
+	return params
+}
+
+// lowOperation returns an operation of a low level path item by the name of its field, or nil.
+func lowOperation(pathItem any, field string) any {
+	v := reflect.ValueOf(pathItem)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return nil
+	}
+	if f := v.Elem().FieldByName(field); f.IsValid() {
+		return f.FieldByName("Value").Interface()
+	}
+	return nil
+}
+
+// lowParameter returns a parameter of a low level path item or operation by name, or nil.
+func lowParameter(holder any, name string) any {
+	v := reflect.ValueOf(holder)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return nil
+	}
+	params := v.Elem().FieldByName("Parameters")
+	if !params.IsValid() {
+		return nil
+	}
+	params = params.FieldByName("Value")
+	for i := 0; i < params.Len(); i++ {
+		p := params.Index(i).FieldByName("Value").Interface()
+		if sp, ok := p.(low.SharedParameters); ok && !reflect.ValueOf(p).IsNil() && sp.GetName().Value == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// similarity returns how alike the objects of two candidates are, from 0 to 1.
+func similarity(l, r *renameCandidate) float64 {
+	if l.hash == r.hash {
+		return 1
+	}
+	lValues, rValues := l.values, r.values
+	total, same := 0, 0
+	for k, n := range lValues {
+		total += n
+		if m := rValues[k]; m < n {
+			same += m
+		} else {
+			same += n
+		}
+	}
+	for _, n := range rValues {
+		total += n
+	}
+	if total == 0 {
+		return 0
+	}
+	return float64(2*same) / float64(total)
+}
+
+// objectValues collects every value of a low level object, keyed by the location and the value (so a value that
+// moves counts as different). Values of arrays are not keyed by position, so reordering an array changes nothing.
+func objectValues(object any) map[string]int {
+	values := make(map[string]int)
+	v := reflect.ValueOf(object)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return values
+		}
+		v = v.Elem()
+	}
+	if n, ok := object.(interface{ GetValueNode() *yaml.Node }); ok {
+		// schemas are not built until they are needed, so the node is used instead.
+		collectNodeValues(n.GetValueNode(), "", values)
+		return values
+	}
+	if v.Kind() != reflect.Struct {
+		return values
+	}
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		f := v.Field(i)
+		if n, ok := f.Interface().(interface{ GetValueNode() *yaml.Node }); ok && f.Kind() == reflect.Struct {
+			collectNodeValues(n.GetValueNode(), field.Name, values)
+			continue
+		}
+		if f.Kind() != reflect.Map {
+			continue
+		}
+		iter := f.MapRange()
+		for iter.Next() {
+			k, isKey := iter.Key().Interface().(interface{ GetKeyNode() *yaml.Node })
+			n, isValue := iter.Value().Interface().(interface{ GetValueNode() *yaml.Node })
+			if !isKey || !isValue || k.GetKeyNode() == nil {
+				continue
+			}
+			collectNodeValues(n.GetValueNode(), field.Name+"/"+k.GetKeyNode().Value, values)
+		}
+	}
+	return values
+}
+
+// collectNodeValues collects every scalar value under a node.
+func collectNodeValues(node *yaml.Node, location string, values map[string]int) {
+	if node == nil {
+		return
+	}
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, n := range node.Content {
+			collectNodeValues(n, location, values)
+		}
+	case yaml.SequenceNode:
+		for _, n := range node.Content {
+			collectNodeValues(n, location+"/-", values)
+		}
+	case yaml.AliasNode:
+		collectNodeValues(node.Alias, location, values)
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			collectNodeValues(node.Content[i+1], location+"/"+node.Content[i].Value, values)
+		}
+	case yaml.ScalarNode:
+		values[location+"="+node.Value]++
+	}
+}
@@ -0,0 +1,329 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"testing"
+
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectRenames_PathParameter(t *testing.T) {
+	left := `openapi: 3.1.0
+paths:
+  /users/{id}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: a user`
+
+	right := `openapi: 3.1.0
+paths:
+  /users/{userId}:
+    get:
+      parameters:
+        - name: userId
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: a user`
+
+	changes := compareSchemaUsageDocs(left, right)
+	assert.Equal(t, 2, changes.PathsChanges.TotalChanges())
+	assert.Equal(t, 1, changes.TotalBreakingChanges())
+
+	DetectRenames(changes, 0)
+	assert.Len(t, changes.PathsChanges.Changes, 1)
+	c := changes.PathsChanges.Changes[0]
+	assert.Equal(t, Renamed, c.ChangeType)
+	assert.Equal(t, v3.PathLabel, c.Property)
+	assert.Equal(t, "/users/{id}", c.Original)
+	assert.Equal(t, "/users/{userId}", c.New)
+	assert.False(t, c.Breaking)
+	assert.Greater(t, c.Similarity, 0.0)
+	assert.Less(t, c.Similarity, 1.0)
+	assert.Equal(t, 3, *c.Context.OriginalLine)
+	assert.Equal(t, 3, *c.Context.NewLine)
+	assert.Equal(t, 0, changes.TotalBreakingChanges())
+	assert.Empty(t, changes.PathsChanges.PathItemsChanges)
+}
+
+func TestDetectRenames_PathParameter_OperationRemoved(t *testing.T) {
+	left := `openapi: 3.1.0
+paths:
+  /users/{id}:
+    parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: string
+    get:
+      responses:
+        "200":
+          description: a user
+    delete:
+      responses:
+        "204":
+          description: deleted`
+
+	right := `openapi: 3.1.0
+paths:
+  /users/{userId}:
+    parameters:
+      - name: userId
+        in: path
+        required: true
+        description: the user
+        schema:
+          type: string
+    get:
+      responses:
+        "200":
+          description: a user`
+
+	changes := compareSchemaUsageDocs(left, right)
+	assert.Equal(t, 1, changes.TotalBreakingChanges())
+
+	DetectRenames(changes, 0)
+	assert.Len(t, changes.PathsChanges.Changes, 1)
+	assert.Equal(t, Renamed, changes.PathsChanges.Changes[0].ChangeType)
+	assert.False(t, changes.PathsChanges.Changes[0].Breaking)
+
+	// the path item is still compared, the parameter is compared with the one it was renamed to.
+	pc := changes.PathsChanges.PathItemsChanges["/users/{userId}"]
+	if assert.NotNil(t, pc) {
+		assert.Len(t, pc.Changes, 1)
+		assert.Equal(t, PropertyRemoved, pc.Changes[0].ChangeType)
+		assert.Equal(t, v3.DeleteLabel, pc.Changes[0].Property)
+		assert.True(t, pc.Changes[0].Breaking)
+		assert.Equal(t, "/paths/~1users~1{userId}/delete", pc.Changes[0].JSONPointer)
+
+		assert.Len(t, pc.ParameterChanges, 1)
+		assert.Len(t, pc.ParameterChanges[0].Changes, 1)
+		assert.Equal(t, v3.DescriptionLabel, pc.ParameterChanges[0].Changes[0].Property)
+	}
+	assert.Equal(t, 1, changes.TotalBreakingChanges())
+}
+
+func TestDetectRenames_Path(t *testing.T) {
+	left := `openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        "200":
+          description: pets`
+
+	right := `openapi: 3.1.0
+paths:
+  /animals:
+    get:
+      operationId: listPets
+      responses:
+        "200":
+          description: pets`
+
+	changes := compareSchemaUsageDocs(left, right)
+	DetectRenames(changes, 0)
+	assert.Len(t, changes.PathsChanges.Changes, 1)
+	c := changes.PathsChanges.Changes[0]
+	assert.Equal(t, Renamed, c.ChangeType)
+	assert.Equal(t, "/pets", c.Original)
+	assert.Equal(t, "/animals", c.New)
+	assert.True(t, c.Breaking)
+	assert.Equal(t, 1.0, c.Similarity)
+	assert.Equal(t, "/paths/~1animals", c.JSONPointer)
+	assert.NotEmpty(t, c.Fingerprint)
+	assert.Equal(t, 1, changes.TotalBreakingChanges())
+}
+
+func TestDetectRenames_Component(t *testing.T) {
+	left := `openapi: 3.1.0
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string`
+
+	right := `openapi: 3.1.0
+components:
+  schemas:
+    Animal:
+      type: object
+      properties:
+        name:
+          type: string`
+
+	changes := compareSchemaUsageDocs(left, right)
+	DetectRenames(changes, 0)
+	assert.Len(t, changes.ComponentsChanges.Changes, 1)
+	c := changes.ComponentsChanges.Changes[0]
+	assert.Equal(t, Renamed, c.ChangeType)
+	assert.Equal(t, v3.SchemasLabel, c.Property)
+	assert.Equal(t, "Pet", c.Original)
+	assert.Equal(t, "Animal", c.New)
+	assert.False(t, c.Breaking)
+	assert.Equal(t, 0, changes.TotalBreakingChanges())
+}
+
+func TestDetectRenames_SchemaProperty(t *testing.T) {
+	left := `openapi: 3.1.0
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+          description: the name of the pet
+          maxLength: 64
+        age:
+          type: integer
+    Owner:
+      type: object
+      properties:
+        id:
+          type: integer
+        address:
+          type: string
+          description: where the owner lives
+          maxLength: 256
+    Shop:
+      type: object
+      properties:
+        id:
+          type: integer`
+
+	right := `openapi: 3.1.0
+components:
+  schemas:
+    Pet:
+      type: object
+      description: a pet
+      properties:
+        petName:
+          type: string
+          description: the name of the pet
+          maxLength: 64
+        age:
+          type: integer
+    Owner:
+      type: object
+      properties:
+        id:
+          type: integer
+    Shop:
+      type: object
+      properties:
+        id:
+          type: integer
+        address:
+          type: string
+          description: where the owner lives
+          maxLength: 256`
+
+	changes := compareSchemaUsageDocs(left, right)
+	DetectRenames(changes, 0)
+	schemas := changes.ComponentsChanges.SchemaChanges
+
+	// the description is added, and the name property is renamed.
+	if assert.Len(t, schemas["Pet"].Changes, 2) {
+		c := schemas["Pet"].Changes[1]
+		assert.Equal(t, Renamed, c.ChangeType)
+		assert.Equal(t, v3.PropertiesLabel, c.Property)
+		assert.Equal(t, "name", c.Original)
+		assert.Equal(t, "petName", c.New)
+		assert.True(t, c.Breaking)
+	}
+
+	// the removal from Owner is reported as a move, and the addition to Shop is dropped.
+	if assert.Len(t, schemas["Owner"].Changes, 1) {
+		c := schemas["Owner"].Changes[0]
+		assert.Equal(t, Moved, c.ChangeType)
+		assert.Equal(t, "address", c.Original)
+		assert.Equal(t, "address", c.New)
+		assert.True(t, c.Breaking)
+	}
+	assert.Empty(t, schemas["Shop"].Changes)
+}
+
+func TestDetectRenames_Dissimilar(t *testing.T) {
+	left := `openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        "200":
+          description: pets`
+
+	right := `openapi: 3.1.0
+paths:
+  /owners:
+    get:
+      operationId: listOwners
+      summary: list the owners
+      tags: [owners]
+      responses:
+        "200":
+          description: pets`
+
+	changes := compareSchemaUsageDocs(left, right)
+	DetectRenames(changes, 0)
+	assert.Len(t, changes.PathsChanges.Changes, 2)
+	for _, c := range changes.PathsChanges.Changes {
+		assert.NotEqual(t, Renamed, c.ChangeType)
+	}
+
+	// a low enough similarity pairs anything of the same kind.
+	DetectRenames(changes, 0.01)
+	assert.Len(t, changes.PathsChanges.Changes, 1)
+	assert.Equal(t, Renamed, changes.PathsChanges.Changes[0].ChangeType)
+}
+
+func TestDetectRenames_BreakingRules(t *testing.T) {
+	left := `openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        "200":
+          description: pets`
+
+	right := `openapi: 3.1.0
+paths:
+  /animals:
+    get:
+      operationId: listPets
+      responses:
+        "200":
+          description: pets`
+
+	changes := compareSchemaUsageDocs(left, right)
+	DetectRenames(changes, 0)
+
+	rules, _ := LoadBreakingRules([]byte(`paths:
+  path:
+    renamed: false`))
+	rules.Apply(changes)
+	assert.False(t, changes.PathsChanges.Changes[0].Breaking)
+	assert.Equal(t, 0, changes.TotalBreakingChanges())
+
+	DetectRenames(nil, 0)
+}
@@ -46,6 +46,9 @@ func (rb *RequestBodyChanges) TotalBreakingChanges() int {
     for k := range rb.ContentChanges {
         c += rb.ContentChanges[k].TotalBreakingChanges()
     }
+    if rb.ExtensionChanges != nil {
+        c += rb.ExtensionChanges.TotalBreakingChanges()
+    }
     return c
 }
 
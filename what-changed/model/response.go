@@ -93,6 +93,12 @@ func (r *ResponseChanges) TotalBreakingChanges() int {
     for k := range r.LinkChanges {
         c += r.LinkChanges[k].TotalBreakingChanges()
     }
+    if r.ExamplesChanges != nil {
+        c += r.ExamplesChanges.TotalBreakingChanges()
+    }
+    if r.ExtensionChanges != nil {
+        c += r.ExtensionChanges.TotalBreakingChanges()
+    }
     return c
 }
 
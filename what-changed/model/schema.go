@@ -43,6 +43,11 @@ type SchemaChanges struct {
     UnevaluatedPropertiesChanges *SchemaChanges            `json:"unevaluatedProperties,omitempty" yaml:"unevaluatedProperties,omitempty"`
     DependentSchemasChanges      map[string]*SchemaChanges `json:"dependentSchemas,omitempty" yaml:"dependentSchemas,omitempty"`
     PatternPropertiesChanges     map[string]*SchemaChanges `json:"patternProperties,omitempty" yaml:"patternProperties,omitempty"`
+    ContentSchemaChanges         *SchemaChanges            `json:"contentSchema,omitempty" yaml:"contentSchema,omitempty"`
+    DefsChanges                  map[string]*SchemaChanges `json:"$defs,omitempty" yaml:"$defs,omitempty"`
+
+    // Usage is where the schema is used, it is only known when whole documents are compared.
+    Usage SchemaUsage `json:"usage,omitempty" yaml:"usage,omitempty"`
 }
 
 // GetAllChanges returns a slice of all changes made between Responses objects
@@ -121,6 +126,16 @@ func (s *SchemaChanges) GetAllChanges() []*Change {
             }
         }
     }
+    if s.ContentSchemaChanges != nil {
+        changes = append(changes, s.ContentSchemaChanges.GetAllChanges()...)
+    }
+    if s.DefsChanges != nil {
+        for n := range s.DefsChanges {
+            if s.DefsChanges[n] != nil {
+                changes = append(changes, s.DefsChanges[n].GetAllChanges()...)
+            }
+        }
+    }
     if s.ExternalDocChanges != nil {
         changes = append(changes, s.ExternalDocChanges.GetAllChanges()...)
     }
@@ -200,6 +215,16 @@ func (s *SchemaChanges) TotalChanges() int {
             t += s.PatternPropertiesChanges[n].TotalChanges()
         }
     }
+    if s.ContentSchemaChanges != nil {
+        t += s.ContentSchemaChanges.TotalChanges()
+    }
+    if s.DefsChanges != nil {
+        for n := range s.DefsChanges {
+            if s.DefsChanges[n] != nil {
+                t += s.DefsChanges[n].TotalChanges()
+            }
+        }
+    }
     if s.ExternalDocChanges != nil {
         t += s.ExternalDocChanges.TotalChanges()
     }
@@ -275,6 +300,16 @@ func (s *SchemaChanges) TotalBreakingChanges() int {
             t += s.PatternPropertiesChanges[n].TotalBreakingChanges()
         }
     }
+    if s.ContentSchemaChanges != nil {
+        t += s.ContentSchemaChanges.TotalBreakingChanges()
+    }
+    if s.DefsChanges != nil {
+        for n := range s.DefsChanges {
+            if s.DefsChanges[n] != nil {
+                t += s.DefsChanges[n].TotalBreakingChanges()
+            }
+        }
+    }
     if s.XMLChanges != nil {
         t += s.XMLChanges.TotalBreakingChanges()
     }
@@ -283,6 +318,12 @@ func (s *SchemaChanges) TotalBreakingChanges() int {
             t += s.SchemaPropertyChanges[n].TotalBreakingChanges()
         }
     }
+    if s.ExternalDocChanges != nil {
+        t += s.ExternalDocChanges.TotalBreakingChanges()
+    }
+    if s.ExtensionChanges != nil {
+        t += s.ExtensionChanges.TotalBreakingChanges()
+    }
     return t
 }
 
@@ -369,6 +410,9 @@ func CompareSchemas(l, r *base.SchemaProxy) *SchemaChanges {
         patterns, patternsTotal := checkMappedSchemaOfASchema(lSchema.PatternProperties.Value, rSchema.PatternProperties.Value, &changes, doneChan)
         sc.PatternPropertiesChanges = patterns
 
+        defs, defsTotal := checkMappedSchemaOfASchema(lSchema.Defs.Value, rSchema.Defs.Value, &changes, doneChan)
+        sc.DefsChanges = defs
+
         // check polymorphic and multi-values async for speed.
         go extractSchemaChanges(lSchema.OneOf.Value, rSchema.OneOf.Value, v3.OneOfLabel,
             &sc.OneOfChanges, &changes, doneChan)
@@ -379,7 +423,7 @@ func CompareSchemas(l, r *base.SchemaProxy) *SchemaChanges {
         go extractSchemaChanges(lSchema.AnyOf.Value, rSchema.AnyOf.Value, v3.AnyOfLabel,
             &sc.AnyOfChanges, &changes, doneChan)
 
-        totalChecks := totalProperties + depsTotal + patternsTotal + 3
+        totalChecks := totalProperties + depsTotal + patternsTotal + defsTotal + 3
         completedChecks := 0
         for completedChecks < totalChecks {
             select {
@@ -542,28 +586,6 @@ func checkSchemaPropertyChanges(
         New:       rSchema,
     })
 
-    // ExclusiveMaximum
-    props = append(props, &PropertyCheck{
-        LeftNode:  lSchema.ExclusiveMaximum.ValueNode,
-        RightNode: rSchema.ExclusiveMaximum.ValueNode,
-        Label:     v3.ExclusiveMaximumLabel,
-        Changes:   changes,
-        Breaking:  true,
-        Original:  lSchema,
-        New:       rSchema,
-    })
-
-    // ExclusiveMinimum
-    props = append(props, &PropertyCheck{
-        LeftNode:  lSchema.ExclusiveMinimum.ValueNode,
-        RightNode: rSchema.ExclusiveMinimum.ValueNode,
-        Label:     v3.ExclusiveMinimumLabel,
-        Changes:   changes,
-        Breaking:  true,
-        Original:  lSchema,
-        New:       rSchema,
-    })
-
     // Type
     props = append(props, &PropertyCheck{
         LeftNode:  lSchema.Type.ValueNode,
@@ -586,39 +608,6 @@ func checkSchemaPropertyChanges(
         New:       rSchema,
     })
 
-    // MultipleOf
-    props = append(props, &PropertyCheck{
-        LeftNode:  lSchema.MultipleOf.ValueNode,
-        RightNode: rSchema.MultipleOf.ValueNode,
-        Label:     v3.MultipleOfLabel,
-        Changes:   changes,
-        Breaking:  true,
-        Original:  lSchema,
-        New:       rSchema,
-    })
-
-    // Maximum
-    props = append(props, &PropertyCheck{
-        LeftNode:  lSchema.Maximum.ValueNode,
-        RightNode: rSchema.Maximum.ValueNode,
-        Label:     v3.MaximumLabel,
-        Changes:   changes,
-        Breaking:  true,
-        Original:  lSchema,
-        New:       rSchema,
-    })
-
-    // Minimum
-    props = append(props, &PropertyCheck{
-        LeftNode:  lSchema.Minimum.ValueNode,
-        RightNode: rSchema.Minimum.ValueNode,
-        Label:     v3.MinimumLabel,
-        Changes:   changes,
-        Breaking:  true,
-        Original:  lSchema,
-        New:       rSchema,
-    })
-
     // MaxLength
     props = append(props, &PropertyCheck{
         LeftNode:  lSchema.MaxLength.ValueNode,
@@ -831,6 +820,64 @@ func checkSchemaPropertyChanges(
         New:       rSchema,
     })
 
+    // $id
+    props = append(props, &PropertyCheck{
+        LeftNode:  lSchema.Id.ValueNode,
+        RightNode: rSchema.Id.ValueNode,
+        Label:     v3.IdLabel,
+        Changes:   changes,
+        Breaking:  true,
+        Original:  lSchema,
+        New:       rSchema,
+    })
+
+    // $dynamicRef
+    props = append(props, &PropertyCheck{
+        LeftNode:  lSchema.DynamicRef.ValueNode,
+        RightNode: rSchema.DynamicRef.ValueNode,
+        Label:     v3.DynamicRefLabel,
+        Changes:   changes,
+        Breaking:  true,
+        Original:  lSchema,
+        New:       rSchema,
+    })
+
+    // $dynamicAnchor
+    props = append(props, &PropertyCheck{
+        LeftNode:  lSchema.DynamicAnchor.ValueNode,
+        RightNode: rSchema.DynamicAnchor.ValueNode,
+        Label:     v3.DynamicAnchorLabel,
+        Changes:   changes,
+        Breaking:  true,
+        Original:  lSchema,
+        New:       rSchema,
+    })
+
+    // $comment
+    props = append(props, &PropertyCheck{
+        LeftNode:  lSchema.Comment.ValueNode,
+        RightNode: rSchema.Comment.ValueNode,
+        Label:     v3.CommentLabel,
+        Changes:   changes,
+        Breaking:  false,
+        Original:  lSchema,
+        New:       rSchema,
+    })
+
+    // Const (only if a scalar)
+    if !utils.IsNodeMap(lSchema.Const.ValueNode) && !utils.IsNodeArray(lSchema.Const.ValueNode) &&
+        !utils.IsNodeMap(rSchema.Const.ValueNode) && !utils.IsNodeArray(rSchema.Const.ValueNode) {
+        props = append(props, &PropertyCheck{
+            LeftNode:  lSchema.Const.ValueNode,
+            RightNode: rSchema.Const.ValueNode,
+            Label:     v3.ConstLabel,
+            Changes:   changes,
+            Breaking:  true,
+            Original:  lSchema,
+            New:       rSchema,
+        })
+    }
+
     // Required
     j := make(map[string]int)
     k := make(map[string]int)
@@ -1068,6 +1115,73 @@ func checkSchemaPropertyChanges(
             lSchema.Items.ValueNode, nil, true, lSchema.Items.Value, nil)
     }
 
+    // ContentSchema
+    if lSchema.ContentSchema.Value != nil && rSchema.ContentSchema.Value != nil {
+        if !low.AreEqual(lSchema.ContentSchema.Value, rSchema.ContentSchema.Value) {
+            sc.ContentSchemaChanges = CompareSchemas(lSchema.ContentSchema.Value, rSchema.ContentSchema.Value)
+        }
+    }
+    // added ContentSchema
+    if lSchema.ContentSchema.Value == nil && rSchema.ContentSchema.Value != nil {
+        CreateChange(changes, ObjectAdded, v3.ContentSchemaLabel,
+            nil, rSchema.ContentSchema.ValueNode, true, nil, rSchema.ContentSchema.Value)
+    }
+    // removed ContentSchema
+    if lSchema.ContentSchema.Value != nil && rSchema.ContentSchema.Value == nil {
+        CreateChange(changes, ObjectRemoved, v3.ContentSchemaLabel,
+            lSchema.ContentSchema.ValueNode, nil, true, lSchema.ContentSchema.Value, nil)
+    }
+
+    // $vocabulary
+    lVocab := make(map[string]low.ValueReference[bool])
+    rVocab := make(map[string]low.ValueReference[bool])
+    for k, v := range lSchema.Vocabulary.Value {
+        lVocab[k.Value] = v
+    }
+    for k, v := range rSchema.Vocabulary.Value {
+        rVocab[k.Value] = v
+    }
+    for k, v := range rVocab {
+        if lv, ok := lVocab[k]; !ok {
+            CreateChange(changes, PropertyAdded, v3.VocabularyLabel,
+                nil, v.ValueNode, true, nil, k)
+        } else if lv.Value != v.Value {
+            CreateChange(changes, Modified, v3.VocabularyLabel,
+                lv.ValueNode, v.ValueNode, true, lv.Value, v.Value)
+        }
+    }
+    for k, v := range lVocab {
+        if _, ok := rVocab[k]; !ok {
+            CreateChange(changes, PropertyRemoved, v3.VocabularyLabel,
+                v.ValueNode, nil, true, k, nil)
+        }
+    }
+
+    // DependentRequired
+    lDepReq := make(map[string]low.ValueReference[[]string])
+    rDepReq := make(map[string]low.ValueReference[[]string])
+    for k, v := range lSchema.DependentRequired.Value {
+        lDepReq[k.Value] = v
+    }
+    for k, v := range rSchema.DependentRequired.Value {
+        rDepReq[k.Value] = v
+    }
+    for k, v := range rDepReq {
+        if lv, ok := lDepReq[k]; !ok {
+            CreateChange(changes, PropertyAdded, v3.DependentRequiredLabel,
+                nil, v.ValueNode, true, nil, k)
+        } else if low.GenerateHashString(lv.Value) != low.GenerateHashString(v.Value) {
+            CreateChange(changes, Modified, v3.DependentRequiredLabel,
+                lv.ValueNode, v.ValueNode, true, lv.Value, v.Value)
+        }
+    }
+    for k, v := range lDepReq {
+        if _, ok := rDepReq[k]; !ok {
+            CreateChange(changes, PropertyRemoved, v3.DependentRequiredLabel,
+                v.ValueNode, nil, true, k, nil)
+        }
+    }
+
     // check extensions
     sc.ExtensionChanges = CompareExtensions(lSchema.Extensions, rSchema.Extensions)
 
@@ -1085,8 +1199,53 @@ func checkSchemaPropertyChanges(
         }
     }
 
+    // if const is an object or an array, then hash it
+    if utils.IsNodeMap(lSchema.Const.ValueNode) || utils.IsNodeArray(lSchema.Const.ValueNode) ||
+        utils.IsNodeMap(rSchema.Const.ValueNode) || utils.IsNodeArray(rSchema.Const.ValueNode) {
+        lHash := low.GenerateHashString(lSchema.Const.Value)
+        rHash := low.GenerateHashString(rSchema.Const.Value)
+        if lHash != rHash {
+            CreateChange(changes, Modified, v3.ConstLabel,
+                lSchema.Const.ValueNode, rSchema.Const.ValueNode, true,
+                lSchema.Const.Value, rSchema.Const.Value)
+        }
+    }
+
     // check core properties
     CheckProperties(props)
+
+    // numeric constraints are compared by value, not by how they are written, so '1' and '1.0' are the same.
+    numericProps := []*PropertyCheck{
+        {
+            LeftNode:  lSchema.ExclusiveMaximum.ValueNode,
+            RightNode: rSchema.ExclusiveMaximum.ValueNode,
+            Label:     v3.ExclusiveMaximumLabel,
+        },
+        {
+            LeftNode:  lSchema.ExclusiveMinimum.ValueNode,
+            RightNode: rSchema.ExclusiveMinimum.ValueNode,
+            Label:     v3.ExclusiveMinimumLabel,
+        },
+        {
+            LeftNode:  lSchema.MultipleOf.ValueNode,
+            RightNode: rSchema.MultipleOf.ValueNode,
+            Label:     v3.MultipleOfLabel,
+        },
+        {
+            LeftNode:  lSchema.Maximum.ValueNode,
+            RightNode: rSchema.Maximum.ValueNode,
+            Label:     v3.MaximumLabel,
+        },
+        {
+            LeftNode:  lSchema.Minimum.ValueNode,
+            RightNode: rSchema.Minimum.ValueNode,
+            Label:     v3.MinimumLabel,
+        },
+    }
+    for _, n := range numericProps {
+        CheckPropertyAdditionOrRemoval(n.LeftNode, n.RightNode, n.Label, changes, true, lSchema, rSchema)
+        CheckForNumericModification(n.LeftNode, n.RightNode, n.Label, changes, true, lSchema, rSchema)
+    }
 }
 
 func checkExamples(lSchema *base.Schema, rSchema *base.Schema, changes *[]*Change) {
@@ -2388,3 +2388,228 @@ components:
 	assert.Equal(t, 1, changes.TotalBreakingChanges())
 
 }
+
+func TestCompareSchemas_31_Keywords_Modified(t *testing.T) {
+	left := `openapi: 3.1
+components:
+  schemas:
+    OK:
+      $id: https://pb33f.io/schemas/ok
+      $dynamicRef: '#meta'
+      $dynamicAnchor: meta
+      $comment: all is well
+      const: yes
+      $vocabulary:
+        https://json-schema.org/draft/2020-12/vocab/core: true
+      dependentRequired:
+        cheese:
+          - bun`
+
+	right := `openapi: 3.1
+components:
+  schemas:
+    OK:
+      $id: https://pb33f.io/schemas/not-ok
+      $dynamicRef: '#meta-data'
+      $dynamicAnchor: meta-data
+      $comment: all is not well
+      const: no
+      $vocabulary:
+        https://json-schema.org/draft/2020-12/vocab/core: false
+      dependentRequired:
+        cheese:
+          - patty`
+
+	leftDoc, rightDoc := test_BuildDoc(left, right)
+
+	// extract left reference schema and non reference schema.
+	lSchemaProxy := leftDoc.Components.Value.FindSchema("OK").Value
+	rSchemaProxy := rightDoc.Components.Value.FindSchema("OK").Value
+
+	changes := CompareSchemas(lSchemaProxy, rSchemaProxy)
+	assert.NotNil(t, changes)
+	assert.Equal(t, 7, changes.TotalChanges())
+	assert.Len(t, changes.GetAllChanges(), 7)
+	assert.Equal(t, 6, changes.TotalBreakingChanges())
+
+	labels := make(map[string]*Change)
+	for _, c := range changes.Changes {
+		assert.Equal(t, Modified, c.ChangeType)
+		labels[c.Property] = c
+	}
+	assert.Equal(t, "https://pb33f.io/schemas/not-ok", labels[v3.IdLabel].New)
+	assert.Equal(t, "#meta-data", labels[v3.DynamicRefLabel].New)
+	assert.Equal(t, "meta-data", labels[v3.DynamicAnchorLabel].New)
+	assert.Equal(t, "all is not well", labels[v3.CommentLabel].New)
+	assert.False(t, labels[v3.CommentLabel].Breaking)
+	assert.Equal(t, "no", labels[v3.ConstLabel].New)
+	assert.Equal(t, "false", labels[v3.VocabularyLabel].New)
+	assert.Equal(t, []string{"patty"}, labels[v3.DependentRequiredLabel].NewObject)
+}
+
+func TestCompareSchemas_31_Keywords_AddRemove(t *testing.T) {
+	left := `openapi: 3.1
+components:
+  schemas:
+    OK:
+      contentSchema:
+        type: string
+      $vocabulary:
+        https://json-schema.org/draft/2020-12/vocab/core: true
+      dependentRequired:
+        cheese:
+          - bun`
+
+	right := `openapi: 3.1
+components:
+  schemas:
+    OK:
+      const:
+        name: burger
+      $vocabulary:
+        https://json-schema.org/draft/2020-12/vocab/validation: true
+      dependentRequired:
+        pickles:
+          - bun`
+
+	leftDoc, rightDoc := test_BuildDoc(left, right)
+
+	// extract left reference schema and non reference schema.
+	lSchemaProxy := leftDoc.Components.Value.FindSchema("OK").Value
+	rSchemaProxy := rightDoc.Components.Value.FindSchema("OK").Value
+
+	changes := CompareSchemas(lSchemaProxy, rSchemaProxy)
+	assert.NotNil(t, changes)
+	assert.Equal(t, 6, changes.TotalChanges())
+	assert.Equal(t, 6, changes.TotalBreakingChanges())
+
+	types := make(map[string][]int)
+	for _, c := range changes.Changes {
+		types[c.Property] = append(types[c.Property], c.ChangeType)
+	}
+	assert.Equal(t, []int{ObjectRemoved}, types[v3.ContentSchemaLabel])
+	assert.Equal(t, []int{Modified}, types[v3.ConstLabel])
+	assert.ElementsMatch(t, []int{PropertyAdded, PropertyRemoved}, types[v3.VocabularyLabel])
+	assert.ElementsMatch(t, []int{PropertyAdded, PropertyRemoved}, types[v3.DependentRequiredLabel])
+
+	// swap and check content schema is added
+	changes = CompareSchemas(rSchemaProxy, lSchemaProxy)
+	assert.Equal(t, 6, changes.TotalChanges())
+	for _, c := range changes.Changes {
+		if c.Property == v3.ContentSchemaLabel {
+			assert.Equal(t, ObjectAdded, c.ChangeType)
+		}
+	}
+}
+
+func TestCompareSchemas_31_DefsAndContentSchema(t *testing.T) {
+	left := `openapi: 3.1
+components:
+  schemas:
+    OK:
+      contentSchema:
+        type: string
+      $defs:
+        bun:
+          type: string
+        patty:
+          type: integer`
+
+	right := `openapi: 3.1
+components:
+  schemas:
+    OK:
+      contentSchema:
+        type: integer
+      $defs:
+        bun:
+          type: boolean
+        lettuce:
+          type: string`
+
+	leftDoc, rightDoc := test_BuildDoc(left, right)
+
+	// extract left reference schema and non reference schema.
+	lSchemaProxy := leftDoc.Components.Value.FindSchema("OK").Value
+	rSchemaProxy := rightDoc.Components.Value.FindSchema("OK").Value
+
+	changes := CompareSchemas(lSchemaProxy, rSchemaProxy)
+	assert.NotNil(t, changes)
+	assert.Equal(t, 4, changes.TotalChanges())
+	assert.Len(t, changes.GetAllChanges(), 4)
+	assert.Equal(t, 3, changes.TotalBreakingChanges())
+	assert.Equal(t, Modified, changes.ContentSchemaChanges.Changes[0].ChangeType)
+	assert.Equal(t, "integer", changes.ContentSchemaChanges.Changes[0].New)
+	assert.Equal(t, Modified, changes.DefsChanges["bun"].Changes[0].ChangeType)
+	assert.Equal(t, "boolean", changes.DefsChanges["bun"].Changes[0].New)
+}
+
+func TestCompareSchemas_Decimals_Same(t *testing.T) {
+	left := `openapi: 3.1
+components:
+  schemas:
+    OK:
+      multipleOf: 1
+      minimum: 0.5
+      maximum: 10
+      exclusiveMaximum: 100`
+
+	right := `openapi: 3.1
+components:
+  schemas:
+    OK:
+      multipleOf: 1.0
+      minimum: 0.50
+      maximum: 10.0
+      exclusiveMaximum: 1e2`
+
+	leftDoc, rightDoc := test_BuildDoc(left, right)
+
+	lSchemaProxy := leftDoc.Components.Value.FindSchema("OK").Value
+	rSchemaProxy := rightDoc.Components.Value.FindSchema("OK").Value
+
+	changes := CompareSchemas(lSchemaProxy, rSchemaProxy)
+	assert.Nil(t, changes)
+}
+
+func TestCompareSchemas_Decimals_Modified(t *testing.T) {
+	left := `openapi: 3.1
+components:
+  schemas:
+    OK:
+      multipleOf: 0.01
+      minimum: 0.5
+      maximum: 99.99
+      exclusiveMinimum: 0.1
+      exclusiveMaximum: true`
+
+	right := `openapi: 3.1
+components:
+  schemas:
+    OK:
+      multipleOf: 0.02
+      minimum: 0.25
+      maximum: 99.999
+      exclusiveMinimum: 0.1
+      exclusiveMaximum: 100`
+
+	leftDoc, rightDoc := test_BuildDoc(left, right)
+
+	lSchemaProxy := leftDoc.Components.Value.FindSchema("OK").Value
+	rSchemaProxy := rightDoc.Components.Value.FindSchema("OK").Value
+
+	changes := CompareSchemas(lSchemaProxy, rSchemaProxy)
+	assert.NotNil(t, changes)
+	assert.Equal(t, 4, changes.TotalChanges())
+	assert.Equal(t, 4, changes.TotalBreakingChanges())
+
+	labels := make(map[string]*Change)
+	for _, c := range changes.Changes {
+		assert.Equal(t, Modified, c.ChangeType)
+		labels[c.Property] = c
+	}
+	assert.Equal(t, "0.02", labels[v3.MultipleOfLabel].New)
+	assert.Equal(t, "0.25", labels[v3.MinimumLabel].New)
+	assert.Equal(t, "99.999", labels[v3.MaximumLabel].New)
+	assert.Equal(t, "100", labels[v3.ExclusiveMaximumLabel].New)
+}
@@ -0,0 +1,308 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"reflect"
+	"strings"
+
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// SchemaUsage describes where a schema is used, which decides if a change to the schema breaks clients. A schema
+// can be used in more than one place (a component schema used by both a request and a response for example), so
+// SchemaUsage is a set of flags.
+//
+// Callbacks and webhooks are sent by the API to the client, so their usage is flipped: a callback request body
+// is used like a response (the client receives it), and a callback response is used like a request body.
+type SchemaUsage uint8
+
+const (
+	// SchemaUsageRequestBody is a schema in a request body.
+	SchemaUsageRequestBody SchemaUsage = 1 << iota
+
+	// SchemaUsageParameter is a schema of a parameter (including a Swagger body parameter).
+	SchemaUsageParameter
+
+	// SchemaUsageResponse is a schema in a response, or of a response header.
+	SchemaUsageResponse
+)
+
+// IsRequest returns true if the schema is used by something sent by the client.
+func (u SchemaUsage) IsRequest() bool {
+	return u&(SchemaUsageRequestBody|SchemaUsageParameter) != 0
+}
+
+// IsResponse returns true if the schema is used by something received by the client.
+func (u SchemaUsage) IsResponse() bool {
+	return u&SchemaUsageResponse != 0
+}
+
+// String returns the usages as a comma separated list, like 'requestBody,response'.
+func (u SchemaUsage) String() string {
+	var usages []string
+	if u&SchemaUsageRequestBody != 0 {
+		usages = append(usages, v3.RequestBodyLabel)
+	}
+	if u&SchemaUsageParameter != 0 {
+		usages = append(usages, "parameter")
+	}
+	if u&SchemaUsageResponse != 0 {
+		usages = append(usages, "response")
+	}
+	return strings.Join(usages, ",")
+}
+
+// MarshalText renders the usage as a string, see String.
+func (u SchemaUsage) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// flip swaps the direction of a usage, for callbacks and webhooks.
+func (u SchemaUsage) flip() SchemaUsage {
+	var flipped SchemaUsage
+	if u.IsRequest() {
+		flipped |= SchemaUsageResponse
+	}
+	if u.IsResponse() {
+		flipped |= SchemaUsageRequestBody
+	}
+	return flipped
+}
+
+// IsBreaking returns the breaking classification of a type of change made to a property of a schema with this
+// usage, the second value is false if the usage makes no difference to the change.
+//
+// Changes that make a schema stricter break clients sending it (adding a required property, removing an enum
+// value), and changes that make a schema looser break clients receiving it (removing a required property, adding
+// an enum value). A change to a schema used in both directions is breaking if it breaks either.
+func (u SchemaUsage) IsBreaking(property string, changeType int) (bool, bool) {
+	var breaking, found bool
+	if u.IsRequest() {
+		b, ok := requestSchemaRules.IsBreaking("schema", property, changeType)
+		breaking, found = breaking || b, found || ok
+	}
+	if u.IsResponse() {
+		b, ok := responseSchemaRules.IsBreaking("schema", property, changeType)
+		breaking, found = breaking || b, found || ok
+	}
+	return breaking, found
+}
+
+var (
+	breakingTrue  = true
+	breakingFalse = false
+
+	// requestSchemaRules classify changes to schemas sent by clients.
+	requestSchemaRules = BreakingRules{"schema": {
+		v3.RequiredLabel: {Added: &breakingTrue, Removed: &breakingFalse},
+		v3.EnumLabel:     {Added: &breakingFalse, Removed: &breakingTrue},
+	}}
+
+	// responseSchemaRules classify changes to schemas received by clients.
+	responseSchemaRules = BreakingRules{"schema": {
+		v3.RequiredLabel: {Added: &breakingFalse, Removed: &breakingTrue},
+		v3.EnumLabel:     {Added: &breakingTrue, Removed: &breakingFalse},
+	}}
+)
+
+// applySchemaUsage sets the usage of every schema in a DocumentChanges tree, and classifies the changes made to
+// them for that usage. Component schemas get the usage of everywhere they are referenced from, in either the left
+// or right document. Schemas with no known usage keep their classification.
+func applySchemaUsage(changes *DocumentChanges, left, right *index.SpecIndex) {
+	components := make(map[string]SchemaUsage)
+	for _, idx := range []*index.SpecIndex{left, right} {
+		if idx != nil {
+			traceSchemaUsage(idx, components)
+		}
+	}
+	w := &schemaUsageWalker{components: components}
+	w.walk(reflect.ValueOf(changes), 0, false)
+}
+
+// schemaUsageWalker walks a change model, working out the usage of each schema from its place in the tree.
+type schemaUsageWalker struct {
+	// components is the usage of each component schema (or Swagger definition), by name.
+	components map[string]SchemaUsage
+}
+
+// walk visits a change model with a usage, flipped is true inside callbacks and webhooks.
+func (w *schemaUsageWalker) walk(value reflect.Value, usage SchemaUsage, flipped bool) {
+	switch value.Kind() {
+	case reflect.Ptr:
+		if !value.IsNil() {
+			w.walk(value.Elem(), usage, flipped)
+		}
+		return
+	case reflect.Slice:
+		if isChangeModel(value.Type().Elem()) {
+			for i := 0; i < value.Len(); i++ {
+				w.walk(value.Index(i), usage, flipped)
+			}
+		}
+		return
+	case reflect.Map:
+		if isChangeModel(value.Type().Elem()) {
+			iter := value.MapRange()
+			for iter.Next() {
+				w.walk(iter.Value(), usage, flipped)
+			}
+		}
+		return
+	case reflect.Struct:
+		if !value.CanAddr() {
+			return
+		}
+	default:
+		return
+	}
+
+	var location SchemaUsage
+	switch c := value.Addr().Interface().(type) {
+	case *RequestBodyChanges:
+		location = SchemaUsageRequestBody
+	case *ParameterChanges:
+		location = SchemaUsageParameter
+	case *ResponseChanges, *ResponsesChanges:
+		location = SchemaUsageResponse
+	case *CallbackChanges:
+		flipped = !flipped
+	case *DocumentChanges:
+		for _, hook := range c.WebhookChanges {
+			w.walk(reflect.ValueOf(hook), usage, !flipped)
+		}
+	case *ComponentsChanges:
+		for name, schema := range c.SchemaChanges {
+			w.walk(reflect.ValueOf(schema), w.components[name], false)
+		}
+		return
+	case *SchemaChanges:
+		if usage != 0 {
+			c.Usage = usage
+			if c.PropertyChanges != nil {
+				for _, change := range c.Changes {
+					if breaking, found := usage.IsBreaking(change.Property, change.ChangeType); found {
+						change.Breaking = breaking
+					}
+				}
+			}
+		}
+	}
+	if location != 0 && usage == 0 {
+		usage = location
+		if flipped {
+			usage = usage.flip()
+		}
+	}
+
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if field.PkgPath != "" || field.Name == "WebhookChanges" || !isChangeModel(field.Type) {
+			continue
+		}
+		w.walk(value.Field(i), usage, flipped)
+	}
+}
+
+// traceSchemaUsage follows every reference made by the operations and webhooks of a document, and records the
+// usage of each component schema (or Swagger definition) that is reached.
+func traceSchemaUsage(idx *index.SpecIndex, components map[string]SchemaUsage) {
+	root := idx.GetRootNode()
+	if root != nil && root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	if root == nil || !utils.IsNodeMap(root) {
+		return
+	}
+	t := &schemaUsageTracer{idx: idx, components: components, visited: make(map[schemaUsageVisit]bool)}
+	if _, paths := utils.FindKeyNodeTop(v3.PathsLabel, root.Content); paths != nil {
+		t.walk(paths, 0, false)
+	}
+	if _, hooks := utils.FindKeyNodeTop(v3.WebhooksLabel, root.Content); hooks != nil {
+		t.walk(hooks, 0, true)
+	}
+}
+
+// schemaUsageVisit is a node that has been walked with a usage.
+type schemaUsageVisit struct {
+	node    *yaml.Node
+	usage   SchemaUsage
+	flipped bool
+}
+
+// schemaUsageTracer records the usage of component schemas.
+type schemaUsageTracer struct {
+	idx        *index.SpecIndex
+	components map[string]SchemaUsage
+	visited    map[schemaUsageVisit]bool
+}
+
+// walk follows every reference under a node. Until a request body, parameter or response is found the usage is
+// zero, and the keys of path items and operations decide the usage of everything under them.
+func (t *schemaUsageTracer) walk(node *yaml.Node, usage SchemaUsage, flipped bool) {
+	if node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+	switch node.Kind {
+	case yaml.SequenceNode:
+		for _, n := range node.Content {
+			t.walk(n, usage, flipped)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			k, v := node.Content[i], node.Content[i+1]
+			if k.Value == "$ref" && utils.IsNodeStringValue(v) {
+				t.follow(v.Value, node, usage, flipped)
+				continue
+			}
+			if usage != 0 {
+				t.walk(v, usage, flipped)
+				continue
+			}
+			var location SchemaUsage
+			switch k.Value {
+			case v3.RequestBodyLabel:
+				location = SchemaUsageRequestBody
+			case v3.ParametersLabel:
+				location = SchemaUsageParameter
+			case v3.ResponsesLabel:
+				location = SchemaUsageResponse
+			case v3.CallbacksLabel:
+				t.walk(v, 0, !flipped)
+				continue
+			}
+			if location != 0 && flipped {
+				location = location.flip()
+			}
+			t.walk(v, location, flipped)
+		}
+	}
+}
+
+// follow walks the target of a reference, and records the usage if it is a component schema.
+func (t *schemaUsageTracer) follow(ref string, node *yaml.Node, usage SchemaUsage, flipped bool) {
+	if usage != 0 {
+		for _, prefix := range []string{"#/components/schemas/", "#/definitions/"} {
+			if strings.HasPrefix(ref, prefix) {
+				name := utils.UnescapePointerSegment(ref[len(prefix):])
+				if !strings.Contains(name, "/") {
+					t.components[name] |= usage
+				}
+			}
+		}
+	}
+	found := t.idx.FindComponent(ref, node)
+	if found == nil || found.Node == nil {
+		return
+	}
+	visit := schemaUsageVisit{node: found.Node, usage: usage, flipped: flipped}
+	if t.visited[visit] {
+		return
+	}
+	t.visited[visit] = true
+	t.walk(found.Node, usage, flipped)
+}
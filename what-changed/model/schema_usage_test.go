@@ -0,0 +1,451 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func compareSchemaUsageDocs(left, right string) *DocumentChanges {
+	siLeft, _ := datamodel.ExtractSpecInfo([]byte(left))
+	siRight, _ := datamodel.ExtractSpecInfo([]byte(right))
+	lDoc, _ := v3.CreateDocument(siLeft)
+	rDoc, _ := v3.CreateDocument(siRight)
+	return CompareDocuments(lDoc, rDoc)
+}
+
+func TestSchemaUsage_String(t *testing.T) {
+	assert.Equal(t, "", SchemaUsage(0).String())
+	assert.Equal(t, "requestBody,response", (SchemaUsageRequestBody | SchemaUsageResponse).String())
+	assert.Equal(t, "parameter", SchemaUsageParameter.String())
+
+	out, _ := json.Marshal(&SchemaChanges{Usage: SchemaUsageResponse})
+	assert.Equal(t, `{"usage":"response"}`, string(out))
+	out, _ = json.Marshal(&SchemaChanges{})
+	assert.Equal(t, `{}`, string(out))
+}
+
+func TestSchemaUsage_IsBreaking(t *testing.T) {
+	breaking, found := SchemaUsageRequestBody.IsBreaking(v3.RequiredLabel, PropertyAdded)
+	assert.True(t, found)
+	assert.True(t, breaking)
+
+	breaking, found = SchemaUsageResponse.IsBreaking(v3.RequiredLabel, PropertyAdded)
+	assert.True(t, found)
+	assert.False(t, breaking)
+
+	breaking, found = SchemaUsageParameter.IsBreaking(v3.EnumLabel, PropertyAdded)
+	assert.True(t, found)
+	assert.False(t, breaking)
+
+	breaking, found = (SchemaUsageParameter | SchemaUsageResponse).IsBreaking(v3.EnumLabel, PropertyAdded)
+	assert.True(t, found)
+	assert.True(t, breaking)
+
+	_, found = SchemaUsageResponse.IsBreaking(v3.TypeLabel, Modified)
+	assert.False(t, found)
+
+	_, found = SchemaUsage(0).IsBreaking(v3.EnumLabel, PropertyAdded)
+	assert.False(t, found)
+}
+
+func TestCompareDocuments_SchemaUsage_Inline(t *testing.T) {
+	left := `openapi: 3.1.0
+paths:
+  /pets:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                kind:
+                  type: string
+                  enum: [cat, dog]
+      responses:
+        "200":
+          description: pet
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [name]
+                properties:
+                  kind:
+                    type: string
+                    enum: [cat, dog]`
+
+	right := `openapi: 3.1.0
+paths:
+  /pets:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [kind]
+              properties:
+                kind:
+                  type: string
+                  enum: [cat, dog, fish]
+      responses:
+        "200":
+          description: pet
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [kind]
+                properties:
+                  kind:
+                    type: string
+                    enum: [cat, dog, fish]`
+
+	changes := compareSchemaUsageDocs(left, right)
+	op := changes.PathsChanges.PathItemsChanges["/pets"].PostChanges
+
+	request := op.RequestBodyChanges.ContentChanges["application/json"].SchemaChanges
+	assert.Equal(t, SchemaUsageRequestBody, request.Usage)
+	assert.Equal(t, SchemaUsageRequestBody, request.SchemaPropertyChanges["kind"].Usage)
+	assert.Len(t, request.Changes, 1)
+	assert.True(t, request.Changes[0].Breaking)                                // required added
+	assert.False(t, request.SchemaPropertyChanges["kind"].Changes[0].Breaking) // enum added
+
+	response := op.ResponsesChanges.ResponseChanges["200"].ContentChanges["application/json"].SchemaChanges
+	assert.Equal(t, SchemaUsageResponse, response.Usage)
+	for _, c := range response.Changes {
+		if c.ChangeType == PropertyAdded {
+			assert.False(t, c.Breaking) // required added
+		} else {
+			assert.True(t, c.Breaking) // required removed
+		}
+	}
+	assert.True(t, response.SchemaPropertyChanges["kind"].Changes[0].Breaking) // enum added
+
+	assert.Equal(t, 3, changes.TotalBreakingChanges())
+}
+
+func TestCompareDocuments_SchemaUsage_Components(t *testing.T) {
+	left := `openapi: 3.1.0
+paths:
+  /pets:
+    post:
+      parameters:
+        - $ref: '#/components/parameters/Kind'
+      requestBody:
+        $ref: '#/components/requestBodies/Pet'
+      responses:
+        "200":
+          description: pet
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+components:
+  parameters:
+    Kind:
+      name: kind
+      in: query
+      schema:
+        $ref: '#/components/schemas/Kind'
+  requestBodies:
+    Pet:
+      content:
+        application/json:
+          schema:
+            $ref: '#/components/schemas/NewPet'
+  schemas:
+    Kind:
+      type: string
+      enum: [cat, dog]
+    NewPet:
+      type: object
+      properties:
+        kind:
+          $ref: '#/components/schemas/Kind'
+    Pet:
+      allOf:
+        - $ref: '#/components/schemas/NewPet'
+    Unused:
+      type: string
+      enum: [cat, dog]`
+
+	right := `openapi: 3.1.0
+paths:
+  /pets:
+    post:
+      parameters:
+        - $ref: '#/components/parameters/Kind'
+      requestBody:
+        $ref: '#/components/requestBodies/Pet'
+      responses:
+        "200":
+          description: pet
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+components:
+  parameters:
+    Kind:
+      name: kind
+      in: query
+      schema:
+        $ref: '#/components/schemas/Kind'
+  requestBodies:
+    Pet:
+      content:
+        application/json:
+          schema:
+            $ref: '#/components/schemas/NewPet'
+  schemas:
+    Kind:
+      type: string
+      enum: [cat, dog, fish]
+    NewPet:
+      type: object
+      required: [kind]
+      properties:
+        kind:
+          $ref: '#/components/schemas/Kind'
+    Pet:
+      allOf:
+        - $ref: '#/components/schemas/NewPet'
+    Unused:
+      type: string
+      enum: [cat, dog, fish]`
+
+	changes := compareSchemaUsageDocs(left, right)
+	schemas := changes.ComponentsChanges.SchemaChanges
+
+	// Kind is used by a parameter, and by a request body and response through NewPet.
+	assert.Equal(t, SchemaUsageParameter|SchemaUsageRequestBody|SchemaUsageResponse, schemas["Kind"].Usage)
+	assert.True(t, schemas["Kind"].Changes[0].Breaking)
+
+	assert.Equal(t, SchemaUsageRequestBody|SchemaUsageResponse, schemas["NewPet"].Usage)
+	assert.True(t, schemas["NewPet"].Changes[0].Breaking)
+
+	// unused schemas keep the default classification.
+	assert.Equal(t, SchemaUsage(0), schemas["Unused"].Usage)
+	assert.False(t, schemas["Unused"].Changes[0].Breaking)
+}
+
+func TestCompareDocuments_SchemaUsage_Callbacks(t *testing.T) {
+	left := `openapi: 3.1.0
+paths:
+  /subscribe:
+    post:
+      callbacks:
+        onEvent:
+          '{$request.body#/url}':
+            post:
+              requestBody:
+                content:
+                  application/json:
+                    schema:
+                      $ref: '#/components/schemas/Event'
+              responses:
+                "200":
+                  description: ok
+webhooks:
+  newPet:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: string
+              enum: [cat]
+      responses:
+        "200":
+          description: ok
+components:
+  schemas:
+    Event:
+      type: string
+      enum: [created]`
+
+	right := `openapi: 3.1.0
+paths:
+  /subscribe:
+    post:
+      callbacks:
+        onEvent:
+          '{$request.body#/url}':
+            post:
+              requestBody:
+                content:
+                  application/json:
+                    schema:
+                      $ref: '#/components/schemas/Event'
+              responses:
+                "200":
+                  description: ok
+webhooks:
+  newPet:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: string
+              enum: [cat, dog]
+      responses:
+        "200":
+          description: ok
+components:
+  schemas:
+    Event:
+      type: string
+      enum: [created, deleted]`
+
+	changes := compareSchemaUsageDocs(left, right)
+
+	// callbacks and webhooks are sent to the client, so their request bodies are used like responses.
+	event := changes.ComponentsChanges.SchemaChanges["Event"]
+	assert.Equal(t, SchemaUsageResponse, event.Usage)
+	assert.True(t, event.Changes[0].Breaking)
+
+	hook := changes.WebhookChanges["newPet"].PostChanges.RequestBodyChanges.ContentChanges["application/json"].SchemaChanges
+	assert.Equal(t, SchemaUsageResponse, hook.Usage)
+	assert.True(t, hook.Changes[0].Breaking)
+}
+
+func TestCompareDocuments_SchemaUsage_Swagger(t *testing.T) {
+	left := `swagger: 2.0
+paths:
+  /pets:
+    post:
+      parameters:
+        - in: body
+          name: pet
+          schema:
+            $ref: '#/definitions/Pet'
+      responses:
+        "200":
+          description: pet
+          schema:
+            type: object
+            required: [name]
+definitions:
+  Pet:
+    type: object
+    properties:
+      kind:
+        type: string
+        enum: [cat, dog]`
+
+	right := `swagger: 2.0
+paths:
+  /pets:
+    post:
+      parameters:
+        - in: body
+          name: pet
+          schema:
+            $ref: '#/definitions/Pet'
+      responses:
+        "200":
+          description: pet
+          schema:
+            type: object
+            required: [name, kind]
+definitions:
+  Pet:
+    type: object
+    required: [kind]
+    properties:
+      kind:
+        type: string
+        enum: [cat, dog, fish]`
+
+	siLeft, _ := datamodel.ExtractSpecInfo([]byte(left))
+	siRight, _ := datamodel.ExtractSpecInfo([]byte(right))
+	lDoc, _ := v2.CreateDocument(siLeft)
+	rDoc, _ := v2.CreateDocument(siRight)
+	changes := CompareDocuments(lDoc, rDoc)
+
+	pet := changes.ComponentsChanges.SchemaChanges["Pet"]
+	assert.Equal(t, SchemaUsageParameter, pet.Usage)
+	assert.True(t, pet.Changes[0].Breaking)                                // required added
+	assert.False(t, pet.SchemaPropertyChanges["kind"].Changes[0].Breaking) // enum added
+
+	response := changes.PathsChanges.PathItemsChanges["/pets"].PostChanges.ResponsesChanges.ResponseChanges["200"].SchemaChanges
+	assert.Equal(t, SchemaUsageResponse, response.Usage)
+	assert.False(t, response.Changes[0].Breaking) // required added
+}
+
+func TestCompareDocuments_SchemaUsage_BreakingRules(t *testing.T) {
+	left := `openapi: 3.1.0
+paths:
+  /pets:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: string
+              enum: [cat, dog]
+      responses:
+        "200":
+          description: pet
+          content:
+            application/json:
+              schema:
+                type: string
+                enum: [cat, dog]`
+
+	right := `openapi: 3.1.0
+paths:
+  /pets:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: string
+              enum: [cat, dog, fish]
+      responses:
+        "200":
+          description: pet
+          content:
+            application/json:
+              schema:
+                type: string
+                enum: [cat, dog, fish]`
+
+	changes := compareSchemaUsageDocs(left, right)
+	op := changes.PathsChanges.PathItemsChanges["/pets"].PostChanges
+	request := op.RequestBodyChanges.ContentChanges["application/json"].SchemaChanges
+	response := op.ResponsesChanges.ResponseChanges["200"].ContentChanges["application/json"].SchemaChanges
+
+	// the usage decides the default classification of the same change.
+	assert.False(t, request.Changes[0].Breaking)
+	assert.True(t, response.Changes[0].Breaking)
+	assert.Equal(t, 1, changes.TotalBreakingChanges())
+
+	// rules are applied after usage, so they win for every usage.
+	rules, _ := LoadBreakingRules([]byte(`schema:
+  enum:
+    added: false`))
+	rules.Apply(changes)
+	assert.False(t, request.Changes[0].Breaking)
+	assert.False(t, response.Changes[0].Breaking)
+	assert.Equal(t, 0, changes.TotalBreakingChanges())
+
+	rules, _ = LoadBreakingRules([]byte(`schema:
+  enum:
+    added: true`))
+	rules.Apply(changes)
+	assert.True(t, request.Changes[0].Breaking)
+	assert.Equal(t, 2, changes.TotalBreakingChanges())
+}
@@ -36,7 +36,11 @@ func (s *ScopesChanges) TotalChanges() int {
 
 // TotalBreakingChanges returns the total number of breaking changes between two Swagger Scopes objects.
 func (s *ScopesChanges) TotalBreakingChanges() int {
-    return s.PropertyChanges.TotalBreakingChanges()
+    c := s.PropertyChanges.TotalBreakingChanges()
+    if s.ExtensionChanges != nil {
+        c += s.ExtensionChanges.TotalBreakingChanges()
+    }
+    return c
 }
 
 // CompareScopes compares a left and right Swagger Scopes objects for changes. If anything is found, returns
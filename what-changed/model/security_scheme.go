@@ -62,6 +62,9 @@ func (ss *SecuritySchemeChanges) TotalBreakingChanges() int {
     if ss.ScopesChanges != nil {
         c += ss.ScopesChanges.TotalBreakingChanges()
     }
+    if ss.ExtensionChanges != nil {
+        c += ss.ExtensionChanges.TotalBreakingChanges()
+    }
     return c
 }
 
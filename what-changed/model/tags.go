@@ -43,7 +43,11 @@ func (t *TagChanges) TotalChanges() int {
 
 // TotalBreakingChanges returns the number of breaking changes made by Tags
 func (t *TagChanges) TotalBreakingChanges() int {
-    return t.PropertyChanges.TotalBreakingChanges()
+    c := t.PropertyChanges.TotalBreakingChanges()
+    if t.ExtensionChanges != nil {
+        c += t.ExtensionChanges.TotalBreakingChanges()
+    }
+    return c
 }
 
 // CompareTags will compare a left (original) and a right (new) slice of ValueReference nodes for
@@ -0,0 +1,311 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package reports
+
+import (
+	"sort"
+	"strings"
+
+	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/pb33f/libopenapi/what-changed/model"
+)
+
+// Changelog is a readable list of every change made to a document, grouped by where the change was made (like a
+// path and operation, or a component schema). Create one with CreateChangelog and render it with Markdown, HTML,
+// JSON or JUnit.
+type Changelog struct {
+	Total    int               `json:"totalChanges"`
+	Breaking int               `json:"breakingChanges"`
+	Groups   []*ChangelogGroup `json:"groups,omitempty"`
+}
+
+// ChangelogGroup holds the changes made in one place of a document. Section is the top level part of the document
+// (like 'paths' or 'components'), Name is the path, webhook or component inside it, and Method is the operation.
+// Name and Method are empty for changes made to the section itself.
+type ChangelogGroup struct {
+	Section  string            `json:"section"`
+	Name     string            `json:"name,omitempty"`
+	Method   string            `json:"method,omitempty"`
+	Total    int               `json:"totalChanges"`
+	Breaking int               `json:"breakingChanges"`
+	Entries  []*ChangelogEntry `json:"changes"`
+}
+
+// ChangelogEntry is a single change, with the line and column numbers of the original and new values, and the
+// location and fingerprint of the change (see model.Change).
+type ChangelogEntry struct {
+	ChangeType     string  `json:"change"`
+	Property       string  `json:"property"`
+	Original       string  `json:"original,omitempty"`
+	New            string  `json:"new,omitempty"`
+	Breaking       bool    `json:"breaking"`
+	OriginalLine   int     `json:"originalLine,omitempty"`
+	OriginalColumn int     `json:"originalColumn,omitempty"`
+	NewLine        int     `json:"newLine,omitempty"`
+	NewColumn      int     `json:"newColumn,omitempty"`
+	Similarity     float64 `json:"similarity,omitempty"`
+	Path           string  `json:"path,omitempty"`
+	JSONPointer    string  `json:"jsonPointer,omitempty"`
+	Fingerprint    string  `json:"fingerprint,omitempty"`
+}
+
+// Title returns a readable name for the group, like 'GET /pets' or 'components Pet'.
+func (g *ChangelogGroup) Title() string {
+	switch {
+	case g.Method != "":
+		return strings.ToUpper(g.Method) + " " + g.Name
+	case g.Section == v3.PathsLabel && g.Name != "":
+		return g.Name
+	case g.Name != "":
+		return g.Section + " " + g.Name
+	}
+	return g.Section
+}
+
+// changelog sections, in the order they are rendered.
+var changelogSections = []string{"document", v3.InfoLabel, v3.ServersLabel, v3.PathsLabel, v3.WebhooksLabel,
+	v3.ComponentsLabel, v3.SecurityLabel, v3.TagsLabel, v3.ExternalDocsLabel}
+
+// operation methods, in the order they are rendered.
+var changelogMethods = []string{v3.GetLabel, v3.PutLabel, v3.PostLabel, v3.DeleteLabel, v3.OptionsLabel,
+	v3.HeadLabel, v3.PatchLabel, v3.TraceLabel}
+
+// CreateChangelog walks a DocumentChanges tree and creates a Changelog from every change in it. Groups are sorted
+// by section, name and method, and the entries of a group are sorted by line number.
+func CreateChangelog(changes *model.DocumentChanges) *Changelog {
+	b := &changelogBuilder{groups: make(map[[3]string]*ChangelogGroup)}
+	if changes == nil {
+		return &Changelog{}
+	}
+	if changes.PropertyChanges != nil {
+		b.add("document", "", "", changes.Changes)
+	}
+	if changes.ExtensionChanges != nil {
+		b.add("document", "", "", changes.ExtensionChanges.GetAllChanges())
+	}
+	if changes.InfoChanges != nil {
+		b.add(v3.InfoLabel, "", "", changes.InfoChanges.GetAllChanges())
+	}
+	for _, s := range changes.ServerChanges {
+		b.add(v3.ServersLabel, "", "", s.GetAllChanges())
+	}
+	if p := changes.PathsChanges; p != nil {
+		if p.PropertyChanges != nil {
+			// paths that were added or removed are grouped with the path.
+			for _, c := range p.Changes {
+				b.add(v3.PathsLabel, changeName(c), "", []*model.Change{c})
+			}
+		}
+		if p.ExtensionChanges != nil {
+			b.add(v3.PathsLabel, "", "", p.ExtensionChanges.GetAllChanges())
+		}
+		for path, pathItem := range p.PathItemsChanges {
+			b.addPathItem(v3.PathsLabel, path, pathItem)
+		}
+	}
+	for name, hook := range changes.WebhookChanges {
+		b.addPathItem(v3.WebhooksLabel, name, hook)
+	}
+	if c := changes.ComponentsChanges; c != nil {
+		if c.PropertyChanges != nil {
+			// components that were added or removed are grouped with the component, if it has a group.
+			for _, change := range c.Changes {
+				switch change.Property {
+				case v3.SchemasLabel, v2.DefinitionsLabel, v3.SecuritySchemesLabel, v2.SecurityDefinitionsLabel:
+					b.add(v3.ComponentsLabel, changeName(change), "", []*model.Change{change})
+				default:
+					b.add(v3.ComponentsLabel, "", "", []*model.Change{change})
+				}
+			}
+		}
+		if c.ExtensionChanges != nil {
+			b.add(v3.ComponentsLabel, "", "", c.ExtensionChanges.GetAllChanges())
+		}
+		for name, schema := range c.SchemaChanges {
+			b.add(v3.ComponentsLabel, name, "", schema.GetAllChanges())
+		}
+		for name, scheme := range c.SecuritySchemeChanges {
+			b.add(v3.ComponentsLabel, name, "", scheme.GetAllChanges())
+		}
+	}
+	for _, s := range changes.SecurityRequirementChanges {
+		b.add(v3.SecurityLabel, "", "", s.GetAllChanges())
+	}
+	for _, t := range changes.TagChanges {
+		b.add(v3.TagsLabel, "", "", t.GetAllChanges())
+	}
+	if changes.ExternalDocChanges != nil {
+		b.add(v3.ExternalDocsLabel, "", "", changes.ExternalDocChanges.GetAllChanges())
+	}
+	return b.changelog()
+}
+
+// changelogBuilder collects changes into groups.
+type changelogBuilder struct {
+	groups map[[3]string]*ChangelogGroup
+}
+
+// addPathItem adds the changes of a path item, with a group for each operation.
+func (b *changelogBuilder) addPathItem(section, name string, p *model.PathItemChanges) {
+	if p == nil {
+		return
+	}
+	if p.PropertyChanges != nil {
+		// operations that were added or removed are grouped with the operation.
+		for _, c := range p.Changes {
+			method := ""
+			if indexOf(changelogMethods, c.Property) >= 0 {
+				method = c.Property
+			}
+			b.add(section, name, method, []*model.Change{c})
+		}
+	}
+	operations := map[string]*model.OperationChanges{
+		v3.GetLabel: p.GetChanges, v3.PutLabel: p.PutChanges, v3.PostLabel: p.PostChanges,
+		v3.DeleteLabel: p.DeleteChanges, v3.OptionsLabel: p.OptionsChanges, v3.HeadLabel: p.HeadChanges,
+		v3.PatchLabel: p.PatchChanges, v3.TraceLabel: p.TraceChanges,
+	}
+	for method, op := range operations {
+		if op != nil {
+			b.add(section, name, method, op.GetAllChanges())
+		}
+	}
+	for _, s := range p.ServerChanges {
+		b.add(section, name, "", s.GetAllChanges())
+	}
+	for _, param := range p.ParameterChanges {
+		b.add(section, name, "", param.GetAllChanges())
+	}
+	if p.ExtensionChanges != nil {
+		b.add(section, name, "", p.ExtensionChanges.GetAllChanges())
+	}
+}
+
+// add adds changes to a group, creating the group if it does not exist.
+func (b *changelogBuilder) add(section, name, method string, changes []*model.Change) {
+	if len(changes) == 0 {
+		return
+	}
+	key := [3]string{section, name, method}
+	g := b.groups[key]
+	if g == nil {
+		g = &ChangelogGroup{Section: section, Name: name, Method: method}
+		b.groups[key] = g
+	}
+	for _, c := range changes {
+		if c == nil {
+			continue
+		}
+		g.Entries = append(g.Entries, newChangelogEntry(c))
+		g.Total++
+		if c.Breaking {
+			g.Breaking++
+		}
+	}
+}
+
+// changelog sorts the groups, and their entries, into a Changelog.
+func (b *changelogBuilder) changelog() *Changelog {
+	cl := &Changelog{}
+	for _, g := range b.groups {
+		sort.SliceStable(g.Entries, func(i, j int) bool {
+			l, r := g.Entries[i], g.Entries[j]
+			if l.line() != r.line() {
+				return l.line() < r.line()
+			}
+			if l.Property != r.Property {
+				return l.Property < r.Property
+			}
+			if l.Original != r.Original {
+				return l.Original < r.Original
+			}
+			return l.New < r.New
+		})
+		cl.Groups = append(cl.Groups, g)
+		cl.Total += g.Total
+		cl.Breaking += g.Breaking
+	}
+	sort.Slice(cl.Groups, func(i, j int) bool {
+		l, r := cl.Groups[i], cl.Groups[j]
+		if l.Section != r.Section {
+			return indexOf(changelogSections, l.Section) < indexOf(changelogSections, r.Section)
+		}
+		if l.Name != r.Name {
+			return l.Name < r.Name
+		}
+		return indexOf(changelogMethods, l.Method) < indexOf(changelogMethods, r.Method)
+	})
+	return cl
+}
+
+// newChangelogEntry creates a ChangelogEntry from a change.
+func newChangelogEntry(c *model.Change) *ChangelogEntry {
+	e := &ChangelogEntry{
+		ChangeType:  ChangeTypeName(c.ChangeType),
+		Property:    c.Property,
+		Original:    c.Original,
+		New:         c.New,
+		Breaking:    c.Breaking,
+		Similarity:  c.Similarity,
+		Path:        c.Path,
+		JSONPointer: c.JSONPointer,
+		Fingerprint: c.Fingerprint,
+	}
+	if ctx := c.Context; ctx != nil {
+		e.OriginalLine, e.OriginalColumn = intValue(ctx.OriginalLine), intValue(ctx.OriginalColumn)
+		e.NewLine, e.NewColumn = intValue(ctx.NewLine), intValue(ctx.NewColumn)
+	}
+	return e
+}
+
+// line returns the line of the new value, or the original value if there is no new one.
+func (e *ChangelogEntry) line() int {
+	if e.NewLine > 0 {
+		return e.NewLine
+	}
+	return e.OriginalLine
+}
+
+// ChangeTypeName returns a readable name for a type of change (like model.ObjectAdded), one of 'modified',
+// 'added', 'removed', 'renamed' or 'moved'.
+func ChangeTypeName(changeType int) string {
+	switch changeType {
+	case model.Modified:
+		return "modified"
+	case model.PropertyAdded, model.ObjectAdded:
+		return "added"
+	case model.PropertyRemoved, model.ObjectRemoved:
+		return "removed"
+	case model.Renamed:
+		return "renamed"
+	case model.Moved:
+		return "moved"
+	}
+	return "unknown"
+}
+
+// changeName returns the name of the object a change added or removed.
+func changeName(c *model.Change) string {
+	if c.New != "" {
+		return c.New
+	}
+	return c.Original
+}
+
+func intValue(i *int) int {
+	if i == nil {
+		return 0
+	}
+	return *i
+}
+
+func indexOf(values []string, value string) int {
+	for i := range values {
+		if values[i] == value {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,189 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package reports
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"html/template"
+	"strings"
+)
+
+// JSON renders the changelog as indented JSON.
+func (c *Changelog) JSON() ([]byte, error) {
+	return json.MarshalIndent(c, "", "  ")
+}
+
+// Markdown renders the changelog as GitHub flavored Markdown, with a table of changes for each group. It's designed
+// to be posted as a comment on a pull request.
+func (c *Changelog) Markdown() []byte {
+	var b bytes.Buffer
+	b.WriteString("# API Changes\n\n")
+	if c.Total == 0 {
+		b.WriteString("No changes found.\n")
+		return b.Bytes()
+	}
+	fmt.Fprintf(&b, "**%d** changes, **%d** breaking.\n", c.Total, c.Breaking)
+	for _, g := range c.Groups {
+		fmt.Fprintf(&b, "\n## %s\n\n", markdownText(g.Title()))
+		b.WriteString("| Change | Property | Original | New | Line | Breaking |\n")
+		b.WriteString("| --- | --- | --- | --- | --- | --- |\n")
+		for _, e := range g.Entries {
+			breaking := ""
+			if e.Breaking {
+				breaking = "**yes**"
+			}
+			fmt.Fprintf(&b, "| %s | %s | %s | %s | %s | %s |\n", e.ChangeType, markdownCode(e.Property),
+				markdownCode(e.Original), markdownCode(e.New), e.Lines(), breaking)
+		}
+	}
+	return b.Bytes()
+}
+
+// markdownText escapes text for a Markdown heading or table cell.
+func markdownText(s string) string {
+	s = strings.ReplaceAll(s, "\n", " ")
+	s = strings.ReplaceAll(s, "|", "\\|")
+	return s
+}
+
+// markdownCode renders a value as inline code in a Markdown table cell.
+func markdownCode(s string) string {
+	if s == "" {
+		return ""
+	}
+	s = strings.ReplaceAll(markdownText(s), "\r", "")
+	if strings.Contains(s, "`") {
+		return "`` " + s + " ``"
+	}
+	return "`" + s + "`"
+}
+
+// Lines returns the original and new line numbers of the entry, like '12 → 14'. Only one number is returned if
+// the lines are the same, or if there is only one value.
+func (e *ChangelogEntry) Lines() string {
+	switch {
+	case e.OriginalLine > 0 && e.NewLine > 0 && e.OriginalLine != e.NewLine:
+		return fmt.Sprintf("%d → %d", e.OriginalLine, e.NewLine)
+	case e.NewLine > 0:
+		return fmt.Sprint(e.NewLine)
+	case e.OriginalLine > 0:
+		return fmt.Sprint(e.OriginalLine)
+	}
+	return ""
+}
+
+// changelogHTML is a self-contained page, everything it needs is inline.
+var changelogHTML = template.Must(template.New("changelog").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>API Changes</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em; color: #24292f; }
+h2 { border-bottom: 1px solid #d0d7de; padding-bottom: 0.3em; font-family: monospace; }
+table { border-collapse: collapse; width: 100%; margin-bottom: 1em; }
+th, td { border: 1px solid #d0d7de; padding: 0.4em 0.6em; text-align: left; vertical-align: top; }
+th { background: #f6f8fa; }
+code { font-family: monospace; white-space: pre-wrap; word-break: break-all; }
+tr.breaking { background: #ffebe9; }
+.badge { border-radius: 1em; padding: 0.1em 0.6em; font-size: 0.8em; color: #fff; background: #cf222e; }
+.summary { font-size: 1.1em; }
+</style>
+</head>
+<body>
+<h1>API Changes</h1>
+{{- if eq .Total 0 }}
+<p class="summary">No changes found.</p>
+{{- else }}
+<p class="summary"><strong>{{ .Total }}</strong> changes, <strong>{{ .Breaking }}</strong> breaking.</p>
+{{- range .Groups }}
+<h2>{{ .Title }}{{ if .Breaking }} <span class="badge">{{ .Breaking }} breaking</span>{{ end }}</h2>
+<table>
+<tr><th>Change</th><th>Property</th><th>Original</th><th>New</th><th>Line</th><th>Breaking</th></tr>
+{{- range .Entries }}
+<tr{{ if .Breaking }} class="breaking"{{ end }}><td>{{ .ChangeType }}</td><td><code>{{ .Property }}</code></td><td><code>{{ .Original }}</code></td><td><code>{{ .New }}</code></td><td>{{ .Lines }}</td><td>{{ if .Breaking }}<span class="badge">breaking</span>{{ end }}</td></tr>
+{{- end }}
+</table>
+{{- end }}
+{{- end }}
+</body>
+</html>
+`))
+
+// HTML renders the changelog as a self-contained HTML page, with a table of changes for each group.
+func (c *Changelog) HTML() ([]byte, error) {
+	var b bytes.Buffer
+	if err := changelogHTML.Execute(&b, c); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// junitSuites, junitSuite, junitCase and junitFailure are the JUnit XML report format.
+type junitSuites struct {
+	XMLName  xml.Name      `xml:"testsuites"`
+	Name     string        `xml:"name,attr"`
+	Tests    int           `xml:"tests,attr"`
+	Failures int           `xml:"failures,attr"`
+	Suites   []*junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name     string       `xml:"name,attr"`
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Cases    []*junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// JUnit renders the changelog as a JUnit XML report, with a test suite for each group and a test case for each
+// change. Breaking changes are failures, so a CI pipeline that reads JUnit reports fails when a breaking change
+// is made.
+func (c *Changelog) JUnit() ([]byte, error) {
+	report := &junitSuites{Name: "what-changed", Tests: c.Total, Failures: c.Breaking}
+	for _, g := range c.Groups {
+		suite := &junitSuite{Name: g.Title(), Tests: g.Total, Failures: g.Breaking}
+		for _, e := range g.Entries {
+			tc := &junitCase{Name: e.ChangeType + " " + e.Property, ClassName: g.Title()}
+			if e.Breaking {
+				var text []string
+				if e.Original != "" {
+					text = append(text, "original: "+e.Original)
+				}
+				if e.New != "" {
+					text = append(text, "new: "+e.New)
+				}
+				if lines := e.Lines(); lines != "" {
+					text = append(text, "line: "+lines)
+				}
+				tc.Failure = &junitFailure{
+					Message: fmt.Sprintf("breaking change: %s %s", e.ChangeType, e.Property),
+					Type:    "breaking",
+					Text:    strings.Join(text, "\n"),
+				}
+			}
+			suite.Cases = append(suite.Cases, tc)
+		}
+		report.Suites = append(report.Suites, suite)
+	}
+	out, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), out...), nil
+}
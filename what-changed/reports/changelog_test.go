@@ -0,0 +1,196 @@
+// Copyright 2023 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package reports
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi/what-changed/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func createChangelogDiff() *model.DocumentChanges {
+	left := `openapi: 3.1.0
+info:
+  title: pets
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        "200":
+          description: pets
+          content:
+            application/json:
+              schema:
+                type: string
+  /owners:
+    get:
+      responses:
+        "200":
+          description: owners
+components:
+  schemas:
+    Pet:
+      type: object
+      description: a pet`
+
+	right := `openapi: 3.1.0
+info:
+  title: all the pets
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: pets | cats
+          content:
+            application/json:
+              schema:
+                type: integer
+    post:
+      responses:
+        "201":
+          description: created
+components:
+  schemas:
+    Pet:
+      type: object
+      description: a <pet>`
+
+	leftDoc, _ := libopenapi.NewDocument([]byte(left))
+	rightDoc, _ := libopenapi.NewDocument([]byte(right))
+	changes, _ := libopenapi.CompareDocuments(leftDoc, rightDoc)
+	return changes
+}
+
+func findChangelogGroup(cl *Changelog, title string) *ChangelogGroup {
+	for _, g := range cl.Groups {
+		if g.Title() == title {
+			return g
+		}
+	}
+	return nil
+}
+
+func TestCreateChangelog(t *testing.T) {
+	changes := createChangelogDiff()
+	cl := CreateChangelog(changes)
+	assert.Equal(t, changes.TotalChanges(), cl.Total)
+	assert.Equal(t, changes.TotalBreakingChanges(), cl.Breaking)
+
+	var titles []string
+	for _, g := range cl.Groups {
+		titles = append(titles, g.Title())
+	}
+	assert.Equal(t, []string{"info", "/owners", "GET /pets", "POST /pets", "components Pet"}, titles)
+
+	get := findChangelogGroup(cl, "GET /pets")
+	assert.Equal(t, 3, get.Total)
+	assert.Equal(t, 2, get.Breaking)
+	assert.Equal(t, "removed", get.Entries[0].ChangeType)
+	assert.Equal(t, "operationId", get.Entries[0].Property)
+	assert.Equal(t, "listPets", get.Entries[0].Original)
+	assert.Equal(t, 7, get.Entries[0].OriginalLine)
+	assert.Equal(t, "type", get.Entries[2].Property)
+	assert.Equal(t, "string", get.Entries[2].Original)
+	assert.Equal(t, "integer", get.Entries[2].New)
+	assert.True(t, get.Entries[2].Breaking)
+	assert.Equal(t, "14 → 13", get.Entries[2].Lines())
+	assert.Equal(t, "paths./pets.get.responses.200.content.application/json.schema.type", get.Entries[2].Path)
+	assert.Len(t, get.Entries[2].Fingerprint, 64)
+
+	owners := findChangelogGroup(cl, "/owners")
+	assert.Equal(t, "removed", owners.Entries[0].ChangeType)
+	assert.True(t, owners.Entries[0].Breaking)
+
+	post := findChangelogGroup(cl, "POST /pets")
+	assert.Equal(t, "added", post.Entries[0].ChangeType)
+	assert.False(t, post.Entries[0].Breaking)
+
+	assert.Equal(t, 0, CreateChangelog(nil).Total)
+}
+
+func TestCreateChangelog_BurgerShop(t *testing.T) {
+	changes := createDiff()
+	cl := CreateChangelog(changes)
+	assert.Equal(t, changes.TotalChanges(), cl.Total)
+	assert.Equal(t, changes.TotalBreakingChanges(), cl.Breaking)
+
+	total, breaking := 0, 0
+	for _, g := range cl.Groups {
+		assert.Len(t, g.Entries, g.Total)
+		total += g.Total
+		breaking += g.Breaking
+	}
+	assert.Equal(t, cl.Total, total)
+	assert.Equal(t, cl.Breaking, breaking)
+	assert.Equal(t, "document", cl.Groups[0].Section)
+}
+
+func TestChangelog_Markdown(t *testing.T) {
+	md := string(CreateChangelog(createChangelogDiff()).Markdown())
+	assert.Contains(t, md, "**7** changes, **3** breaking.")
+	assert.Contains(t, md, "\n## GET /pets\n")
+	assert.Contains(t, md, "| modified | `type` | `string` | `integer` | 14 → 13 | **yes** |")
+	assert.Contains(t, md, "`pets \\| cats`")
+
+	assert.Contains(t, string(CreateChangelog(nil).Markdown()), "No changes found.")
+}
+
+func TestChangelog_HTML(t *testing.T) {
+	out, err := CreateChangelog(createChangelogDiff()).HTML()
+	assert.NoError(t, err)
+	page := string(out)
+	assert.Contains(t, page, "<!DOCTYPE html>")
+	assert.Contains(t, page, "<style>")
+	assert.Contains(t, page, "<h2>GET /pets <span class=\"badge\">2 breaking</span></h2>")
+	assert.Contains(t, page, "<code>a &lt;pet&gt;</code>")
+	assert.NotContains(t, page, "<script")
+}
+
+func TestChangelog_JSON(t *testing.T) {
+	out, err := CreateChangelog(createChangelogDiff()).JSON()
+	assert.NoError(t, err)
+
+	var cl Changelog
+	assert.NoError(t, json.Unmarshal(out, &cl))
+	assert.Equal(t, 7, cl.Total)
+	assert.Equal(t, 3, cl.Breaking)
+	assert.Equal(t, "paths", cl.Groups[2].Section)
+	assert.Equal(t, "/pets", cl.Groups[2].Name)
+	assert.Equal(t, "get", cl.Groups[2].Method)
+	assert.Contains(t, string(out), `"change": "modified"`)
+}
+
+func TestChangelog_JUnit(t *testing.T) {
+	out, err := CreateChangelog(createChangelogDiff()).JUnit()
+	assert.NoError(t, err)
+
+	var report junitSuites
+	assert.NoError(t, xml.Unmarshal(out, &report))
+	assert.Equal(t, 7, report.Tests)
+	assert.Equal(t, 3, report.Failures)
+	assert.Len(t, report.Suites, 5)
+
+	get := report.Suites[2]
+	assert.Equal(t, "GET /pets", get.Name)
+	assert.Equal(t, 2, get.Failures)
+	assert.Equal(t, "modified type", get.Cases[2].Name)
+	assert.Equal(t, "breaking", get.Cases[2].Failure.Type)
+	assert.Equal(t, "original: string\nnew: integer\nline: 14 → 13", get.Cases[2].Failure.Text)
+	assert.Nil(t, get.Cases[1].Failure)
+}
+
+func TestChangeTypeName(t *testing.T) {
+	assert.Equal(t, "modified", ChangeTypeName(model.Modified))
+	assert.Equal(t, "added", ChangeTypeName(model.PropertyAdded))
+	assert.Equal(t, "removed", ChangeTypeName(model.ObjectRemoved))
+	assert.Equal(t, "renamed", ChangeTypeName(model.Renamed))
+	assert.Equal(t, "moved", ChangeTypeName(model.Moved))
+	assert.Equal(t, "unknown", ChangeTypeName(0))
+}
@@ -36,3 +36,24 @@ func TestCreateSummary_OverallReport(t *testing.T) {
     assert.Equal(t, 17, report.ChangeReport[v3.ComponentsLabel].Total)
     assert.Equal(t, 6, report.ChangeReport[v3.ComponentsLabel].Breaking)
 }
+
+func TestCreateSummary_OverallReport_BreakingRules(t *testing.T) {
+    burgerShopOriginal, _ := ioutil.ReadFile("../../test_specs/burgershop.openapi.yaml")
+    burgerShopUpdated, _ := ioutil.ReadFile("../../test_specs/burgershop.openapi-modified.yaml")
+    originalDoc, _ := libopenapi.NewDocument(burgerShopOriginal)
+    updatedDoc, _ := libopenapi.NewDocument(burgerShopUpdated)
+
+    rules, _ := model.LoadBreakingRules([]byte(`license:
+  '*':
+    modified: true
+serverVariable:
+  enum:
+    removed: false`))
+    changes, _ := libopenapi.CompareDocuments(originalDoc, updatedDoc, rules)
+    report := CreateOverallReport(changes)
+    assert.Equal(t, 1, report.ChangeReport[v3.InfoLabel].Total)
+    assert.Equal(t, 1, report.ChangeReport[v3.InfoLabel].Breaking)
+    assert.Equal(t, 2, report.ChangeReport[v3.ServersLabel].Total)
+    assert.Equal(t, 0, report.ChangeReport[v3.ServersLabel].Breaking)
+    assert.Equal(t, 9, report.ChangeReport[v3.PathsLabel].Breaking)
+}
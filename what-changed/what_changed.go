@@ -24,13 +24,25 @@ import (
 // CompareOpenAPIDocuments will compare left (original) and right (updated) OpenAPI 3+ documents and extract every change
 // made across the entire specification. The report outlines every property changed, everything that was added,
 // or removed and which of those changes were breaking.
-func CompareOpenAPIDocuments(original, updated *v3.Document) *model.DocumentChanges {
-	return model.CompareDocuments(original, updated)
+//
+// Any model.BreakingRules supplied override which changes are breaking, they are applied in order.
+func CompareOpenAPIDocuments(original, updated *v3.Document, rules ...model.BreakingRules) *model.DocumentChanges {
+	return applyBreakingRules(model.CompareDocuments(original, updated), rules)
 }
 
 // CompareSwaggerDocuments will compare left (original) and a right (updated) Swagger documents and extract every change
 // made across the entire specification. The report outlines every property changes, everything that was added,
 // or removed and which of those changes were breaking.
-func CompareSwaggerDocuments(original, updated *v2.Swagger) *model.DocumentChanges {
-	return model.CompareDocuments(original, updated)
+//
+// Any model.BreakingRules supplied override which changes are breaking, they are applied in order.
+func CompareSwaggerDocuments(original, updated *v2.Swagger, rules ...model.BreakingRules) *model.DocumentChanges {
+	return applyBreakingRules(model.CompareDocuments(original, updated), rules)
+}
+
+// applyBreakingRules applies rules to changes, in order.
+func applyBreakingRules(changes *model.DocumentChanges, rules []model.BreakingRules) *model.DocumentChanges {
+	for _, r := range rules {
+		r.Apply(changes)
+	}
+	return changes
 }
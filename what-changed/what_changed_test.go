@@ -8,6 +8,7 @@ import (
 	"github.com/pb33f/libopenapi/datamodel"
 	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
 	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/pb33f/libopenapi/what-changed/model"
 	"github.com/stretchr/testify/assert"
 	"io/ioutil"
 	"testing"
@@ -49,6 +50,56 @@ func TestCompareSwaggerDocuments(t *testing.T) {
 
 }
 
+func TestCompareOpenAPIDocuments_BreakingRules(t *testing.T) {
+
+	original, _ := ioutil.ReadFile("../test_specs/burgershop.openapi.yaml")
+	modified, _ := ioutil.ReadFile("../test_specs/burgershop.openapi-modified.yaml")
+	infoOrig, _ := datamodel.ExtractSpecInfo(original)
+	infoMod, _ := datamodel.ExtractSpecInfo(modified)
+
+	origDoc, _ := v3.CreateDocument(infoOrig)
+	modDoc, _ := v3.CreateDocument(infoMod)
+
+	nothingBreaks, _ := model.LoadBreakingRules([]byte(`'*':
+  '*':
+    added: false
+    modified: false
+    removed: false`))
+	changes := CompareOpenAPIDocuments(origDoc, modDoc, nothingBreaks)
+	assert.Equal(t, 72, changes.TotalChanges())
+	assert.Equal(t, 0, changes.TotalBreakingChanges())
+
+	// later rules win.
+	licenseBreaks, _ := model.LoadBreakingRules([]byte(`license:
+  '*':
+    modified: true`))
+	changes = CompareOpenAPIDocuments(origDoc, modDoc, nothingBreaks, licenseBreaks)
+	assert.Equal(t, 1, changes.TotalBreakingChanges())
+}
+
+func TestCompareSwaggerDocuments_BreakingRules(t *testing.T) {
+
+	original, _ := ioutil.ReadFile("../test_specs/petstorev2-complete.yaml")
+	modified, _ := ioutil.ReadFile("../test_specs/petstorev2-complete-modified.yaml")
+	infoOrig, _ := datamodel.ExtractSpecInfo(original)
+	infoMod, _ := datamodel.ExtractSpecInfo(modified)
+
+	origDoc, _ := v2.CreateDocument(infoOrig)
+	modDoc, _ := v2.CreateDocument(infoMod)
+
+	rules, _ := model.LoadBreakingRules([]byte(`'*':
+  '*':
+    removed: false`))
+	changes := CompareSwaggerDocuments(origDoc, modDoc, rules)
+	assert.Equal(t, 52, changes.TotalChanges())
+	assert.Less(t, changes.TotalBreakingChanges(), 27)
+	for _, c := range changes.GetAllChanges() {
+		if c.ChangeType == model.PropertyRemoved || c.ChangeType == model.ObjectRemoved {
+			assert.False(t, c.Breaking)
+		}
+	}
+}
+
 func Benchmark_CompareOpenAPIDocuments(b *testing.B) {
 
 	original, _ := ioutil.ReadFile("../test_specs/burgershop.openapi.yaml")